@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/PaulLocust/Avito-review/config"
 	"github.com/PaulLocust/Avito-review/internal/app"
@@ -14,5 +15,13 @@ func main() {
 		log.Fatalf("Config error: %s", err)
 	}
 
+	// Subcommand: migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Migrate error: %s", err)
+		}
+		return
+	}
+
 	app.Run(cfg)
 }
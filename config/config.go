@@ -2,16 +2,25 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type (
 	Config struct {
-		Log  Log
-		DB   DB
-		HTTP HTTP
-		PG   PG
+		Log      Log
+		DB       DB
+		HTTP     HTTP
+		PG       PG
+		Reviewer Reviewer
+		Storage  Storage
+		Webhook  Webhook
+		Outbox   Outbox
+		Auth     Auth
+
+		Idempotency    Idempotency
+		Unavailability Unavailability
 	}
 
 	HTTP struct {
@@ -31,6 +40,58 @@ type (
 		SSLMode  string `env:"DB_SSL,required"`
 	}
 
+	Storage struct {
+		Type string `env:"STORAGE" envDefault:"postgres"` // postgres | memory
+	}
+
+	Reviewer struct {
+		Strategy string `env:"REVIEWER_STRATEGY" envDefault:"random"`
+	}
+
+	Webhook struct {
+		Workers     int           `env:"WEBHOOK_WORKERS" envDefault:"4"`
+		MaxAttempts int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"5"`
+		Backoff     time.Duration `env:"WEBHOOK_BACKOFF" envDefault:"1s"`
+		Timeout     time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"5s"`
+	}
+
+	Outbox struct {
+		Interval  time.Duration `env:"OUTBOX_INTERVAL" envDefault:"1s"`
+		BatchSize int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	}
+
+	Auth struct {
+		Enabled      bool   `env:"AUTH_ENABLED" envDefault:"true"`
+		BootstrapKey string `env:"AUTH_BOOTSTRAP_KEY"` // ключ администратора для выдачи первых ключей
+		JWT          JWT
+	}
+
+	// JWT - проверка bearer-токенов SSO; токены принимаются, если задан хотя бы один ключ
+	JWT struct {
+		HS256Secret string        `env:"AUTH_JWT_HS256_SECRET"`
+		KeyFile     string        `env:"AUTH_JWT_KEY_FILE"`  // PEM с публичным RSA-ключом
+		JWKSFile    string        `env:"AUTH_JWT_JWKS_FILE"` // JWKS с RSA-ключами
+		Issuer      string        `env:"AUTH_JWT_ISSUER"`
+		Audience    string        `env:"AUTH_JWT_AUDIENCE"`
+		Leeway      time.Duration `env:"AUTH_JWT_LEEWAY" envDefault:"30s"`
+		UserClaim   string        `env:"AUTH_JWT_USER_CLAIM" envDefault:"sub"`
+		RoleClaim   string        `env:"AUTH_JWT_ROLE_CLAIM" envDefault:"role"`
+		DefaultRole string        `env:"AUTH_JWT_DEFAULT_ROLE" envDefault:"bot"`
+	}
+
+	// Idempotency - хранение ответов на запросы с заголовком Idempotency-Key
+	Idempotency struct {
+		TTL           time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+		Lease         time.Duration `env:"IDEMPOTENCY_LEASE" envDefault:"1m"` // срок резерва ключа незавершенным запросом
+		PurgeInterval time.Duration `env:"IDEMPOTENCY_PURGE_INTERVAL" envDefault:"1h"`
+	}
+
+	// Unavailability - переназначение открытых ревью при начале периода недоступности пользователя
+	Unavailability struct {
+		// ReassignInterval - период проверки начавшихся периодов, 0 отключает переназначение
+		ReassignInterval time.Duration `env:"UNAVAILABILITY_REASSIGN_INTERVAL" envDefault:"0"`
+	}
+
 	PG struct {
 		URL     string `env:"PG_URL"`
 		PoolMax int    `env:"PG_POOL_MAX"`
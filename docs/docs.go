@@ -15,9 +15,59 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/pullRequest/create": {
+        "/apiKeys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает выданные ключи без их значений, включая отозванные",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Список API-ключей",
+                "responses": {
+                    "200": {
+                        "description": "Список ключей",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Ключ не передан или недействителен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Создает новый pull request и автоматически назначает до двух активных ревьюверов из команды автора",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выдает ключ с ролью admin, team-lead, bot или read-only. Ключ возвращается только в этом ответе, в базе хранится его SHA-256",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,36 +75,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PullRequests"
+                    "Auth"
                 ],
-                "summary": "Создать PR и автоматически назначить до 2 ревьюверов из команды автора",
+                "summary": "Выдать API-ключ",
                 "parameters": [
                     {
-                        "description": "Данные PR",
+                        "description": "Название и роль ключа",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestCreateJSONBody"
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostApiKeysJSONBody"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "PR создан",
+                        "description": "Ключ выдан",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Автор/команда не найдены",
+                    "400": {
+                        "description": "Некорректное название или роль",
                         "schema": {
                             "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "PR уже существует",
+                    "401": {
+                        "description": "Ключ не передан или недействителен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
                         "schema": {
                             "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
@@ -62,9 +130,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/pullRequest/merge": {
+        "/apiKeys/revoke": {
             "post": {
-                "description": "Изменяет статус PR на MERGED. Операция идемпотентна - повторный вызов не приводит к ошибке",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отозванный ключ перестает проходить аутентификацию. Повторный отзыв не меняет время отзыва",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,30 +148,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PullRequests"
+                    "Auth"
                 ],
-                "summary": "Пометить PR как MERGED (идемпотентная операция)",
+                "summary": "Отозвать API-ключ",
                 "parameters": [
                     {
-                        "description": "Данные PR",
+                        "description": "Идентификатор ключа",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestMergeJSONBody"
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostApiKeysRevokeJSONBody"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "PR в состоянии MERGED",
+                        "description": "Ключ отозван",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Ключ не передан или недействителен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "PR не найден",
+                        "description": "Ключ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
                         "schema": {
                             "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
@@ -103,9 +209,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/pullRequest/reassign": {
+        "/pullRequest/close": {
             "post": {
-                "description": "Заменяет одного ревьювера на случайного активного участника из команды заменяемого ревьювера",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переводит PR из OPEN или DRAFT в CLOSED. Ревьюверы остаются назначенными, но закрытый PR не учитывается в их нагрузке",
                 "consumes": [
                     "application/json"
                 ],
@@ -115,34 +229,46 @@ const docTemplate = `{
                 "tags": [
                     "PullRequests"
                 ],
-                "summary": "Переназначить конкретного ревьювера на другого из его команды",
+                "summary": "Закрыть PR без мерджа (идемпотентная операция)",
                 "parameters": [
                     {
-                        "description": "Данные для переназначения",
+                        "description": "Данные PR",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReassignJSONBody"
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestCloseJSONBody"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Переназначение выполнено",
+                        "description": "PR в состоянии CLOSED",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "PR или пользователь не найден",
+                        "description": "PR не найден",
                         "schema": {
                             "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Нарушение доменных правил переназначения",
+                        "description": "Смердженный PR нельзя закрыть",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
                         "schema": {
                             "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
@@ -150,9 +276,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/team/add": {
+        "/pullRequest/create": {
             "post": {
-                "description": "Создает новую команду и обновляет/создает пользователей",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает новый pull request и автоматически назначает активных ревьюверов из команды автора согласно настройкам команды (по умолчанию до двух). Черновик (draft: true) создается без ревьюверов",
                 "consumes": [
                     "application/json"
                 ],
@@ -160,30 +294,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "PullRequests"
                 ],
-                "summary": "Создать команду с участниками (создаёт/обновляет пользователей)",
+                "summary": "Создать PR и автоматически назначить до 2 ревьюверов из команды автора",
                 "parameters": [
                     {
-                        "description": "Данные команды",
-                        "name": "team",
+                        "description": "Данные PR",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.Team"
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestCreateJSONBody"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Команда создана",
+                        "description": "PR создан",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Команда уже существует",
+                    "404": {
+                        "description": "Автор/команда не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR уже существует или недостаточно ревьюверов",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
                         "schema": {
                             "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
@@ -191,9 +343,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/team/get": {
+        "/pullRequest/get": {
             "get": {
-                "description": "Возвращает информацию о команде и её участниках",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает PR со списком ревьюверов, их решениями, временными метками и командой автора",
                 "consumes": [
                     "application/json"
                 ],
@@ -201,31 +361,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "PullRequests"
                 ],
-                "summary": "Получить команду с участниками",
+                "summary": "Получить PR с ревьюверами и командой автора",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Уникальное имя команды",
-                        "name": "team_name",
+                        "description": "Идентификатор PR",
+                        "name": "pull_request_id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Объект команды",
+                        "description": "PR",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.Team"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Не указан pull_request_id",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/users/getReview": {
+        "/pullRequest/history": {
             "get": {
-                "description": "Возвращает список pull requests, назначенных пользователю на ревью",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает события PR в порядке записи: создание, назначение, замену и снятие ревьюверов (со старым и новым ревьювером и причиной), смену статуса. Инициатор - владелец API-ключа или пользователь токена, admin и bot могут передать другого в заголовке X-Actor. Без аутентификации и X-Actor записывается system",
                 "consumes": [
                     "application/json"
                 ],
@@ -233,32 +414,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "PullRequests"
                 ],
-                "summary": "Получить PR'ы, где пользователь назначен ревьювером",
+                "summary": "Получить журнал изменений PR",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Идентификатор пользователя",
-                        "name": "user_id",
+                        "description": "Идентификатор PR",
+                        "name": "pull_request_id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список PR'ов пользователя",
+                        "description": "Журнал изменений PR",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PullRequestHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Не указан pull_request_id",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/users/setIsActive": {
-            "post": {
-                "description": "Изменяет статус активности пользователя",
+        "/pullRequest/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает PR, отсортированные по времени создания. Для следующей страницы передайте next_cursor в параметре cursor с теми же фильтрами",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,108 +466,2673 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "PullRequests"
                 ],
-                "summary": "Установить флаг активности пользователя",
+                "summary": "Список PR с фильтрами и курсорной пагинацией",
                 "parameters": [
                     {
-                        "description": "Данные пользователя",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostUsersSetIsActiveJSONBody"
-                        }
+                        "type": "string",
+                        "description": "Статус PR (DRAFT, OPEN, MERGED, CLOSED)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Автор PR",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Назначенный ревьювер",
+                        "name": "reviewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Команда автора PR",
+                        "name": "team_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подстрока названия PR (без учёта регистра)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PR, созданные не раньше этого момента (RFC3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PR, созданные раньше этого момента (RFC3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PR, смердженные не раньше этого момента (RFC3339)",
+                        "name": "merged_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PR, смердженные раньше этого момента (RFC3339)",
+                        "name": "merged_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка по времени создания: asc или desc (по умолчанию desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (1..100, по умолчанию 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor предыдущей страницы",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновлённый пользователь",
+                        "description": "Страница PR",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PullRequestPage"
                         }
                     },
-                    "404": {
-                        "description": "Пользователь не найден",
+                    "400": {
+                        "description": "Некорректные параметры",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Изменяет статус PR на MERGED. Операция идемпотентна - повторный вызов не приводит к ошибке. Если в настройках команды автора задан required_approvals, PR без нужного числа одобрений не мерджится",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Пометить PR как MERGED (идемпотентная операция)",
+                "parameters": [
+                    {
+                        "description": "Данные PR",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestMergeJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR в состоянии MERGED",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Мерджить PR может только его автор или администратор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Недостаточно одобрений или PR в статусе DRAFT/CLOSED",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/ready": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переводит PR из DRAFT в OPEN и назначает ревьюверов согласно настройкам команды автора",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Перевести черновик в OPEN и назначить ревьюверов",
+                "parameters": [
+                    {
+                        "description": "Данные PR",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReadyJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR в состоянии OPEN",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "PR не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Готовым можно пометить только черновик",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/reassign": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет одного ревьювера на случайного активного участника из команды заменяемого ревьювера",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Переназначить конкретного ревьювера на другого из его команды",
+                "parameters": [
+                    {
+                        "description": "Данные для переназначения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReassignJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Переназначение выполнено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "PR или пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Нарушение доменных правил переназначения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/reopen": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Переводит PR из CLOSED в OPEN. Если у PR нет ревьюверов (был закрыт черновик), они назначаются как при создании",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Переоткрыть закрытый PR",
+                "parameters": [
+                    {
+                        "description": "Данные PR",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReopenJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR в состоянии OPEN",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "PR не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Переоткрыть можно только закрытый PR",
                         "schema": {
                             "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
                         }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/review": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет решение назначенного ревьювера (APPROVED, CHANGES_REQUESTED, COMMENTED). Повторный вызов перезаписывает решение. Пользователь токена фиксирует решение только от своего имени, от имени любого ревьювера действуют admin и API-ключи bot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Зафиксировать решение ревьювера по PR",
+                "parameters": [
+                    {
+                        "description": "Решение ревьювера",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReviewJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Решение сохранено",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректное состояние ревью",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Решение от имени другого ревьювера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PR не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR уже смерджен или пользователь не назначен ревьювером",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/assignments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает количество назначений по пользователям и командам (всего, открытых, смердженных) и количество ревьюверов по PR",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Статистика назначений ревьюверов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ограничить статистику командой",
+                        "name": "team_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Учитывать PR, созданные не раньше этого момента (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Учитывать PR, созданные раньше этого момента (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статистика назначений",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.AssignmentStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/add": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает новую команду и обновляет/создает пользователей",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Создать команду с участниками (создаёт/обновляет пользователей)",
+                "parameters": [
+                    {
+                        "description": "Данные команды",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.Team"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Команда создана",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Команда уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Команда пользователя токена не совпадает с создаваемой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/addMembers": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает или обновляет пользователей в существующей команде. Открытые ревью участников, перешедших из других команд, переназначаются на активных участников прежних команд",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Добавить участников в команду",
+                "parameters": [
+                    {
+                        "description": "Команда и участники",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostTeamAddMembersJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состав команды и замены в ревью перешедших участников",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь токена не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/deactivateUsers": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "В одной транзакции деактивирует пользователей и заменяет их в открытых PR активными участниками команды (или снимает, если замены нет)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Массово деактивировать пользователей команды и переназначить их открытые ревью",
+                "parameters": [
+                    {
+                        "description": "Команда и пользователи",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostTeamDeactivateUsersJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт о деактивации",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamDeactivation"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь токена не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда или пользователь не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/delete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Мягко удаляет команду и ее участников, их открытые ревью снимаются. Если у авторов из команды есть открытые или черновые PR, удаление отклоняется, а с force=true они закрываются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Удалить команду",
+                "parameters": [
+                    {
+                        "description": "Команда",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostTeamDeleteJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт об удалении",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamDeletion"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь токена не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "У команды есть незавершенные PR",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/get": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию о команде и её участниках",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Получить команду с участниками",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальное имя команды",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Объект команды",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.Team"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает неудаленные команды с количеством участников, отсортированные по имени",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Список команд",
+                "responses": {
+                    "200": {
+                        "description": "Список команд",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/team/removeMembers": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Мягко удаляет пользователей команды и переназначает их открытые ревью на оставшихся активных участников",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Удалить участников команды",
+                "parameters": [
+                    {
+                        "description": "Команда и пользователи",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostTeamRemoveMembersJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состав команды и замены в ревью удаленных участников",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь токена не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда или пользователь не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/rename": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет имя команды; команда пользователей и настройки переносятся на новое имя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Переименовать команду",
+                "parameters": [
+                    {
+                        "description": "Текущее и новое имя команды",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostTeamRenameJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Переименованная команда",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос или команда с новым именем уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь токена не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/settings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает настройки команды (или настройки по умолчанию, если они не заданы)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Получить настройки назначения ревьюверов команды",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальное имя команды",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Настройки команды",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamSettings"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задает количество ревьюверов, минимально необходимое количество, стратегию выбора и лимит открытых ревью участника (max_open_reviews) для команды",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Задать настройки назначения ревьюверов команды",
+                "parameters": [
+                    {
+                        "description": "Настройки команды",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamSettings"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Настройки сохранены",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные настройки",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь токена не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/sync": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Принимает полный желаемый список участников: создает команду, если ее нет, добавляет и обновляет пользователей, деактивирует отсутствующих в списке и переназначает их открытые ревью. С dry_run=true возвращает расхождение без применения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Синхронизировать состав команды",
+                "parameters": [
+                    {
+                        "description": "Желаемый состав команды",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.Team"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только рассчитать расхождение",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Расхождение и примененные изменения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamSync"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь токена не состоит в команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/delete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Помечает пользователя удаленным и неактивным, его открытые ревью переназначаются на активных участников команды. Повторное добавление через /team/add восстанавливает пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удалить пользователя",
+                "parameters": [
+                    {
+                        "description": "Идентификатор пользователя",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostUsersDeleteJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленный пользователь и замены в его ревью",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.UserChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Не передан user_id",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь состоит в другой команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/get": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает пользователя по идентификатору (удаленные пользователи не возвращаются) и его загрузку: число открытых ревью и действующий лимит max_open_reviews",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получить пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь и его загрузка",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Не передан user_id",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/getReview": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список pull requests, назначенных пользователю на ревью",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получить PR'ы, где пользователь назначен ревьювером",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список PR'ов пользователя",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает неудаленных пользователей, отсортированных по user_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Список пользователей",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Команда пользователей",
+                        "name": "team_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Флаг активности",
+                        "name": "is_active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список пользователей",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Команда не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/setIsActive": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Изменяет статус активности пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Установить флаг активности пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostUsersSetIsActiveJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённый пользователь",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь состоит в другой команде",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/unavailability": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает прошедшие, текущие и будущие периоды пользователя, отсортированные по началу",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Периоды недоступности пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Периоды недоступности",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Не передан user_id",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Во время периода [from, to) пользователь не выбирается ревьювером. Если включено фоновое переназначение, его открытые ревью переходят к другим участникам команды, когда период начинается",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Добавить период недоступности",
+                "parameters": [
+                    {
+                        "description": "Пользователь, границы периода в RFC3339 и причина",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostUsersUnavailabilityJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Период добавлен",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные границы периода",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь из другой команды",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/unavailability/delete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет период; уже переназначенные ревью не возвращаются пользователю",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удалить период недоступности",
+                "parameters": [
+                    {
+                        "description": "Идентификатор периода",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostUsersUnavailabilityDeleteJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Период удален",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Не передан id",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь из другой команды",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Период не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/unavailability/update": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет границы и причину периода. После переноса границ открытые ревью снова переназначаются, когда начнется новый период",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Изменить период недоступности",
+                "parameters": [
+                    {
+                        "description": "Идентификатор периода, новые границы в RFC3339 и причина",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostUsersUnavailabilityUpdateJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Период изменен",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные границы периода",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь из другой команды",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Период не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/update": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет имя, команду и/или личный лимит открытых ревью пользователя (max_open_reviews, 0 - лимит команды). При переводе в другую команду открытые ревью пользователя переназначаются на активных участников прежней команды. Переводить может пользователь токена из прежней команды, новая команда должна существовать",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Изменить пользователя",
+                "parameters": [
+                    {
+                        "description": "Изменения пользователя",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostUsersUpdateJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённый пользователь и замены в его ревью",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.UserChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные изменения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь вне команды пользователя токена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь или команда не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает зарегистрированные вебхуки без секретов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Список вебхуков",
+                "responses": {
+                    "200": {
+                        "description": "Список вебхуков",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Регистрирует вебхук. События отправляются POST-запросом с JSON-телом и подписью X-Webhook-Signature: sha256=<HMAC-SHA256 тела по секрету>. Если secret не передан, он генерируется; секрет возвращается только в ответе на создание. Неуспешные доставки повторяются с экспоненциальной задержкой",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Подписать URL на события сервиса",
+                "parameters": [
+                    {
+                        "description": "URL и типы событий",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostWebhooksJSONBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Вебхук создан",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный URL или тип события",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает последние доставки вебхука (новые первыми): статус, число попыток, код ответа и ошибку последней попытки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Журнал доставок вебхука",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор вебхука",
+                        "name": "webhook_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (1..100, по умолчанию 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Журнал доставок",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Вебхук не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_PaulLocust_Avito-review_internal_dto.ApiKeyRole": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "bot",
+                "read-only",
+                "team-lead"
+            ],
+            "x-enum-varnames": [
+                "Admin",
+                "Bot",
+                "ReadOnly",
+                "TeamLead"
+            ]
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.AssignmentStats": {
+            "type": "object",
+            "properties": {
+                "pull_requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PRReviewerStats"
+                    }
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamAssignmentStats"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.UserAssignmentStats"
+                    }
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "object",
+                    "properties": {
+                        "code": {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponseErrorCode"
+                        },
+                        "message": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.ErrorResponseErrorCode": {
+            "type": "string",
+            "enum": [
+                "FORBIDDEN",
+                "IDEMPOTENCY_KEY_REUSED",
+                "INVALID_INPUT",
+                "INVALID_TRANSITION",
+                "NO_CANDIDATE",
+                "NOT_APPROVED",
+                "NOT_ASSIGNED",
+                "NOT_FOUND",
+                "PR_CLOSED",
+                "PR_EXISTS",
+                "PR_MERGED",
+                "REQUEST_IN_PROGRESS",
+                "TEAM_EXISTS",
+                "TEAM_HAS_OPEN_PRS",
+                "UNAUTHORIZED"
+            ],
+            "x-enum-varnames": [
+                "FORBIDDEN",
+                "IDEMPOTENCYKEYREUSED",
+                "INVALIDINPUT",
+                "INVALIDTRANSITION",
+                "NOCANDIDATE",
+                "NOTAPPROVED",
+                "NOTASSIGNED",
+                "NOTFOUND",
+                "PRCLOSED",
+                "PREXISTS",
+                "PRMERGED",
+                "REQUESTINPROGRESS",
+                "TEAMEXISTS",
+                "TEAMHASOPENPRS",
+                "UNAUTHORIZED"
+            ]
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PRReviewerStats": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "reviewer_count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PRReviewerStatsStatus"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PRReviewerStatsStatus": {
+            "type": "string",
+            "enum": [
+                "CLOSED",
+                "DRAFT",
+                "MERGED",
+                "OPEN"
+            ],
+            "x-enum-varnames": [
+                "PRReviewerStatsStatusCLOSED",
+                "PRReviewerStatsStatusDRAFT",
+                "PRReviewerStatsStatusMERGED",
+                "PRReviewerStatsStatusOPEN"
+            ]
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostApiKeysJSONBody": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ApiKeyRole"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostApiKeysRevokeJSONBody": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestCloseJSONBody": {
+            "type": "object",
+            "properties": {
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestCreateJSONBody": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "draft": {
+                    "description": "Draft Создать черновик (DRAFT) без ревьюверов",
+                    "type": "boolean"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestMergeJSONBody": {
+            "type": "object",
+            "properties": {
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReadyJSONBody": {
+            "type": "object",
+            "properties": {
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReassignJSONBody": {
+            "type": "object",
+            "properties": {
+                "old_user_id": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReopenJSONBody": {
+            "type": "object",
+            "properties": {
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReviewJSONBody": {
+            "type": "object",
+            "properties": {
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReviewJSONBodyState"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReviewJSONBodyState": {
+            "type": "string",
+            "enum": [
+                "APPROVED",
+                "CHANGES_REQUESTED",
+                "COMMENTED"
+            ],
+            "x-enum-varnames": [
+                "PostPullRequestReviewJSONBodyStateAPPROVED",
+                "PostPullRequestReviewJSONBodyStateCHANGESREQUESTED",
+                "PostPullRequestReviewJSONBodyStateCOMMENTED"
+            ]
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostTeamAddMembersJSONBody": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamMember"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostTeamDeactivateUsersJSONBody": {
+            "type": "object",
+            "properties": {
+                "team_name": {
+                    "type": "string"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostTeamDeleteJSONBody": {
+            "type": "object",
+            "properties": {
+                "force": {
+                    "description": "Force Закрыть незавершенные PR авторов из команды вместо отказа",
+                    "type": "boolean"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostTeamRemoveMembersJSONBody": {
+            "type": "object",
+            "properties": {
+                "team_name": {
+                    "type": "string"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostTeamRenameJSONBody": {
+            "type": "object",
+            "properties": {
+                "new_team_name": {
+                    "description": "NewTeamName Новое имя команды",
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostUsersDeleteJSONBody": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostUsersSetIsActiveJSONBody": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostUsersUnavailabilityDeleteJSONBody": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostUsersUnavailabilityJSONBody": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From Начало периода (включительно)",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason Причина (отпуск, больничный)",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "To Конец периода (не включительно)",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostUsersUnavailabilityUpdateJSONBody": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From Начало периода (включительно)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Reason Причина (отпуск, больничный)",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "To Конец периода (не включительно)",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostUsersUpdateJSONBody": {
+            "type": "object",
+            "properties": {
+                "max_open_reviews": {
+                    "description": "MaxOpenReviews Личный лимит открытых ревью (0 - лимит команды)",
+                    "type": "integer"
+                },
+                "team_name": {
+                    "description": "TeamName Новая команда; открытые ревью пользователя переназначаются внутри прежней команды",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Username Новое имя пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PostWebhooksJSONBody": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.WebhookEventType"
+                    }
+                },
+                "secret": {
+                    "description": "Secret Ключ HMAC-подписи (если не задан - генерируется)",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PullRequest": {
+            "type": "object",
+            "properties": {
+                "assigned_reviewers": {
+                    "description": "AssignedReviewers user_id назначенных ревьюверов (0..2)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "author_team": {
+                    "description": "AuthorTeam Команда автора (только в /pullRequest/get)",
+                    "type": "string"
+                },
+                "capacity_warning": {
+                    "description": "CapacityWarning Назначено меньше reviewer_count ревьюверов, потому что остальные участники достигли max_open_reviews (только в ответе на назначение)",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "mergedAt": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "reviews": {
+                    "description": "Reviews Решения назначенных ревьюверов",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.Review"
                     }
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PullRequestStatus"
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_PaulLocust_Avito-review_internal_dto.ErrorResponse": {
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PullRequestEvent": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "object",
-                    "properties": {
-                        "code": {
-                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ErrorResponseErrorCode"
-                        },
-                        "message": {
-                            "type": "string"
-                        }
+                "actor": {
+                    "description": "Actor Инициатор изменения (владелец ключа или пользователь токена, X-Actor от admin и bot, по умолчанию system)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_user_id": {
+                    "description": "NewUserId Назначенный ревьювер",
+                    "type": "string"
+                },
+                "old_user_id": {
+                    "description": "OldUserId Снятый ревьювер",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason Причина изменения состава ревьюверов (create, ready, reopen, reassign, deactivation, user_moved, user_deleted, unavailable)",
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PullRequestEventType"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PullRequestEventType": {
+            "type": "string",
+            "enum": [
+                "closed",
+                "created",
+                "merged",
+                "ready_for_review",
+                "reopened",
+                "reviewer_assigned",
+                "reviewer_removed",
+                "reviewer_replaced"
+            ],
+            "x-enum-varnames": [
+                "Closed",
+                "Created",
+                "Merged",
+                "ReadyForReview",
+                "Reopened",
+                "ReviewerAssigned",
+                "ReviewerRemoved",
+                "ReviewerReplaced"
+            ]
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PullRequestHistory": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PullRequestEvent"
                     }
+                },
+                "pull_request_id": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_PaulLocust_Avito-review_internal_dto.ErrorResponseErrorCode": {
+        "github_com_PaulLocust_Avito-review_internal_dto.PullRequestPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "NextCursor Курсор следующей страницы (отсутствует на последней странице)",
+                    "type": "string"
+                },
+                "pull_requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.PullRequest"
+                    }
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.PullRequestStatus": {
             "type": "string",
             "enum": [
-                "NO_CANDIDATE",
-                "NOT_ASSIGNED",
-                "NOT_FOUND",
-                "PR_EXISTS",
-                "PR_MERGED",
-                "TEAM_EXISTS"
+                "CLOSED",
+                "DRAFT",
+                "MERGED",
+                "OPEN"
             ],
             "x-enum-varnames": [
-                "NOCANDIDATE",
-                "NOTASSIGNED",
-                "NOTFOUND",
-                "PREXISTS",
-                "PRMERGED",
-                "TEAMEXISTS"
+                "PullRequestStatusCLOSED",
+                "PullRequestStatusDRAFT",
+                "PullRequestStatusMERGED",
+                "PullRequestStatusOPEN"
             ]
         },
-        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestCreateJSONBody": {
+        "github_com_PaulLocust_Avito-review_internal_dto.Review": {
             "type": "object",
             "properties": {
-                "author_id": {
+                "state": {
+                    "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ReviewState"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.ReviewState": {
+            "type": "string",
+            "enum": [
+                "APPROVED",
+                "CHANGES_REQUESTED",
+                "COMMENTED",
+                "PENDING"
+            ],
+            "x-enum-varnames": [
+                "ReviewStateAPPROVED",
+                "ReviewStateCHANGESREQUESTED",
+                "ReviewStateCOMMENTED",
+                "ReviewStatePENDING"
+            ]
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.ReviewerReassignment": {
+            "type": "object",
+            "properties": {
+                "new_user_id": {
+                    "description": "NewUserId user_id нового ревьювера (отсутствует, если замены не нашлось и ревьювер снят)",
+                    "type": "string"
+                },
+                "old_user_id": {
                     "type": "string"
                 },
                 "pull_request_id": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.Team": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamMember"
+                    }
                 },
-                "pull_request_name": {
+                "team_name": {
                     "type": "string"
                 }
             }
         },
-        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestMergeJSONBody": {
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamAssignmentStats": {
             "type": "object",
             "properties": {
-                "pull_request_id": {
+                "merged": {
+                    "type": "integer"
+                },
+                "open": {
+                    "type": "integer"
+                },
+                "team_name": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_PaulLocust_Avito-review_internal_dto.PostPullRequestReassignJSONBody": {
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamChange": {
             "type": "object",
             "properties": {
-                "old_user_id": {
+                "reassignments": {
+                    "description": "Reassignments Замены затронутых пользователей в их открытых ревью",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ReviewerReassignment"
+                    }
+                },
+                "team": {
+                    "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.Team"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamDeactivation": {
+            "type": "object",
+            "properties": {
+                "deactivated_users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reassignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ReviewerReassignment"
+                    }
+                },
+                "team_name": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamDeletion": {
+            "type": "object",
+            "properties": {
+                "closed_pull_requests": {
+                    "description": "ClosedPullRequests Незавершенные PR авторов из команды, закрытые принудительным удалением",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "pull_request_id": {
+                "deleted_users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reassignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ReviewerReassignment"
+                    }
+                },
+                "team_name": {
                     "type": "string"
                 }
             }
         },
-        "github_com_PaulLocust_Avito-review_internal_dto.PostUsersSetIsActiveJSONBody": {
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamMember": {
             "type": "object",
             "properties": {
                 "is_active": {
@@ -375,29 +3140,147 @@ const docTemplate = `{
                 },
                 "user_id": {
                     "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_PaulLocust_Avito-review_internal_dto.Team": {
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamSettings": {
             "type": "object",
             "properties": {
-                "members": {
+                "max_open_reviews": {
+                    "description": "MaxOpenReviews Лимит открытых ревью участника по умолчанию (0 - без ограничения; если не задано - не меняется)",
+                    "type": "integer"
+                },
+                "min_required": {
+                    "description": "MinRequired Минимальное число ревьюверов, без которого PR не создаётся",
+                    "type": "integer"
+                },
+                "required_approvals": {
+                    "description": "RequiredApprovals Сколько одобрений нужно для мерджа PR (0 - мердж без одобрений; если не задано - не меняется)",
+                    "type": "integer"
+                },
+                "reviewer_count": {
+                    "description": "ReviewerCount Сколько ревьюверов назначать при создании PR",
+                    "type": "integer"
+                },
+                "strategy": {
+                    "description": "Strategy Стратегия выбора ревьюверов (если не задана - стратегия сервиса по умолчанию)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamSettingsStrategy"
+                        }
+                    ]
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamSettingsStrategy": {
+            "type": "string",
+            "enum": [
+                "least_loaded",
+                "random"
+            ],
+            "x-enum-varnames": [
+                "LeastLoaded",
+                "Random"
+            ]
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamSummary": {
+            "type": "object",
+            "properties": {
+                "active_members_count": {
+                    "type": "integer"
+                },
+                "members_count": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.TeamSync": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Added Новые, восстановленные и перешедшие из других команд участники",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamMember"
                     }
                 },
+                "deactivated": {
+                    "description": "Deactivated Участники, отсутствующие в списке или переданные неактивными",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dry_run": {
+                    "description": "DryRun Изменения только рассчитаны и не сохранены",
+                    "type": "boolean"
+                },
+                "reassignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ReviewerReassignment"
+                    }
+                },
+                "team_created": {
+                    "type": "boolean"
+                },
                 "team_name": {
                     "type": "string"
+                },
+                "updated": {
+                    "description": "Updated Участники, у которых изменилось имя или которые снова активны",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.TeamMember"
+                    }
                 }
             }
         },
-        "github_com_PaulLocust_Avito-review_internal_dto.TeamMember": {
+        "github_com_PaulLocust_Avito-review_internal_dto.User": {
             "type": "object",
             "properties": {
                 "is_active": {
                     "type": "boolean"
                 },
+                "max_open_reviews": {
+                    "description": "MaxOpenReviews Личный лимит открытых ревью (если не задан - лимит команды)",
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.UserAssignmentStats": {
+            "type": "object",
+            "properties": {
+                "merged": {
+                    "type": "integer"
+                },
+                "open": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
                 "user_id": {
                     "type": "string"
                 },
@@ -405,6 +3288,49 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.UserChange": {
+            "type": "object",
+            "properties": {
+                "reassignments": {
+                    "description": "Reassignments Замены пользователя в его открытых ревью",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.ReviewerReassignment"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_PaulLocust_Avito-review_internal_dto.User"
+                }
+            }
+        },
+        "github_com_PaulLocust_Avito-review_internal_dto.WebhookEventType": {
+            "type": "string",
+            "enum": [
+                "pr.created",
+                "pr.merged",
+                "reviewer.reassigned",
+                "user.deactivated"
+            ],
+            "x-enum-varnames": [
+                "PrCreated",
+                "PrMerged",
+                "ReviewerReassigned",
+                "UserDeactivated"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT SSO в формате \"Bearer <token>\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
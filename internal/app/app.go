@@ -2,6 +2,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -10,10 +11,17 @@ import (
 
 	"github.com/PaulLocust/Avito-review/config"
 	"github.com/PaulLocust/Avito-review/internal/controller/http"
+	"github.com/PaulLocust/Avito-review/internal/metrics"
+	"github.com/PaulLocust/Avito-review/internal/outbox"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/internal/repository/memory"
 	"github.com/PaulLocust/Avito-review/internal/repository/postgresql"
 	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/internal/webhook"
 	"github.com/PaulLocust/Avito-review/pkg/httpserver"
+	"github.com/PaulLocust/Avito-review/migrations"
 	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/PaulLocust/Avito-review/pkg/migrate"
 	"github.com/PaulLocust/Avito-review/pkg/postgres"
 )
 
@@ -22,29 +30,153 @@ func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
 	l.Info("Starting application...")
 
+	// Metrics
+	m := metrics.New()
+
 	// Repository
-	l.Info("Connecting to database...")
-	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
-	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
+	var (
+		teamRepo    repository.TeamRepository
+		userRepo    repository.UserRepository
+		prRepo      repository.PRRepository
+		statsRepo   repository.StatsRepository
+		eventRepo   repository.EventRepository
+		webhookRepo repository.WebhookRepository
+		outboxRepo  repository.OutboxRepository
+		apiKeyRepo  repository.APIKeyRepository
+		idemRepo    repository.IdempotencyRepository
+		unavailRepo repository.UnavailabilityRepository
+		tx          repository.Transactor
+		checks      []http.ReadinessCheck
+	)
+
+	switch cfg.Storage.Type {
+	case "memory":
+		l.Info("Using in-memory storage")
+		storage := memory.NewStorage()
+		teamRepo = memory.NewTeamRepository(storage, l)
+		userRepo = memory.NewUserRepository(storage, l)
+		prRepo = memory.NewPRRepository(storage, l)
+		statsRepo = memory.NewStatsRepository(storage, l)
+		eventRepo = memory.NewEventRepository(storage, l)
+		webhookRepo = memory.NewWebhookRepository(storage, l)
+		outboxRepo = memory.NewOutboxRepository(storage, l)
+		apiKeyRepo = memory.NewAPIKeyRepository(storage, l)
+		idemRepo = memory.NewIdempotencyRepository(storage, l)
+		unavailRepo = memory.NewUnavailabilityRepository(storage, l)
+		tx = memory.NewTransactor(storage)
+	case "postgres":
+		l.Info("Connecting to database...")
+		pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
+		}
+		defer pg.Close()
+		l.Info("Database connected successfully")
+
+		m.RegisterPool(pg.Pool)
+
+		// Применяем миграции до того, как начать обслуживать запросы
+		l.Info("Applying database migrations...")
+		migrator, err := migrate.New(pg.Pool, migrations.FS)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - migrate.New: %w", err))
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - migrator.Up: %w", err))
+		}
+		l.Info("Database migrations applied: %d new, schema version %d", len(applied), migrator.Latest())
+
+		// Инициализируем репозитории с логгером
+		teamRepo = postgresql.NewTeamRepository(pg.Pool, l)
+		userRepo = postgresql.NewUserRepository(pg.Pool, l)
+		prRepo = postgresql.NewPRRepository(pg.Pool, l)
+		statsRepo = postgresql.NewStatsRepository(pg.Pool, l)
+		eventRepo = postgresql.NewEventRepository(pg.Pool, l)
+		webhookRepo = postgresql.NewWebhookRepository(pg.Pool, l)
+		outboxRepo = postgresql.NewOutboxRepository(pg.Pool, l)
+		apiKeyRepo = postgresql.NewAPIKeyRepository(pg.Pool, l)
+		idemRepo = postgresql.NewIdempotencyRepository(pg.Pool, l)
+		unavailRepo = postgresql.NewUnavailabilityRepository(pg.Pool, l)
+		tx = postgresql.NewTransactor(pg.Pool, l)
+
+		// Проверки готовности: доступность БД и актуальность схемы
+		checks = []http.ReadinessCheck{
+			{Name: "postgres", Check: pg.Pool.Ping},
+			{Name: "migrations", Check: func(ctx context.Context) error {
+				version, dirty, err := migrator.Version(ctx)
+				if err != nil {
+					return err
+				}
+				if dirty {
+					return fmt.Errorf("schema version %d is dirty", version)
+				}
+				if version < migrator.Latest() {
+					return fmt.Errorf("schema version %d, expected %d", version, migrator.Latest())
+				}
+				return nil
+			}},
+		}
+	default:
+		l.Fatal(fmt.Errorf("app - Run - unknown storage type: %s", cfg.Storage.Type))
 	}
-	defer pg.Close()
-	l.Info("Database connected successfully")
 
 	// Use-Cases с реальными зависимостями
 	l.Info("Initializing use cases...")
 
-	// Инициализируем репозитории с логгером
-	teamRepo := postgresql.NewTeamRepository(pg.Pool, l)
-	userRepo := postgresql.NewUserRepository(pg.Pool, l)
-	prRepo := postgresql.NewPRRepository(pg.Pool, l)
+	// Стратегия выбора ревьюверов
+	selector, err := usecase.NewReviewerSelector(cfg.Reviewer.Strategy, prRepo)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - usecase.NewReviewerSelector: %w", err))
+	}
+	l.Info("Reviewer selection strategy: %s", cfg.Reviewer.Strategy)
+
+	// Доставка вебхуков
+	dispatcher := webhook.New(webhookRepo, l,
+		webhook.Workers(cfg.Webhook.Workers),
+		webhook.MaxAttempts(cfg.Webhook.MaxAttempts),
+		webhook.Backoff(cfg.Webhook.Backoff),
+		webhook.Timeout(cfg.Webhook.Timeout),
+	)
+	if err := dispatcher.Start(); err != nil {
+		l.Fatal(fmt.Errorf("app - Run - dispatcher.Start: %w", err))
+	}
+	defer dispatcher.Stop()
+
+	// Публикация событий из outbox в вебхуки; останавливается раньше диспетчера
+	relay := outbox.New(outboxRepo, dispatcher, l,
+		outbox.Interval(cfg.Outbox.Interval),
+		outbox.BatchSize(cfg.Outbox.BatchSize),
+	)
+	relay.Start()
+	defer relay.Stop()
+
+	// Аутентификация: API-ключи и, если настроены ключи JWT, bearer-токены SSO
+	authOpts, err := authOptions(cfg.Auth)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - authOptions: %w", err))
+	}
+	if authOpts.Verifier != nil {
+		l.Info("Bearer token authentication enabled, user claim: %s", authOpts.UserClaim)
+	}
 
-	useCases := usecase.NewUseCases(teamRepo, userRepo, prRepo, l)
+	useCases := usecase.NewUseCases(teamRepo, userRepo, prRepo, statsRepo, eventRepo, webhookRepo, outboxRepo, apiKeyRepo,
+		idemRepo, unavailRepo, tx, selector, authOpts, cfg.Idempotency.TTL, cfg.Idempotency.Lease, m, l)
 	l.Info("Use cases initialized successfully")
 
+	// Удаление просроченных ключей идемпотентности
+	stopPurge := purgeIdempotencyKeys(useCases.Idempotency, cfg.Idempotency.PurgeInterval, l)
+	defer stopPurge()
+
+	// Переназначение ревью пользователей, у которых начался период недоступности
+	if cfg.Unavailability.ReassignInterval > 0 {
+		stopReassign := reassignUnavailable(useCases.Unavailability, cfg.Unavailability.ReassignInterval, l)
+		defer stopReassign()
+	}
+
 	// HTTP Router (net/http)
 	l.Info("Setting up HTTP router...")
-	handler := http.NewRouter(cfg, l, useCases)
+	handler := http.NewRouter(cfg, l, useCases, m, checks)
 
 	// HTTP Server
 	l.Info("Creating HTTP server...")
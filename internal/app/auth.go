@@ -0,0 +1,74 @@
+// auth.go
+package app
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/PaulLocust/Avito-review/config"
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/jwt"
+)
+
+// _placeholderBootstrapKeys - общеизвестные значения из примеров конфигурации, с которыми ключ администратора известен всем
+var _placeholderBootstrapKeys = map[string]bool{
+	"dev-bootstrap-key": true,
+	"bootstrap-key":     true,
+	"changeme":          true,
+	"change-me":         true,
+	"secret":            true,
+	"admin":             true,
+	"password":          true,
+}
+
+// authOptions собирает параметры аутентификации; bearer-токены включаются, если задан хотя бы один ключ JWT
+func authOptions(cfg config.Auth) (usecase.AuthOptions, error) {
+	if cfg.Enabled && _placeholderBootstrapKeys[strings.ToLower(cfg.BootstrapKey)] {
+		return usecase.AuthOptions{}, fmt.Errorf("app - authOptions: AUTH_BOOTSTRAP_KEY is a known placeholder, set a random value or leave it empty")
+	}
+
+	opts := usecase.AuthOptions{
+		BootstrapKey: cfg.BootstrapKey,
+		UserClaim:    cfg.JWT.UserClaim,
+		RoleClaim:    cfg.JWT.RoleClaim,
+		DefaultRole:  entity.Role(cfg.JWT.DefaultRole),
+	}
+	if !opts.DefaultRole.IsKnown() {
+		return opts, fmt.Errorf("app - authOptions: unknown AUTH_JWT_DEFAULT_ROLE %q", cfg.JWT.DefaultRole)
+	}
+
+	var verifierOpts []jwt.Option
+	if cfg.JWT.HS256Secret != "" {
+		verifierOpts = append(verifierOpts, jwt.HS256Secret([]byte(cfg.JWT.HS256Secret)))
+	}
+	if cfg.JWT.KeyFile != "" {
+		key, err := jwt.LoadRSAPublicKey(cfg.JWT.KeyFile)
+		if err != nil {
+			return opts, fmt.Errorf("app - authOptions - jwt.LoadRSAPublicKey: %w", err)
+		}
+		verifierOpts = append(verifierOpts, jwt.RSAKeys(map[string]*rsa.PublicKey{"": key}))
+	}
+	if cfg.JWT.JWKSFile != "" {
+		keys, err := jwt.LoadJWKS(cfg.JWT.JWKSFile)
+		if err != nil {
+			return opts, fmt.Errorf("app - authOptions - jwt.LoadJWKS: %w", err)
+		}
+		verifierOpts = append(verifierOpts, jwt.RSAKeys(keys))
+	}
+	if len(verifierOpts) == 0 {
+		return opts, nil
+	}
+
+	verifier, err := jwt.New(append(verifierOpts,
+		jwt.Issuer(cfg.JWT.Issuer),
+		jwt.Audience(cfg.JWT.Audience),
+		jwt.Leeway(cfg.JWT.Leeway),
+	)...)
+	if err != nil {
+		return opts, fmt.Errorf("app - authOptions - jwt.New: %w", err)
+	}
+	opts.Verifier = verifier
+	return opts, nil
+}
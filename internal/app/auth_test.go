@@ -0,0 +1,36 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/PaulLocust/Avito-review/config"
+)
+
+func TestAuthOptionsRejectsPlaceholderBootstrapKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		key     string
+		wantErr bool
+	}{
+		{name: "empty key", enabled: true},
+		{name: "random key", enabled: true, key: "3f9c1e0b7d2a4c58a6e1f0b9d8c7a6e5"},
+		{name: "dev placeholder", enabled: true, key: "dev-bootstrap-key", wantErr: true},
+		{name: "placeholder in upper case", enabled: true, key: "CHANGEME", wantErr: true},
+		{name: "placeholder with auth disabled", enabled: false, key: "dev-bootstrap-key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg config.Auth
+			cfg.Enabled = tt.enabled
+			cfg.BootstrapKey = tt.key
+			cfg.JWT.DefaultRole = "bot"
+
+			_, err := authOptions(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
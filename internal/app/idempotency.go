@@ -0,0 +1,45 @@
+// idempotency.go
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+// purgeIdempotencyKeys периодически удаляет просроченные ключи идемпотентности.
+// Возвращает функцию остановки
+func purgeIdempotencyKeys(uc usecase.IdempotencyUseCase, interval time.Duration, l logger.Interface) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				deleted, err := uc.PurgeExpired(context.Background())
+				if err != nil {
+					l.Error("Failed to purge idempotency keys: %v", err)
+					continue
+				}
+				if deleted > 0 {
+					l.Info("Purged %d expired idempotency keys", deleted)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
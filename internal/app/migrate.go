@@ -0,0 +1,71 @@
+// migrate.go
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/PaulLocust/Avito-review/config"
+	"github.com/PaulLocust/Avito-review/migrations"
+	"github.com/PaulLocust/Avito-review/pkg/migrate"
+	"github.com/PaulLocust/Avito-review/pkg/postgres"
+)
+
+// Migrate выполняет подкоманду migrate: up - применить все миграции,
+// down - откатить последнюю применённую, status - показать текущую версию
+func Migrate(cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s migrate up|down|status", os.Args[0])
+	}
+
+	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(1))
+	if err != nil {
+		return fmt.Errorf("app - Migrate - postgres.New: %w", err)
+	}
+	defer pg.Close()
+
+	migrator, err := migrate.New(pg.Pool, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("app - Migrate - migrate.New: %w", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range applied {
+			fmt.Printf("applied %06d_%s\n", m.Version, m.Name)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no change")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if reverted == nil {
+			fmt.Println("no change")
+		} else {
+			fmt.Printf("reverted %06d_%s\n", reverted.Version, reverted.Name)
+		}
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version: %d (latest %d), dirty: %v\n", status.Version, status.Latest, status.Dirty)
+		for _, m := range status.Pending {
+			fmt.Printf("pending %06d_%s\n", m.Version, m.Name)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command: %s (want up|down|status)", args[0])
+	}
+
+	return nil
+}
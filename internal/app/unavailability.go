@@ -0,0 +1,45 @@
+// unavailability.go
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+// reassignUnavailable периодически переназначает открытые ревью пользователей,
+// у которых начался период недоступности. Возвращает функцию остановки
+func reassignUnavailable(uc usecase.UnavailabilityUseCase, interval time.Duration, l logger.Interface) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				processed, err := uc.ReassignStarted(context.Background(), time.Now())
+				if err != nil {
+					l.Error("Failed to reassign reviews of unavailable users: %v", err)
+					continue
+				}
+				if processed > 0 {
+					l.Info("Reassigned reviews for %d started unavailability periods", processed)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,66 @@
+// internal/controller/http/health.go
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const _readinessTimeout = 2 * time.Second
+
+// ReadinessCheck проверяет одну внешнюю зависимость сервиса
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type dependencyStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type readinessResponse struct {
+	Status       string             `json:"status"`
+	Dependencies []dependencyStatus `json:"dependencies"`
+}
+
+// livenessHandler отвечает 200, пока процесс способен обрабатывать запросы
+func livenessHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// readinessHandler выполняет все проверки с общим таймаутом и
+// возвращает 503, если хотя бы одна зависимость недоступна
+func readinessHandler(checks []ReadinessCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), _readinessTimeout)
+		defer cancel()
+
+		resp := readinessResponse{
+			Status:       "ok",
+			Dependencies: make([]dependencyStatus, 0, len(checks)),
+		}
+		for _, check := range checks {
+			dep := dependencyStatus{Name: check.Name, Status: "ok"}
+			if err := check.Check(ctx); err != nil {
+				dep.Status = "unavailable"
+				dep.Error = err.Error()
+				resp.Status = "unavailable"
+			}
+			resp.Dependencies = append(resp.Dependencies, dep)
+		}
+
+		status := http.StatusOK
+		if resp.Status != "ok" {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resp)
+	}
+}
@@ -0,0 +1,146 @@
+// internal/controller/http/idempotency.go
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+const (
+	// idempotencyKeyHeader - заголовок с ключом идемпотентности изменяющего запроса
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader - признак того, что ответ воспроизведен из сохраненного
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// responseRecorder копирует ответ обработчика для сохранения по ключу идемпотентности
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// idempotencyMiddleware повторяет сохраненный ответ для изменяющих запросов с тем же заголовком Idempotency-Key.
+// Ключ действует в пределах инициатора запроса; повтор ключа с другим телом отклоняется.
+// Ответы 5xx не сохраняются, чтобы запрос можно было повторить
+func idempotencyMiddleware(idem usecase.IdempotencyUseCase, l logger.Interface, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		principal, authenticated := usecase.PrincipalFrom(r.Context())
+		if key == "" || !authenticated || !isMutating(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := principal.IdempotencyScope()
+		stored, err := idem.Begin(r.Context(), scope, key, requestFingerprint(r, body))
+		if err != nil {
+			var appErr entity.AppError
+			if !errors.As(err, &appErr) {
+				l.Error("Failed to check idempotency key: %v", err)
+				writeError(w, http.StatusInternalServerError, entity.ErrorInvalidInput, "internal server error")
+				return
+			}
+			switch appErr.Code {
+			case entity.ErrorIdempotencyKeyReused:
+				writeError(w, http.StatusUnprocessableEntity, appErr.Code, appErr.Message)
+			case entity.ErrorRequestInProgress:
+				writeError(w, http.StatusConflict, appErr.Code, appErr.Message)
+			default:
+				writeError(w, http.StatusBadRequest, appErr.Code, appErr.Message)
+			}
+			return
+		}
+		if stored != nil {
+			if stored.ContentType != "" {
+				w.Header().Set("Content-Type", stored.ContentType)
+			}
+			w.Header().Set(idempotentReplayedHeader, "true")
+			w.WriteHeader(stored.StatusCode)
+			w.Write(stored.Body)
+			return
+		}
+
+		// Контекст запроса отменяется, когда клиент отключается по таймауту, а операция уже может быть выполнена:
+		// ответ сохраняется и резерв снимается без учета отмены
+		ctx := context.WithoutCancel(r.Context())
+
+		// Резерв снимается, если ответ не сохранен, в том числе при панике обработчика
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := idem.Release(ctx, scope, key); err != nil {
+				l.Error("Failed to release idempotency key: %v", err)
+			}
+		}()
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// Ответ уже отправлен клиенту: ошибки сохранения только логируются
+		if rec.status >= http.StatusInternalServerError {
+			return
+		}
+		err = idem.Complete(ctx, &entity.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			StatusCode:  rec.status,
+			ContentType: rec.Header().Get("Content-Type"),
+			Body:        rec.body.Bytes(),
+		})
+		if err != nil {
+			l.Error("Failed to store idempotent response: %v", err)
+			return
+		}
+		completed = true
+	})
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestFingerprint - хэш метода, пути, параметров и тела запроса
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.Path)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.RawQuery)
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
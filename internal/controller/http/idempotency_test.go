@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/internal/repository/memory"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+var _idemPrincipal = &entity.Principal{Name: "ci-bot", Role: entity.RoleBot, KeyID: 1}
+
+// ctxIdempotencyRepo, как и PostgreSQL, не выполняет запросы с отмененным контекстом
+type ctxIdempotencyRepo struct {
+	repository.IdempotencyRepository
+}
+
+func (r ctxIdempotencyRepo) CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.IdempotencyRepository.CompleteIdempotencyKey(ctx, record)
+}
+
+func (r ctxIdempotencyRepo) DeleteIdempotencyKey(ctx context.Context, scope, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.IdempotencyRepository.DeleteIdempotencyKey(ctx, scope, key)
+}
+
+func newTestIdempotency(lease time.Duration) usecase.IdempotencyUseCase {
+	l := logger.New("error")
+	repo := ctxIdempotencyRepo{memory.NewIdempotencyRepository(memory.NewStorage(), l)}
+	return usecase.NewIdempotencyUseCase(repo, time.Hour, lease, l)
+}
+
+// sendIdempotent отправляет запрос с ключом retry-1, функция отмены его контекста доступна по cancelKey
+func sendIdempotent(handler http.Handler) *httptest.ResponseRecorder {
+	ctx, cancel := context.WithCancel(usecase.WithPrincipal(context.Background(), _idemPrincipal))
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pullRequest/reassign", strings.NewReader(`{}`))
+	req = req.WithContext(context.WithValue(ctx, cancelKey{}, cancel))
+	req.Header.Set(idempotencyKeyHeader, "retry-1")
+	rec := httptest.NewRecorder()
+
+	// Паника обработчика доходит до http.Server, который закрывает соединение
+	defer func() { recover() }()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+type cancelKey struct{}
+
+func TestIdempotencyRetryAfterCancel(t *testing.T) {
+	tests := []struct {
+		name string
+		// first - обработчик первого запроса, cancel отменяет его контекст, как отключение клиента по таймауту
+		first        func(w http.ResponseWriter, r *http.Request, cancel context.CancelFunc)
+		wantReplayed bool
+	}{
+		{
+			name: "client disconnects after operation",
+			first: func(w http.ResponseWriter, r *http.Request, cancel context.CancelFunc) {
+				cancel()
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"attempt":1}`))
+			},
+			wantReplayed: true,
+		},
+		{
+			name: "operation canceled with client",
+			first: func(w http.ResponseWriter, r *http.Request, cancel context.CancelFunc) {
+				cancel()
+				writeError(w, http.StatusInternalServerError, entity.ErrorInvalidInput, r.Context().Err().Error())
+			},
+		},
+		{
+			name: "handler panics",
+			first: func(w http.ResponseWriter, r *http.Request, cancel context.CancelFunc) {
+				cancel()
+				panic("boom")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			handler := idempotencyMiddleware(newTestIdempotency(time.Hour), logger.New("error"),
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls++
+					if calls == 1 {
+						tt.first(w, r, r.Context().Value(cancelKey{}).(context.CancelFunc))
+						return
+					}
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(`{"attempt":2}`))
+				}))
+
+			sendIdempotent(handler)
+			rec := sendIdempotent(handler)
+
+			replayed := rec.Header().Get(idempotentReplayedHeader) == "true"
+			if rec.Code != http.StatusCreated || replayed != tt.wantReplayed {
+				t.Fatalf("retry: %d %s, replayed=%v, want 201 with replayed=%v", rec.Code, rec.Body.String(), replayed, tt.wantReplayed)
+			}
+			wantCalls := 2
+			if tt.wantReplayed {
+				wantCalls = 1
+			}
+			if calls != wantCalls {
+				t.Errorf("handler called %d times, want %d", calls, wantCalls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyLeaseExpires(t *testing.T) {
+	const lease = 50 * time.Millisecond
+	idem := newTestIdempotency(lease)
+	handler := idempotencyMiddleware(idem, logger.New("error"), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	// Резерв запроса, не сохранившего ответ и не снявшего резерв (процесс остановлен)
+	fingerprint := requestFingerprint(httptest.NewRequest(http.MethodPost, "/api/v1/pullRequest/reassign", nil), []byte(`{}`))
+	if _, err := idem.Begin(context.Background(), _idemPrincipal.IdempotencyScope(), "retry-1", fingerprint); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+
+	if rec := sendIdempotent(handler); rec.Code != http.StatusConflict {
+		t.Fatalf("retry during lease: %d %s, want 409", rec.Code, rec.Body.String())
+	}
+	time.Sleep(2 * lease)
+	if rec := sendIdempotent(handler); rec.Code != http.StatusCreated {
+		t.Fatalf("retry after lease: %d %s, want 201", rec.Code, rec.Body.String())
+	}
+	// Сохраненный ответ хранится TTL, а не lease
+	time.Sleep(2 * lease)
+	if rec := sendIdempotent(handler); rec.Header().Get(idempotentReplayedHeader) != "true" {
+		t.Fatalf("completed response was not replayed after lease: %d", rec.Code)
+	}
+}
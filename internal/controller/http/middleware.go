@@ -0,0 +1,146 @@
+// internal/controller/http/middleware.go
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/dto"
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/metrics"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+const (
+	// actorHeader - заголовок с идентификатором инициатора запроса для журнала изменений PR
+	actorHeader = "X-Actor"
+	// apiKeyHeader - заголовок с API-ключом
+	apiKeyHeader = "X-API-Key"
+	// bearerPrefix - схема заголовка Authorization с JWT
+	bearerPrefix = "Bearer "
+)
+
+// anonymousPrincipal - инициатор запросов при выключенной аутентификации, разрешает все операции
+var anonymousPrincipal = &entity.Principal{Name: "anonymous", Role: entity.RoleAdmin}
+
+// statusRecorder запоминает код ответа для метрик
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// matchedRoute - шаблон маршрута, выбранный ServeMux. Middleware передают дальше копии запроса
+// (r.WithContext), а ServeMux заполняет Pattern только у своей копии, поэтому шаблон
+// возвращается в metricsMiddleware через контекст
+type matchedRoute struct {
+	pattern string
+}
+
+type routeKey struct{}
+
+// metricsMiddleware считает запросы и их длительность по шаблону маршрута
+func metricsMiddleware(m *metrics.Metrics, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		matched := &matchedRoute{}
+
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), routeKey{}, matched)))
+
+		// Шаблон вместо пути не даёт кардинальности меток расти от произвольных URL
+		route := matched.pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		m.ObserveHTTP(r.Method, route, rec.status, time.Since(start))
+	})
+}
+
+// routeMiddleware оборачивает ServeMux и сохраняет выбранный им шаблон маршрута для metricsMiddleware
+func routeMiddleware(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+
+		if matched, ok := r.Context().Value(routeKey{}).(*matchedRoute); ok {
+			matched.pattern = r.Pattern
+		}
+	})
+}
+
+// actorMiddleware передает инициатора запроса из заголовка X-Actor в контекст use case.
+// Заголовок учитывается только для администраторов и ботов (в том числе при выключенной аутентификации),
+// остальным он позволил бы записать в журнал PR чужое имя вместо владельца ключа или токена
+func actorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(actorHeader)
+		principal, ok := usecase.PrincipalFrom(r.Context())
+		if actor != "" && ok && (principal.Role == entity.RoleAdmin || principal.Role == entity.RoleBot) {
+			r = r.WithContext(usecase.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authMiddleware аутентифицирует запрос по заголовку X-API-Key или bearer-токену
+// и сохраняет инициатора в контексте. Запрос без учетных данных проходит дальше анонимным:
+// права проверяются на маршрутах v1, а служебные маршруты (healthz, readyz, metrics, swagger) остаются открытыми
+func authMiddleware(auth usecase.AuthUseCase, enabled bool, l logger.Interface, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !enabled {
+			next.ServeHTTP(w, r.WithContext(usecase.WithPrincipal(r.Context(), anonymousPrincipal)))
+			return
+		}
+
+		var principal *entity.Principal
+		var err error
+		rawKey := r.Header.Get(apiKeyHeader)
+		authorization := r.Header.Get("Authorization")
+		switch {
+		case rawKey != "":
+			var key *entity.APIKey
+			if key, err = auth.Authenticate(r.Context(), rawKey); err == nil {
+				principal = key.Principal()
+			}
+		case len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix):
+			principal, err = auth.AuthenticateToken(r.Context(), authorization[len(bearerPrefix):])
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err != nil {
+			var appErr entity.AppError
+			if errors.As(err, &appErr) {
+				writeError(w, http.StatusUnauthorized, appErr.Code, appErr.Message)
+				return
+			}
+			l.Error("Failed to authenticate request: %v", err)
+			writeError(w, http.StatusInternalServerError, entity.ErrorInvalidInput, "internal server error")
+			return
+		}
+
+		// Инициатором изменений считается владелец ключа или пользователь токена,
+		// администраторы и боты могут указать другого в X-Actor (actorMiddleware)
+		ctx := usecase.WithActor(usecase.WithPrincipal(r.Context(), principal), principal.Name)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code entity.ErrorCode, message string) {
+	var resp dto.ErrorResponse
+	resp.Error.Code = dto.ErrorResponseErrorCode(code)
+	resp.Error.Message = message
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/PaulLocust/Avito-review/config"
 	"github.com/PaulLocust/Avito-review/internal/controller/http/v1"
+	"github.com/PaulLocust/Avito-review/internal/metrics"
 	"github.com/PaulLocust/Avito-review/internal/usecase"
 	"github.com/PaulLocust/Avito-review/pkg/logger"
 
@@ -19,14 +20,22 @@ import (
 // @description Сервис назначения ревьюверов для Pull Request'ов
 // @host localhost:8080
 // @BasePath /api/v1
-func NewRouter(cfg *config.Config, l logger.Interface, useCases *usecase.UseCases) http.Handler {
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT SSO в формате "Bearer <token>"
+func NewRouter(cfg *config.Config, l logger.Interface, useCases *usecase.UseCases, m *metrics.Metrics, checks []ReadinessCheck) http.Handler {
 	mux := http.NewServeMux()
 	
-	// Health check
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	// Liveness и readiness
+	mux.HandleFunc("/healthz", livenessHandler)
+	mux.HandleFunc("GET /readyz", readinessHandler(checks))
+	
+	// Prometheus metrics
+	mux.Handle("GET /metrics", m.Handler())
 	
 	// Swagger UI - используем встроенную документацию из docs.go
 	mux.Handle("/swagger/", httpSwagger.WrapHandler)
@@ -34,5 +43,6 @@ func NewRouter(cfg *config.Config, l logger.Interface, useCases *usecase.UseCase
 	// API v1 routes
 	v1.SetupRoutes(mux, useCases, l)
 	
-	return mux
+	return metricsMiddleware(m, authMiddleware(useCases.Auth, cfg.Auth.Enabled, l,
+		idempotencyMiddleware(useCases.Idempotency, l, actorMiddleware(routeMiddleware(mux)))))
 }
\ No newline at end of file
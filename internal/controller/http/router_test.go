@@ -0,0 +1,232 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/config"
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/metrics"
+	"github.com/PaulLocust/Avito-review/internal/repository/memory"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+func newTestRouter(t *testing.T, authEnabled bool) (http.Handler, *usecase.UseCases) {
+	t.Helper()
+
+	l := logger.New("error")
+	m := metrics.New()
+	storage := memory.NewStorage()
+	prRepo := memory.NewPRRepository(storage, l)
+	selector, err := usecase.NewReviewerSelector("random", prRepo)
+	if err != nil {
+		t.Fatalf("NewReviewerSelector: %v", err)
+	}
+	useCases := usecase.NewUseCases(
+		memory.NewTeamRepository(storage, l),
+		memory.NewUserRepository(storage, l),
+		prRepo,
+		memory.NewStatsRepository(storage, l),
+		memory.NewEventRepository(storage, l),
+		memory.NewWebhookRepository(storage, l),
+		memory.NewOutboxRepository(storage, l),
+		memory.NewAPIKeyRepository(storage, l),
+		memory.NewIdempotencyRepository(storage, l),
+		memory.NewUnavailabilityRepository(storage, l),
+		memory.NewTransactor(storage),
+		selector, usecase.AuthOptions{}, time.Hour, time.Minute, m, l,
+	)
+
+	var cfg config.Config
+	cfg.Auth.Enabled = authEnabled
+	return NewRouter(&cfg, l, useCases, m, nil), useCases
+}
+
+func TestMetricsRouteLabel(t *testing.T) {
+	router, _ := newTestRouter(t, false)
+
+	for _, header := range []string{"", "ci-bot"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/pullRequest/create",
+			strings.NewReader(`{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if header != "" {
+			req.Header.Set(actorHeader, header)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/no/such/route", nil))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, want := range []string{
+		`avito_review_http_requests_total{method="POST",route="POST /api/v1/pullRequest/create",status="404"} 2`,
+		`avito_review_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+}
+
+func TestActorHeader(t *testing.T) {
+	ctx := context.Background()
+	router, useCases := newTestRouter(t, true)
+
+	keys := map[entity.Role]string{}
+	for _, role := range []entity.Role{entity.RoleAdmin, entity.RoleBot, entity.RoleTeamLead} {
+		_, raw, err := useCases.Auth.IssueAPIKey(ctx, string(role)+"-key", role)
+		if err != nil {
+			t.Fatalf("IssueAPIKey: %v", err)
+		}
+		keys[role] = raw
+	}
+
+	do := func(role entity.Role, actor, path, body string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(apiKeyHeader, keys[role])
+		if actor != "" {
+			req.Header.Set(actorHeader, actor)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code >= 300 {
+			t.Fatalf("POST %s: %d %s", path, rec.Code, rec.Body.String())
+		}
+	}
+
+	do(entity.RoleTeamLead, "", "/api/v1/team/add",
+		`{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true},{"user_id":"u2","username":"Bob","is_active":true}]}`)
+
+	tests := []struct {
+		role  entity.Role
+		actor string
+		want  string
+	}{
+		{role: entity.RoleTeamLead, actor: "", want: "team-lead-key"},
+		{role: entity.RoleTeamLead, actor: "mallory", want: "team-lead-key"},
+		{role: entity.RoleBot, actor: "", want: "bot-key"},
+		{role: entity.RoleBot, actor: "jenkins", want: "jenkins"},
+		{role: entity.RoleAdmin, actor: "alice", want: "alice"},
+	}
+	for i, tt := range tests {
+		prID := fmt.Sprintf("pr-%d", i)
+		do(tt.role, tt.actor, "/api/v1/pullRequest/create",
+			fmt.Sprintf(`{"pull_request_id":%q,"pull_request_name":"Add search","author_id":"u1"}`, prID))
+
+		events, err := useCases.PR.GetPRHistory(ctx, prID)
+		if err != nil {
+			t.Fatalf("GetPRHistory: %v", err)
+		}
+		if len(events) == 0 || events[0].Actor != tt.want {
+			t.Errorf("%s with X-Actor %q: actor = %v, want %s", tt.role, tt.actor, events, tt.want)
+		}
+	}
+}
+
+func TestIdempotencyScopedByKey(t *testing.T) {
+	ctx := context.Background()
+	router, useCases := newTestRouter(t, true)
+
+	// Два ключа с одинаковым именем и ключ с именем пользователя
+	var keys []string
+	for _, name := range []string{"ci-bot", "ci-bot", "u1"} {
+		_, raw, err := useCases.Auth.IssueAPIKey(ctx, name, entity.RoleAdmin)
+		if err != nil {
+			t.Fatalf("IssueAPIKey: %v", err)
+		}
+		keys = append(keys, raw)
+	}
+
+	post := func(apiKey, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(apiKeyHeader, apiKey)
+		req.Header.Set(idempotencyKeyHeader, "retry-1")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i, apiKey := range keys {
+		body := fmt.Sprintf(`{"team_name":"team-%d","members":[{"user_id":"m%d","username":"M","is_active":true}]}`, i, i)
+		rec := post(apiKey, "/api/v1/team/add", body)
+		if rec.Code != http.StatusCreated || rec.Header().Get("Idempotent-Replayed") != "" {
+			t.Fatalf("key %d: %d %s, replayed=%q", i, rec.Code, rec.Body.String(), rec.Header().Get("Idempotent-Replayed"))
+		}
+		if !strings.Contains(rec.Body.String(), fmt.Sprintf("team-%d", i)) {
+			t.Errorf("key %d got another response: %s", i, rec.Body.String())
+		}
+
+		// Повтор тем же ключом возвращает сохраненный ответ
+		if rec := post(apiKey, "/api/v1/team/add", body); rec.Header().Get("Idempotent-Replayed") != "true" {
+			t.Errorf("key %d: retry was not replayed: %d %s", i, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestTeamSettingsPartialUpdate(t *testing.T) {
+	router, _ := newTestRouter(t, false)
+
+	post := func(path, body string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code >= 300 {
+			t.Fatalf("POST %s: %d %s", path, rec.Code, rec.Body.String())
+		}
+		return rec.Body.String()
+	}
+	post("/api/v1/team/add", `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`)
+	post("/api/v1/team/settings", `{"team_name":"backend","reviewer_count":2,"min_required":0,"required_approvals":1,"max_open_reviews":3}`)
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "strategy only",
+			body: `{"team_name":"backend","reviewer_count":2,"min_required":0,"strategy":"least_loaded"}`,
+			want: []string{`"required_approvals":1`, `"max_open_reviews":3`},
+		},
+		{
+			name: "explicit zero approvals",
+			body: `{"team_name":"backend","reviewer_count":2,"min_required":0,"required_approvals":0}`,
+			want: []string{`"required_approvals":0`, `"max_open_reviews":3`},
+		},
+		{
+			name: "explicit zero limit",
+			body: `{"team_name":"backend","reviewer_count":2,"min_required":0,"max_open_reviews":0}`,
+			want: []string{`"required_approvals":0`, `"max_open_reviews":0`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := post("/api/v1/team/settings", tt.body)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/team/settings?team_name=backend", nil))
+
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("response %s does not contain %s", body, want)
+				}
+				if !strings.Contains(rec.Body.String(), want) {
+					t.Errorf("stored settings %s do not contain %s", rec.Body.String(), want)
+				}
+			}
+		})
+	}
+}
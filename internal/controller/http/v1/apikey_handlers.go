@@ -0,0 +1,160 @@
+// internal/controller/http/v1/apikey_handlers.go
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/PaulLocust/Avito-review/internal/dto"
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type apiKeyHandlers struct {
+	authUC usecase.AuthUseCase
+	logger logger.Interface
+}
+
+func newAPIKeyHandlers(authUC usecase.AuthUseCase, l logger.Interface) *apiKeyHandlers {
+	return &apiKeyHandlers{
+		authUC: authUC,
+		logger: l,
+	}
+}
+
+// IssueAPIKey выдает API-ключ
+// @Summary Выдать API-ключ
+// @Description Выдает ключ с ролью admin, team-lead, bot или read-only. Ключ возвращается только в этом ответе, в базе хранится его SHA-256
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostApiKeysJSONBody true "Название и роль ключа"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 201 {object} map[string]interface{} "Ключ выдан"
+// @Failure 400 {object} dto.ErrorResponse "Некорректное название или роль"
+// @Failure 401 {object} dto.ErrorResponse "Ключ не передан или недействителен"
+// @Failure 403 {object} dto.ErrorResponse "Недостаточно прав"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /apiKeys [post]
+func (h *apiKeyHandlers) issueAPIKey(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/apiKeys")
+
+	var req dto.PostApiKeysJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	key, rawKey, err := h.authUC.IssueAPIKey(r.Context(), req.Name, entity.Role(req.Role))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Открытый ключ отдаём только при выдаче
+	response := toAPIKeyDTO(key)
+	response.Key = &rawKey
+
+	writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
+		"api_key": response,
+	})
+}
+
+// ListAPIKeys возвращает выданные ключи
+// @Summary Список API-ключей
+// @Description Возвращает выданные ключи без их значений, включая отозванные
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Success 200 {object} map[string]interface{} "Список ключей"
+// @Failure 401 {object} dto.ErrorResponse "Ключ не передан или недействителен"
+// @Failure 403 {object} dto.ErrorResponse "Недостаточно прав"
+// @Router /apiKeys [get]
+func (h *apiKeyHandlers) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/apiKeys")
+
+	keys, err := h.authUC.ListAPIKeys(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response := make([]dto.ApiKey, len(keys))
+	for i := range keys {
+		response[i] = toAPIKeyDTO(&keys[i])
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"api_keys": response,
+	})
+}
+
+// RevokeAPIKey отзывает ключ
+// @Summary Отозвать API-ключ
+// @Description Отозванный ключ перестает проходить аутентификацию. Повторный отзыв не меняет время отзыва
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostApiKeysRevokeJSONBody true "Идентификатор ключа"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "Ключ отозван"
+// @Failure 400 {object} dto.ErrorResponse "Некорректный запрос"
+// @Failure 401 {object} dto.ErrorResponse "Ключ не передан или недействителен"
+// @Failure 403 {object} dto.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} dto.ErrorResponse "Ключ не найден"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /apiKeys/revoke [post]
+func (h *apiKeyHandlers) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/apiKeys/revoke")
+
+	var req dto.PostApiKeysRevokeJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	key, err := h.authUC.RevokeAPIKey(r.Context(), req.Id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"api_key": toAPIKeyDTO(key),
+	})
+}
+
+func toAPIKeyDTO(key *entity.APIKey) dto.ApiKey {
+	return dto.ApiKey{
+		Id:        key.ID,
+		Name:      key.Name,
+		Role:      dto.ApiKeyRole(key.Role),
+		Prefix:    key.Prefix,
+		CreatedAt: key.CreatedAt,
+		RevokedAt: key.RevokedAt,
+	}
+}
+
+func (h *apiKeyHandlers) handleError(w http.ResponseWriter, err error) {
+	var appErr entity.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Code {
+		case entity.ErrorInvalidInput:
+			writeErrorResponse(w, http.StatusBadRequest, appErr.Code, appErr.Message)
+		case entity.ErrorNotFound:
+			writeErrorResponse(w, http.StatusNotFound, appErr.Code, appErr.Message)
+		default:
+			writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, appErr.Message)
+		}
+	} else {
+		h.logger.Error("Internal server error: %v", err)
+		writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, "internal server error")
+	}
+}
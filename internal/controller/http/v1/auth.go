@@ -0,0 +1,28 @@
+// internal/controller/http/v1/auth.go
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+)
+
+// authorize пропускает запрос к обработчику, только если роль инициатора запроса имеет право permission.
+// Ограничения по команде и автору PR проверяются в use case
+func authorize(permission entity.Permission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := usecase.PrincipalFrom(r.Context())
+		if !ok {
+			writeErrorResponse(w, http.StatusUnauthorized, entity.ErrorUnauthorized, "API key or bearer token required")
+			return
+		}
+		if !principal.Role.Can(permission) {
+			writeErrorResponse(w, http.StatusForbidden, entity.ErrorForbidden,
+				fmt.Sprintf("role %s has no %s permission", principal.Role, permission))
+			return
+		}
+		next(w, r)
+	}
+}
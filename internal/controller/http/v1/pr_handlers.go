@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/PaulLocust/Avito-review/internal/dto"
 	"github.com/PaulLocust/Avito-review/internal/entity"
@@ -26,14 +28,17 @@ func newPRHandlers(prUC usecase.PRUseCase, l logger.Interface) *prHandlers {
 
 // CreatePR создает PR и автоматически назначает ревьюверов
 // @Summary Создать PR и автоматически назначить до 2 ревьюверов из команды автора
-// @Description Создает новый pull request и автоматически назначает до двух активных ревьюверов из команды автора
+// @Description Создает новый pull request и автоматически назначает активных ревьюверов из команды автора согласно настройкам команды (по умолчанию до двух). Черновик (draft: true) создается без ревьюверов
 // @Tags PullRequests
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth || BearerAuth
 // @Param request body dto.PostPullRequestCreateJSONBody true "Данные PR"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
 // @Success 201 {object} map[string]interface{} "PR создан"
 // @Failure 404 {object} dto.ErrorResponse "Автор/команда не найдены"
-// @Failure 409 {object} dto.ErrorResponse "PR уже существует"
+// @Failure 409 {object} dto.ErrorResponse "PR уже существует или недостаточно ревьюверов"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
 // @Router /pullRequest/create [post]
 func (h *prHandlers) createPR(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info("POST /api/v1/pullRequest/create")
@@ -45,22 +50,15 @@ func (h *prHandlers) createPR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.prUC.CreatePR(r.Context(), req.PullRequestId, req.PullRequestName, req.AuthorId)
+	draft := req.Draft != nil && *req.Draft
+	pr, err := h.prUC.CreatePR(r.Context(), req.PullRequestId, req.PullRequestName, req.AuthorId, draft)
 	if err != nil {
 		h.handleError(w, err)
 		return
 	}
 
 	// Конвертируем в DTO
-	response := dto.PullRequest{
-		PullRequestId:   pr.ID,
-		PullRequestName: pr.Name,
-		AuthorId:        pr.AuthorID,
-		Status:          dto.PullRequestStatus(pr.Status),
-		AssignedReviewers: pr.AssignedReviewers,
-		CreatedAt:       &pr.CreatedAt,
-		MergedAt:        pr.MergedAt,
-	}
+	response := toPullRequestDTO(pr)
 
 	writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
 		"pr": response,
@@ -69,13 +67,18 @@ func (h *prHandlers) createPR(w http.ResponseWriter, r *http.Request) {
 
 // MergePR помечает PR как MERGED
 // @Summary Пометить PR как MERGED (идемпотентная операция)
-// @Description Изменяет статус PR на MERGED. Операция идемпотентна - повторный вызов не приводит к ошибке
+// @Description Изменяет статус PR на MERGED. Операция идемпотентна - повторный вызов не приводит к ошибке. Если в настройках команды автора задан required_approvals, PR без нужного числа одобрений не мерджится
 // @Tags PullRequests
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth || BearerAuth
 // @Param request body dto.PostPullRequestMergeJSONBody true "Данные PR"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
 // @Success 200 {object} map[string]interface{} "PR в состоянии MERGED"
 // @Failure 404 {object} dto.ErrorResponse "PR не найден"
+// @Failure 409 {object} dto.ErrorResponse "Недостаточно одобрений или PR в статусе DRAFT/CLOSED"
+// @Failure 403 {object} dto.ErrorResponse "Мерджить PR может только его автор или администратор"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
 // @Router /pullRequest/merge [post]
 func (h *prHandlers) mergePR(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info("POST /api/v1/pullRequest/merge")
@@ -94,31 +97,131 @@ func (h *prHandlers) mergePR(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Конвертируем в DTO
-	response := dto.PullRequest{
-		PullRequestId:   pr.ID,
-		PullRequestName: pr.Name,
-		AuthorId:        pr.AuthorID,
-		Status:          dto.PullRequestStatus(pr.Status),
-		AssignedReviewers: pr.AssignedReviewers,
-		CreatedAt:       &pr.CreatedAt,
-		MergedAt:        pr.MergedAt,
-	}
+	response := toPullRequestDTO(pr)
 
 	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
 		"pr": response,
 	})
 }
 
+// ClosePR закрывает PR без мерджа
+// @Summary Закрыть PR без мерджа (идемпотентная операция)
+// @Description Переводит PR из OPEN или DRAFT в CLOSED. Ревьюверы остаются назначенными, но закрытый PR не учитывается в их нагрузке
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostPullRequestCloseJSONBody true "Данные PR"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "PR в состоянии CLOSED"
+// @Failure 404 {object} dto.ErrorResponse "PR не найден"
+// @Failure 409 {object} dto.ErrorResponse "Смердженный PR нельзя закрыть"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /pullRequest/close [post]
+func (h *prHandlers) closePR(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/pullRequest/close")
+
+	var req dto.PostPullRequestCloseJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	pr, err := h.prUC.ClosePR(r.Context(), req.PullRequestId)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"pr": toPullRequestDTO(pr),
+	})
+}
+
+// ReopenPR переоткрывает закрытый PR
+// @Summary Переоткрыть закрытый PR
+// @Description Переводит PR из CLOSED в OPEN. Если у PR нет ревьюверов (был закрыт черновик), они назначаются как при создании
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostPullRequestReopenJSONBody true "Данные PR"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "PR в состоянии OPEN"
+// @Failure 404 {object} dto.ErrorResponse "PR не найден"
+// @Failure 409 {object} dto.ErrorResponse "Переоткрыть можно только закрытый PR"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /pullRequest/reopen [post]
+func (h *prHandlers) reopenPR(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/pullRequest/reopen")
+
+	var req dto.PostPullRequestReopenJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	pr, err := h.prUC.ReopenPR(r.Context(), req.PullRequestId)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"pr": toPullRequestDTO(pr),
+	})
+}
+
+// ReadyPR переводит черновик в OPEN
+// @Summary Перевести черновик в OPEN и назначить ревьюверов
+// @Description Переводит PR из DRAFT в OPEN и назначает ревьюверов согласно настройкам команды автора
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostPullRequestReadyJSONBody true "Данные PR"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "PR в состоянии OPEN"
+// @Failure 404 {object} dto.ErrorResponse "PR не найден"
+// @Failure 409 {object} dto.ErrorResponse "Готовым можно пометить только черновик"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /pullRequest/ready [post]
+func (h *prHandlers) readyPR(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/pullRequest/ready")
+
+	var req dto.PostPullRequestReadyJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	pr, err := h.prUC.ReadyPR(r.Context(), req.PullRequestId)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"pr": toPullRequestDTO(pr),
+	})
+}
+
 // ReassignReviewer переназначает ревьювера
 // @Summary Переназначить конкретного ревьювера на другого из его команды
 // @Description Заменяет одного ревьювера на случайного активного участника из команды заменяемого ревьювера
 // @Tags PullRequests
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth || BearerAuth
 // @Param request body dto.PostPullRequestReassignJSONBody true "Данные для переназначения"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
 // @Success 200 {object} map[string]interface{} "Переназначение выполнено"
 // @Failure 404 {object} dto.ErrorResponse "PR или пользователь не найден"
 // @Failure 409 {object} dto.ErrorResponse "Нарушение доменных правил переназначения"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
 // @Router /pullRequest/reassign [post]
 func (h *prHandlers) reassignReviewer(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info("POST /api/v1/pullRequest/reassign")
@@ -137,15 +240,7 @@ func (h *prHandlers) reassignReviewer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Конвертируем в DTO
-	response := dto.PullRequest{
-		PullRequestId:   pr.ID,
-		PullRequestName: pr.Name,
-		AuthorId:        pr.AuthorID,
-		Status:          dto.PullRequestStatus(pr.Status),
-		AssignedReviewers: pr.AssignedReviewers,
-		CreatedAt:       &pr.CreatedAt,
-		MergedAt:        pr.MergedAt,
-	}
+	response := toPullRequestDTO(pr)
 
 	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
 		"pr":          response,
@@ -153,6 +248,242 @@ func (h *prHandlers) reassignReviewer(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ReviewPR фиксирует решение ревьювера
+// @Summary Зафиксировать решение ревьювера по PR
+// @Description Сохраняет решение назначенного ревьювера (APPROVED, CHANGES_REQUESTED, COMMENTED). Повторный вызов перезаписывает решение. Пользователь токена фиксирует решение только от своего имени, от имени любого ревьювера действуют admin и API-ключи bot
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostPullRequestReviewJSONBody true "Решение ревьювера"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "Решение сохранено"
+// @Failure 400 {object} dto.ErrorResponse "Некорректное состояние ревью"
+// @Failure 403 {object} dto.ErrorResponse "Решение от имени другого ревьювера"
+// @Failure 404 {object} dto.ErrorResponse "PR не найден"
+// @Failure 409 {object} dto.ErrorResponse "PR уже смерджен или пользователь не назначен ревьювером"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /pullRequest/review [post]
+func (h *prHandlers) reviewPR(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/pullRequest/review")
+
+	var req dto.PostPullRequestReviewJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	pr, err := h.prUC.ReviewPR(r.Context(), req.PullRequestId, req.UserId, entity.ReviewState(req.State))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"pr": toPullRequestDTO(pr),
+	})
+}
+
+// GetPR возвращает PR по идентификатору
+// @Summary Получить PR с ревьюверами и командой автора
+// @Description Возвращает PR со списком ревьюверов, их решениями, временными метками и командой автора
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param pull_request_id query string true "Идентификатор PR"
+// @Success 200 {object} map[string]interface{} "PR"
+// @Failure 400 {object} dto.ErrorResponse "Не указан pull_request_id"
+// @Failure 404 {object} dto.ErrorResponse "PR не найден"
+// @Router /pullRequest/get [get]
+func (h *prHandlers) getPR(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/pullRequest/get")
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.prUC.GetPR(r.Context(), prID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"pr": toPullRequestDTO(pr),
+	})
+}
+
+// GetPRHistory возвращает журнал изменений PR
+// @Summary Получить журнал изменений PR
+// @Description Возвращает события PR в порядке записи: создание, назначение, замену и снятие ревьюверов (со старым и новым ревьювером и причиной), смену статуса. Инициатор - владелец API-ключа или пользователь токена, admin и bot могут передать другого в заголовке X-Actor. Без аутентификации и X-Actor записывается system
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param pull_request_id query string true "Идентификатор PR"
+// @Success 200 {object} dto.PullRequestHistory "Журнал изменений PR"
+// @Failure 400 {object} dto.ErrorResponse "Не указан pull_request_id"
+// @Failure 404 {object} dto.ErrorResponse "PR не найден"
+// @Router /pullRequest/history [get]
+func (h *prHandlers) getPRHistory(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/pullRequest/history")
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "pull_request_id is required")
+		return
+	}
+
+	events, err := h.prUC.GetPRHistory(r.Context(), prID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Конвертируем в DTO
+	response := dto.PullRequestHistory{
+		PullRequestId: prID,
+		Events:        make([]dto.PullRequestEvent, len(events)),
+	}
+	for i, event := range events {
+		response.Events[i] = toPullRequestEventDTO(event)
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// ListPRs возвращает страницу PR по фильтрам
+// @Summary Список PR с фильтрами и курсорной пагинацией
+// @Description Возвращает PR, отсортированные по времени создания. Для следующей страницы передайте next_cursor в параметре cursor с теми же фильтрами
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param status query string false "Статус PR (DRAFT, OPEN, MERGED, CLOSED)"
+// @Param author_id query string false "Автор PR"
+// @Param reviewer_id query string false "Назначенный ревьювер"
+// @Param team_name query string false "Команда автора PR"
+// @Param name query string false "Подстрока названия PR (без учёта регистра)"
+// @Param created_from query string false "PR, созданные не раньше этого момента (RFC3339)"
+// @Param created_to query string false "PR, созданные раньше этого момента (RFC3339)"
+// @Param merged_from query string false "PR, смердженные не раньше этого момента (RFC3339)"
+// @Param merged_to query string false "PR, смердженные раньше этого момента (RFC3339)"
+// @Param order query string false "Сортировка по времени создания: asc или desc (по умолчанию desc)"
+// @Param limit query int false "Размер страницы (1..100, по умолчанию 20)"
+// @Param cursor query string false "next_cursor предыдущей страницы"
+// @Success 200 {object} dto.PullRequestPage "Страница PR"
+// @Failure 400 {object} dto.ErrorResponse "Некорректные параметры"
+// @Router /pullRequest/list [get]
+func (h *prHandlers) listPRs(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/pullRequest/list")
+
+	query := r.URL.Query()
+	filter := entity.PRFilter{
+		Status:       entity.PRStatus(query.Get("status")),
+		AuthorID:     query.Get("author_id"),
+		ReviewerID:   query.Get("reviewer_id"),
+		TeamName:     query.Get("team_name"),
+		NameContains: query.Get("name"),
+		Order:        entity.SortOrder(query.Get("order")),
+	}
+
+	var err error
+	for name, target := range map[string]**time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+		"merged_from":  &filter.MergedFrom,
+		"merged_to":    &filter.MergedTo,
+	} {
+		if *target, err = parseTimeParam(query.Get(name)); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, name+" must be RFC3339 date-time")
+			return
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "limit must be an integer")
+			return
+		}
+	}
+
+	page, err := h.prUC.ListPRs(r.Context(), filter, query.Get("cursor"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Конвертируем в DTO
+	response := dto.PullRequestPage{
+		PullRequests: make([]dto.PullRequest, len(page.PullRequests)),
+	}
+	for i := range page.PullRequests {
+		response.PullRequests[i] = toPullRequestDTO(&page.PullRequests[i])
+	}
+	if page.NextCursor != "" {
+		response.NextCursor = &page.NextCursor
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func toPullRequestDTO(pr *entity.PullRequest) dto.PullRequest {
+	response := dto.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            dto.PullRequestStatus(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		Reviews:           make([]dto.Review, len(pr.Reviews)),
+		CreatedAt:         &pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+	}
+	if pr.AuthorTeam != "" {
+		authorTeam := pr.AuthorTeam
+		response.AuthorTeam = &authorTeam
+	}
+	if pr.CapacityWarning {
+		capacityWarning := true
+		response.CapacityWarning = &capacityWarning
+	}
+	if response.AssignedReviewers == nil {
+		// PR без ревьюверов (например, черновик) отдаём с пустым массивом, а не null
+		response.AssignedReviewers = []string{}
+	}
+	for i, review := range pr.Reviews {
+		response.Reviews[i] = dto.Review{
+			UserId: review.ReviewerID,
+			State:  dto.ReviewState(review.State),
+		}
+	}
+	return response
+}
+
+func toPullRequestEventDTO(event entity.PREvent) dto.PullRequestEvent {
+	response := dto.PullRequestEvent{
+		Id:        event.ID,
+		Type:      dto.PullRequestEventType(event.Type),
+		Actor:     event.Actor,
+		CreatedAt: event.CreatedAt,
+	}
+	if event.OldUserID != "" {
+		oldUserID := event.OldUserID
+		response.OldUserId = &oldUserID
+	}
+	if event.NewUserID != "" {
+		newUserID := event.NewUserID
+		response.NewUserId = &newUserID
+	}
+	if event.Reason != "" {
+		reason := event.Reason
+		response.Reason = &reason
+	}
+	return response
+}
+
 func (h *prHandlers) handleError(w http.ResponseWriter, err error) {
 	var appErr entity.AppError
 	if errors.As(err, &appErr) {
@@ -161,12 +492,22 @@ func (h *prHandlers) handleError(w http.ResponseWriter, err error) {
 			writeErrorResponse(w, http.StatusConflict, appErr.Code, appErr.Message)
 		case entity.ErrorPRMerged:
 			writeErrorResponse(w, http.StatusConflict, appErr.Code, appErr.Message)
+		case entity.ErrorPRClosed:
+			writeErrorResponse(w, http.StatusConflict, appErr.Code, appErr.Message)
+		case entity.ErrorInvalidTransition:
+			writeErrorResponse(w, http.StatusConflict, appErr.Code, appErr.Message)
 		case entity.ErrorNotAssigned:
 			writeErrorResponse(w, http.StatusConflict, appErr.Code, appErr.Message)
 		case entity.ErrorNoCandidate:
 			writeErrorResponse(w, http.StatusConflict, appErr.Code, appErr.Message)
+		case entity.ErrorNotApproved:
+			writeErrorResponse(w, http.StatusConflict, appErr.Code, appErr.Message)
+		case entity.ErrorInvalidInput:
+			writeErrorResponse(w, http.StatusBadRequest, appErr.Code, appErr.Message)
 		case entity.ErrorNotFound:
 			writeErrorResponse(w, http.StatusNotFound, appErr.Code, appErr.Message)
+		case entity.ErrorForbidden:
+			writeErrorResponse(w, http.StatusForbidden, appErr.Code, appErr.Message)
 		default:
 			writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, appErr.Message)
 		}
@@ -4,6 +4,7 @@ package v1
 import (
 	"net/http"
 
+	"github.com/PaulLocust/Avito-review/internal/entity"
 	"github.com/PaulLocust/Avito-review/internal/usecase"
 	"github.com/PaulLocust/Avito-review/pkg/logger"
 )
@@ -13,17 +14,60 @@ func SetupRoutes(mux *http.ServeMux, useCases *usecase.UseCases, l logger.Interf
 	teamHandlers := newTeamHandlers(useCases.Team, l)
 	userHandlers := newUserHandlers(useCases.User, l)
 	prHandlers := newPRHandlers(useCases.PR, l)
+	statsHandlers := newStatsHandlers(useCases.Stats, l)
+	webhookHandlers := newWebhookHandlers(useCases.Webhook, l)
+	apiKeyHandlers := newAPIKeyHandlers(useCases.Auth, l)
+	unavailabilityHandlers := newUnavailabilityHandlers(useCases.Unavailability, l)
+
+	// Каждый маршрут требует права, которое есть у роли API-ключа запроса (см. entity.Role.Can)
 
 	// Teams
-	mux.HandleFunc("POST /api/v1/team/add", teamHandlers.addTeam)
-	mux.HandleFunc("GET /api/v1/team/get", teamHandlers.getTeam)
+	mux.HandleFunc("POST /api/v1/team/add", authorize(entity.PermissionManageTeams, teamHandlers.addTeam))
+	mux.HandleFunc("GET /api/v1/team/get", authorize(entity.PermissionRead, teamHandlers.getTeam))
+	mux.HandleFunc("POST /api/v1/team/settings", authorize(entity.PermissionManageTeams, teamHandlers.setTeamSettings))
+	mux.HandleFunc("GET /api/v1/team/settings", authorize(entity.PermissionRead, teamHandlers.getTeamSettings))
+	mux.HandleFunc("POST /api/v1/team/deactivateUsers", authorize(entity.PermissionManageTeams, teamHandlers.deactivateUsers))
+	mux.HandleFunc("GET /api/v1/team/list", authorize(entity.PermissionRead, teamHandlers.listTeams))
+	mux.HandleFunc("POST /api/v1/team/addMembers", authorize(entity.PermissionManageTeams, teamHandlers.addMembers))
+	mux.HandleFunc("POST /api/v1/team/removeMembers", authorize(entity.PermissionManageTeams, teamHandlers.removeMembers))
+	mux.HandleFunc("POST /api/v1/team/rename", authorize(entity.PermissionManageTeams, teamHandlers.renameTeam))
+	mux.HandleFunc("POST /api/v1/team/delete", authorize(entity.PermissionManageTeams, teamHandlers.deleteTeam))
+	mux.HandleFunc("PUT /api/v1/team/sync", authorize(entity.PermissionManageTeams, teamHandlers.syncTeam))
 	
 	// Users
-	mux.HandleFunc("POST /api/v1/users/setIsActive", userHandlers.setIsActive)
-	mux.HandleFunc("GET /api/v1/users/getReview", userHandlers.getReviews)
+	mux.HandleFunc("POST /api/v1/users/setIsActive", authorize(entity.PermissionManageTeams, userHandlers.setIsActive))
+	mux.HandleFunc("GET /api/v1/users/getReview", authorize(entity.PermissionRead, userHandlers.getReviews))
+	mux.HandleFunc("GET /api/v1/users/get", authorize(entity.PermissionRead, userHandlers.getUser))
+	mux.HandleFunc("GET /api/v1/users/list", authorize(entity.PermissionRead, userHandlers.listUsers))
+	mux.HandleFunc("POST /api/v1/users/update", authorize(entity.PermissionManageTeams, userHandlers.updateUser))
+	mux.HandleFunc("POST /api/v1/users/delete", authorize(entity.PermissionManageTeams, userHandlers.deleteUser))
+	mux.HandleFunc("POST /api/v1/users/unavailability", authorize(entity.PermissionManageTeams, unavailabilityHandlers.createUnavailability))
+	mux.HandleFunc("GET /api/v1/users/unavailability", authorize(entity.PermissionRead, unavailabilityHandlers.listUnavailability))
+	mux.HandleFunc("POST /api/v1/users/unavailability/update", authorize(entity.PermissionManageTeams, unavailabilityHandlers.updateUnavailability))
+	mux.HandleFunc("POST /api/v1/users/unavailability/delete", authorize(entity.PermissionManageTeams, unavailabilityHandlers.deleteUnavailability))
 	
 	// Pull Requests
-	mux.HandleFunc("POST /api/v1/pullRequest/create", prHandlers.createPR)
-	mux.HandleFunc("POST /api/v1/pullRequest/merge", prHandlers.mergePR)
-	mux.HandleFunc("POST /api/v1/pullRequest/reassign", prHandlers.reassignReviewer)
+	mux.HandleFunc("POST /api/v1/pullRequest/create", authorize(entity.PermissionReview, prHandlers.createPR))
+	mux.HandleFunc("POST /api/v1/pullRequest/merge", authorize(entity.PermissionReview, prHandlers.mergePR))
+	mux.HandleFunc("POST /api/v1/pullRequest/close", authorize(entity.PermissionReview, prHandlers.closePR))
+	mux.HandleFunc("POST /api/v1/pullRequest/reopen", authorize(entity.PermissionReview, prHandlers.reopenPR))
+	mux.HandleFunc("POST /api/v1/pullRequest/ready", authorize(entity.PermissionReview, prHandlers.readyPR))
+	mux.HandleFunc("POST /api/v1/pullRequest/reassign", authorize(entity.PermissionReview, prHandlers.reassignReviewer))
+	mux.HandleFunc("POST /api/v1/pullRequest/review", authorize(entity.PermissionReview, prHandlers.reviewPR))
+	mux.HandleFunc("GET /api/v1/pullRequest/get", authorize(entity.PermissionRead, prHandlers.getPR))
+	mux.HandleFunc("GET /api/v1/pullRequest/history", authorize(entity.PermissionRead, prHandlers.getPRHistory))
+	mux.HandleFunc("GET /api/v1/pullRequest/list", authorize(entity.PermissionRead, prHandlers.listPRs))
+
+	// Stats
+	mux.HandleFunc("GET /api/v1/stats/assignments", authorize(entity.PermissionRead, statsHandlers.getAssignmentStats))
+
+	// Webhooks
+	mux.HandleFunc("POST /api/v1/webhooks", authorize(entity.PermissionAdmin, webhookHandlers.createWebhook))
+	mux.HandleFunc("GET /api/v1/webhooks", authorize(entity.PermissionAdmin, webhookHandlers.listWebhooks))
+	mux.HandleFunc("GET /api/v1/webhooks/deliveries", authorize(entity.PermissionAdmin, webhookHandlers.listDeliveries))
+
+	// API keys
+	mux.HandleFunc("POST /api/v1/apiKeys", authorize(entity.PermissionAdmin, apiKeyHandlers.issueAPIKey))
+	mux.HandleFunc("GET /api/v1/apiKeys", authorize(entity.PermissionAdmin, apiKeyHandlers.listAPIKeys))
+	mux.HandleFunc("POST /api/v1/apiKeys/revoke", authorize(entity.PermissionAdmin, apiKeyHandlers.revokeAPIKey))
 }
\ No newline at end of file
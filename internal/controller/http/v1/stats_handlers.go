@@ -0,0 +1,126 @@
+// internal/controller/http/v1/stats_handlers.go
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/dto"
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type statsHandlers struct {
+	statsUC usecase.StatsUseCase
+	logger  logger.Interface
+}
+
+func newStatsHandlers(statsUC usecase.StatsUseCase, l logger.Interface) *statsHandlers {
+	return &statsHandlers{
+		statsUC: statsUC,
+		logger:  l,
+	}
+}
+
+// GetAssignmentStats возвращает статистику назначений ревьюверов
+// @Summary Статистика назначений ревьюверов
+// @Description Возвращает количество назначений по пользователям и командам (всего, открытых, смердженных) и количество ревьюверов по PR
+// @Tags Stats
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param team_name query string false "Ограничить статистику командой"
+// @Param from query string false "Учитывать PR, созданные не раньше этого момента (RFC3339)"
+// @Param to query string false "Учитывать PR, созданные раньше этого момента (RFC3339)"
+// @Success 200 {object} dto.AssignmentStats "Статистика назначений"
+// @Failure 400 {object} dto.ErrorResponse "Некорректные параметры"
+// @Failure 404 {object} dto.ErrorResponse "Команда не найдена"
+// @Router /stats/assignments [get]
+func (h *statsHandlers) getAssignmentStats(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/stats/assignments")
+
+	query := r.URL.Query()
+	filter := entity.StatsFilter{TeamName: query.Get("team_name")}
+
+	var err error
+	if filter.From, err = parseTimeParam(query.Get("from")); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "from must be RFC3339 date-time")
+		return
+	}
+	if filter.To, err = parseTimeParam(query.Get("to")); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "to must be RFC3339 date-time")
+		return
+	}
+
+	stats, err := h.statsUC.GetAssignmentStats(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Конвертируем в DTO
+	response := dto.AssignmentStats{
+		Users:        make([]dto.UserAssignmentStats, len(stats.Users)),
+		Teams:        make([]dto.TeamAssignmentStats, len(stats.Teams)),
+		PullRequests: make([]dto.PRReviewerStats, len(stats.PullRequests)),
+	}
+	for i, s := range stats.Users {
+		response.Users[i] = dto.UserAssignmentStats{
+			UserId:   s.UserID,
+			Username: s.Username,
+			TeamName: s.TeamName,
+			Total:    s.Total,
+			Open:     s.Open,
+			Merged:   s.Merged,
+		}
+	}
+	for i, s := range stats.Teams {
+		response.Teams[i] = dto.TeamAssignmentStats{
+			TeamName: s.TeamName,
+			Total:    s.Total,
+			Open:     s.Open,
+			Merged:   s.Merged,
+		}
+	}
+	for i, s := range stats.PullRequests {
+		response.PullRequests[i] = dto.PRReviewerStats{
+			PullRequestId: s.PullRequestID,
+			AuthorId:      s.AuthorID,
+			Status:        dto.PRReviewerStatsStatus(s.Status),
+			ReviewerCount: s.ReviewerCount,
+		}
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func (h *statsHandlers) handleError(w http.ResponseWriter, err error) {
+	var appErr entity.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Code {
+		case entity.ErrorInvalidInput:
+			writeErrorResponse(w, http.StatusBadRequest, appErr.Code, appErr.Message)
+		case entity.ErrorNotFound:
+			writeErrorResponse(w, http.StatusNotFound, appErr.Code, appErr.Message)
+		default:
+			writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, appErr.Message)
+		}
+	} else {
+		h.logger.Error("Internal server error: %v", err)
+		writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, "internal server error")
+	}
+}
+
+// parseTimeParam разбирает необязательный query-параметр в формате RFC3339
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/PaulLocust/Avito-review/internal/entity"
 	"github.com/PaulLocust/Avito-review/internal/usecase"
@@ -30,9 +31,13 @@ func newTeamHandlers(teamUC usecase.TeamUseCase, l logger.Interface) *teamHandle
 // @Tags Teams
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth || BearerAuth
 // @Param team body dto.Team true "Данные команды"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
 // @Success 201 {object} map[string]interface{} "Команда создана"
 // @Failure 400 {object} dto.ErrorResponse "Команда уже существует"
+// @Failure 403 {object} dto.ErrorResponse "Команда пользователя токена не совпадает с создаваемой"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
 // @Router /team/add [post]
 func (h *teamHandlers) addTeam(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info("POST /api/v1/team/add")
@@ -87,6 +92,7 @@ func (h *teamHandlers) addTeam(w http.ResponseWriter, r *http.Request) {
 // @Tags Teams
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth || BearerAuth
 // @Param team_name query string true "Уникальное имя команды"
 // @Success 200 {object} dto.Team "Объект команды"
 // @Router /team/get [get]
@@ -105,20 +111,437 @@ func (h *teamHandlers) getTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	writeJSONResponse(w, http.StatusOK, toTeamDTO(team))
+}
+
+// SetTeamSettings задает настройки назначения ревьюверов команды
+// @Summary Задать настройки назначения ревьюверов команды
+// @Description Задает количество ревьюверов, минимально необходимое количество, стратегию выбора и лимит открытых ревью участника (max_open_reviews) для команды
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param settings body dto.TeamSettings true "Настройки команды"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "Настройки сохранены"
+// @Failure 400 {object} dto.ErrorResponse "Некорректные настройки"
+// @Failure 404 {object} dto.ErrorResponse "Команда не найдена"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь токена не состоит в команде"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /team/settings [post]
+func (h *teamHandlers) setTeamSettings(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/team/settings")
+
+	var req dto.TeamSettings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	// Конвертируем DTO в entity
+	// Незаданные required_approvals и max_open_reviews сохраняют текущие значения
+	update := entity.TeamSettingsUpdate{
+		TeamName:          req.TeamName,
+		ReviewerCount:     req.ReviewerCount,
+		MinRequired:       req.MinRequired,
+		RequiredApprovals: req.RequiredApprovals,
+		MaxOpenReviews:    req.MaxOpenReviews,
+	}
+	if req.Strategy != nil {
+		update.Strategy = string(*req.Strategy)
+	}
+
+	saved, err := h.teamUC.SetTeamSettings(r.Context(), update)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"settings": toTeamSettingsDTO(saved),
+	})
+}
+
+// GetTeamSettings возвращает настройки назначения ревьюверов команды
+// @Summary Получить настройки назначения ревьюверов команды
+// @Description Возвращает настройки команды (или настройки по умолчанию, если они не заданы)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param team_name query string true "Уникальное имя команды"
+// @Success 200 {object} dto.TeamSettings "Настройки команды"
+// @Failure 404 {object} dto.ErrorResponse "Команда не найдена"
+// @Router /team/settings [get]
+func (h *teamHandlers) getTeamSettings(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/team/settings")
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "team_name is required")
+		return
+	}
+
+	settings, err := h.teamUC.GetTeamSettings(r.Context(), teamName)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toTeamSettingsDTO(settings))
+}
+
+// DeactivateUsers массово деактивирует пользователей команды
+// @Summary Массово деактивировать пользователей команды и переназначить их открытые ревью
+// @Description В одной транзакции деактивирует пользователей и заменяет их в открытых PR активными участниками команды (или снимает, если замены нет)
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostTeamDeactivateUsersJSONBody true "Команда и пользователи"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} dto.TeamDeactivation "Отчёт о деактивации"
+// @Failure 400 {object} dto.ErrorResponse "Некорректный запрос"
+// @Failure 404 {object} dto.ErrorResponse "Команда или пользователь не найдены"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь токена не состоит в команде"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /team/deactivateUsers [post]
+func (h *teamHandlers) deactivateUsers(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/team/deactivateUsers")
+
+	var req dto.PostTeamDeactivateUsersJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	result, err := h.teamUC.DeactivateUsers(r.Context(), req.TeamName, req.UserIds)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
 	// Конвертируем в DTO
-	response := dto.Team{
+	response := dto.TeamDeactivation{
+		TeamName:         result.TeamName,
+		DeactivatedUsers: result.DeactivatedUsers,
+		Reassignments:    toReassignmentsDTO(result.Reassignments),
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// ListTeams возвращает список команд
+// @Summary Список команд
+// @Description Возвращает неудаленные команды с количеством участников, отсортированные по имени
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Success 200 {object} map[string]interface{} "Список команд"
+// @Router /team/list [get]
+func (h *teamHandlers) listTeams(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/team/list")
+
+	teams, err := h.teamUC.ListTeams(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Конвертируем в DTO
+	response := make([]dto.TeamSummary, len(teams))
+	for i, team := range teams {
+		response[i] = dto.TeamSummary{
+			TeamName:           team.Name,
+			MembersCount:       team.MembersCount,
+			ActiveMembersCount: team.ActiveCount,
+		}
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"teams": response,
+	})
+}
+
+// AddMembers добавляет участников в существующую команду
+// @Summary Добавить участников в команду
+// @Description Создает или обновляет пользователей в существующей команде. Открытые ревью участников, перешедших из других команд, переназначаются на активных участников прежних команд
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostTeamAddMembersJSONBody true "Команда и участники"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} dto.TeamChange "Состав команды и замены в ревью перешедших участников"
+// @Failure 400 {object} dto.ErrorResponse "Некорректный запрос"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь токена не состоит в команде"
+// @Failure 404 {object} dto.ErrorResponse "Команда не найдена"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /team/addMembers [post]
+func (h *teamHandlers) addMembers(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/team/addMembers")
+
+	var req dto.PostTeamAddMembersJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	result, err := h.teamUC.AddMembers(r.Context(), req.TeamName, toTeamMembers(req.Members))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toTeamChangeDTO(result))
+}
+
+// RemoveMembers удаляет участников команды
+// @Summary Удалить участников команды
+// @Description Мягко удаляет пользователей команды и переназначает их открытые ревью на оставшихся активных участников
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostTeamRemoveMembersJSONBody true "Команда и пользователи"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} dto.TeamChange "Состав команды и замены в ревью удаленных участников"
+// @Failure 400 {object} dto.ErrorResponse "Некорректный запрос"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь токена не состоит в команде"
+// @Failure 404 {object} dto.ErrorResponse "Команда или пользователь не найдены"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /team/removeMembers [post]
+func (h *teamHandlers) removeMembers(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/team/removeMembers")
+
+	var req dto.PostTeamRemoveMembersJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	result, err := h.teamUC.RemoveMembers(r.Context(), req.TeamName, req.UserIds)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toTeamChangeDTO(result))
+}
+
+// RenameTeam переименовывает команду
+// @Summary Переименовать команду
+// @Description Меняет имя команды; команда пользователей и настройки переносятся на новое имя
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostTeamRenameJSONBody true "Текущее и новое имя команды"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "Переименованная команда"
+// @Failure 400 {object} dto.ErrorResponse "Некорректный запрос или команда с новым именем уже существует"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь токена не состоит в команде"
+// @Failure 404 {object} dto.ErrorResponse "Команда не найдена"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /team/rename [post]
+func (h *teamHandlers) renameTeam(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/team/rename")
+
+	var req dto.PostTeamRenameJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	team, err := h.teamUC.RenameTeam(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"team": toTeamDTO(team),
+	})
+}
+
+// DeleteTeam удаляет команду
+// @Summary Удалить команду
+// @Description Мягко удаляет команду и ее участников, их открытые ревью снимаются. Если у авторов из команды есть открытые или черновые PR, удаление отклоняется, а с force=true они закрываются
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostTeamDeleteJSONBody true "Команда"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} dto.TeamDeletion "Отчёт об удалении"
+// @Failure 400 {object} dto.ErrorResponse "Некорректный запрос"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь токена не состоит в команде"
+// @Failure 404 {object} dto.ErrorResponse "Команда не найдена"
+// @Failure 409 {object} dto.ErrorResponse "У команды есть незавершенные PR"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /team/delete [post]
+func (h *teamHandlers) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/team/delete")
+
+	var req dto.PostTeamDeleteJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	force := req.Force != nil && *req.Force
+	result, err := h.teamUC.DeleteTeam(r.Context(), req.TeamName, force)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Конвертируем в DTO
+	response := dto.TeamDeletion{
+		TeamName:           result.TeamName,
+		DeletedUsers:       result.DeletedUsers,
+		ClosedPullRequests: result.ClosedPullRequests,
+		Reassignments:      toReassignmentsDTO(result.Reassignments),
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// SyncTeam приводит состав команды к переданному списку
+// @Summary Синхронизировать состав команды
+// @Description Принимает полный желаемый список участников: создает команду, если ее нет, добавляет и обновляет пользователей, деактивирует отсутствующих в списке и переназначает их открытые ревью. С dry_run=true возвращает расхождение без применения
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param team body dto.Team true "Желаемый состав команды"
+// @Param dry_run query boolean false "Только рассчитать расхождение"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} dto.TeamSync "Расхождение и примененные изменения"
+// @Failure 400 {object} dto.ErrorResponse "Некорректный запрос"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь токена не состоит в команде"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /team/sync [put]
+func (h *teamHandlers) syncTeam(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("PUT /api/v1/team/sync")
+
+	dryRun := false
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "dry_run must be a boolean")
+			return
+		}
+	}
+
+	var req dto.Team
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	team := entity.Team{
+		Name:    req.TeamName,
+		Members: toTeamMembers(req.Members),
+	}
+
+	result, err := h.teamUC.SyncTeam(r.Context(), team, dryRun)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Конвертируем в DTO
+	response := dto.TeamSync{
+		TeamName:      result.TeamName,
+		DryRun:        result.DryRun,
+		TeamCreated:   result.TeamCreated,
+		Added:         toTeamMembersDTO(result.Added),
+		Updated:       toTeamMembersDTO(result.Updated),
+		Deactivated:   result.Deactivated,
+		Reassignments: toReassignmentsDTO(result.Reassignments),
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func toTeamDTO(team *entity.Team) dto.Team {
+	return dto.Team{
 		TeamName: team.Name,
-		Members:  make([]dto.TeamMember, len(team.Members)),
+		Members:  toTeamMembersDTO(team.Members),
 	}
-	for i, member := range team.Members {
-		response.Members[i] = dto.TeamMember{
+}
+
+func toTeamMembersDTO(members []entity.TeamMember) []dto.TeamMember {
+	response := make([]dto.TeamMember, len(members))
+	for i, member := range members {
+		response[i] = dto.TeamMember{
 			UserId:   member.UserID,
 			Username: member.Username,
 			IsActive: member.IsActive,
 		}
 	}
+	return response
+}
 
-	writeJSONResponse(w, http.StatusOK, response)
+func toTeamMembers(members []dto.TeamMember) []entity.TeamMember {
+	result := make([]entity.TeamMember, len(members))
+	for i, member := range members {
+		result[i] = entity.TeamMember{
+			UserID:   member.UserId,
+			Username: member.Username,
+			IsActive: member.IsActive,
+		}
+	}
+	return result
+}
+
+func toTeamChangeDTO(change *entity.TeamChange) dto.TeamChange {
+	return dto.TeamChange{
+		Team:          toTeamDTO(&change.Team),
+		Reassignments: toReassignmentsDTO(change.Reassignments),
+	}
+}
+
+func toReassignmentsDTO(reassignments []entity.ReviewerReassignment) []dto.ReviewerReassignment {
+	response := make([]dto.ReviewerReassignment, len(reassignments))
+	for i, reassignment := range reassignments {
+		response[i] = dto.ReviewerReassignment{
+			PullRequestId: reassignment.PullRequestID,
+			OldUserId:     reassignment.OldUserID,
+		}
+		if reassignment.NewUserID != "" {
+			newUserID := reassignment.NewUserID
+			response[i].NewUserId = &newUserID
+		}
+	}
+	return response
+}
+
+func toTeamSettingsDTO(settings *entity.TeamSettings) dto.TeamSettings {
+	response := dto.TeamSettings{
+		TeamName:      settings.TeamName,
+		ReviewerCount: settings.ReviewerCount,
+		MinRequired:   settings.MinRequired,
+	}
+	if settings.Strategy != "" {
+		strategy := dto.TeamSettingsStrategy(settings.Strategy)
+		response.Strategy = &strategy
+	}
+	requiredApprovals := settings.RequiredApprovals
+	response.RequiredApprovals = &requiredApprovals
+	maxOpenReviews := settings.MaxOpenReviews
+	response.MaxOpenReviews = &maxOpenReviews
+	return response
 }
 
 func (h *teamHandlers) handleError(w http.ResponseWriter, err error) {
@@ -127,8 +550,14 @@ func (h *teamHandlers) handleError(w http.ResponseWriter, err error) {
 		switch appErr.Code {
 		case entity.ErrorTeamExists:
 			writeErrorResponse(w, http.StatusBadRequest, appErr.Code, appErr.Message)
+		case entity.ErrorInvalidInput:
+			writeErrorResponse(w, http.StatusBadRequest, appErr.Code, appErr.Message)
 		case entity.ErrorNotFound:
 			writeErrorResponse(w, http.StatusNotFound, appErr.Code, appErr.Message)
+		case entity.ErrorForbidden:
+			writeErrorResponse(w, http.StatusForbidden, appErr.Code, appErr.Message)
+		case entity.ErrorTeamHasOpenPRs:
+			writeErrorResponse(w, http.StatusConflict, appErr.Code, appErr.Message)
 		default:
 			writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, appErr.Message)
 		}
@@ -0,0 +1,214 @@
+// internal/controller/http/v1/unavailability_handlers.go
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/PaulLocust/Avito-review/internal/dto"
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type unavailabilityHandlers struct {
+	unavailabilityUC usecase.UnavailabilityUseCase
+	logger           logger.Interface
+}
+
+func newUnavailabilityHandlers(unavailabilityUC usecase.UnavailabilityUseCase, l logger.Interface) *unavailabilityHandlers {
+	return &unavailabilityHandlers{
+		unavailabilityUC: unavailabilityUC,
+		logger:           l,
+	}
+}
+
+// CreateUnavailability добавляет период недоступности пользователя
+// @Summary Добавить период недоступности
+// @Description Во время периода [from, to) пользователь не выбирается ревьювером. Если включено фоновое переназначение, его открытые ревью переходят к другим участникам команды, когда период начинается
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostUsersUnavailabilityJSONBody true "Пользователь, границы периода в RFC3339 и причина"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 201 {object} map[string]interface{} "Период добавлен"
+// @Failure 400 {object} dto.ErrorResponse "Некорректные границы периода"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь из другой команды"
+// @Failure 404 {object} dto.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /users/unavailability [post]
+func (h *unavailabilityHandlers) createUnavailability(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/users/unavailability")
+
+	var req dto.PostUsersUnavailabilityJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	period := entity.Unavailability{UserID: req.UserId, From: req.From, To: req.To}
+	if req.Reason != nil {
+		period.Reason = *req.Reason
+	}
+
+	created, err := h.unavailabilityUC.CreateUnavailability(r.Context(), period)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
+		"unavailability": toUnavailabilityDTO(created),
+	})
+}
+
+// ListUnavailability возвращает периоды недоступности пользователя
+// @Summary Периоды недоступности пользователя
+// @Description Возвращает прошедшие, текущие и будущие периоды пользователя, отсортированные по началу
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param user_id query string true "Идентификатор пользователя"
+// @Success 200 {object} map[string]interface{} "Периоды недоступности"
+// @Failure 400 {object} dto.ErrorResponse "Не передан user_id"
+// @Failure 404 {object} dto.ErrorResponse "Пользователь не найден"
+// @Router /users/unavailability [get]
+func (h *unavailabilityHandlers) listUnavailability(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/users/unavailability")
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "user_id is required")
+		return
+	}
+
+	periods, err := h.unavailabilityUC.ListUnavailability(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response := make([]dto.Unavailability, len(periods))
+	for i := range periods {
+		response[i] = toUnavailabilityDTO(&periods[i])
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"user_id":        userID,
+		"unavailability": response,
+	})
+}
+
+// UpdateUnavailability меняет период недоступности
+// @Summary Изменить период недоступности
+// @Description Меняет границы и причину периода. После переноса границ открытые ревью снова переназначаются, когда начнется новый период
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostUsersUnavailabilityUpdateJSONBody true "Идентификатор периода, новые границы в RFC3339 и причина"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "Период изменен"
+// @Failure 400 {object} dto.ErrorResponse "Некорректные границы периода"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь из другой команды"
+// @Failure 404 {object} dto.ErrorResponse "Период не найден"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /users/unavailability/update [post]
+func (h *unavailabilityHandlers) updateUnavailability(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/users/unavailability/update")
+
+	var req dto.PostUsersUnavailabilityUpdateJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	period := entity.Unavailability{ID: req.Id, From: req.From, To: req.To}
+	if req.Reason != nil {
+		period.Reason = *req.Reason
+	}
+
+	updated, err := h.unavailabilityUC.UpdateUnavailability(r.Context(), period)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"unavailability": toUnavailabilityDTO(updated),
+	})
+}
+
+// DeleteUnavailability удаляет период недоступности
+// @Summary Удалить период недоступности
+// @Description Удаляет период; уже переназначенные ревью не возвращаются пользователю
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostUsersUnavailabilityDeleteJSONBody true "Идентификатор периода"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} map[string]interface{} "Период удален"
+// @Failure 400 {object} dto.ErrorResponse "Не передан id"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь из другой команды"
+// @Failure 404 {object} dto.ErrorResponse "Период не найден"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /users/unavailability/delete [post]
+func (h *unavailabilityHandlers) deleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/users/unavailability/delete")
+
+	var req dto.PostUsersUnavailabilityDeleteJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	if err := h.unavailabilityUC.DeleteUnavailability(r.Context(), req.Id); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"id": req.Id,
+	})
+}
+
+func toUnavailabilityDTO(period *entity.Unavailability) dto.Unavailability {
+	response := dto.Unavailability{
+		Id:           period.ID,
+		UserId:       period.UserID,
+		From:         period.From,
+		To:           period.To,
+		CreatedAt:    period.CreatedAt,
+		ReassignedAt: period.ReassignedAt,
+	}
+	if period.Reason != "" {
+		response.Reason = &period.Reason
+	}
+	return response
+}
+
+func (h *unavailabilityHandlers) handleError(w http.ResponseWriter, err error) {
+	var appErr entity.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Code {
+		case entity.ErrorInvalidInput:
+			writeErrorResponse(w, http.StatusBadRequest, appErr.Code, appErr.Message)
+		case entity.ErrorNotFound:
+			writeErrorResponse(w, http.StatusNotFound, appErr.Code, appErr.Message)
+		case entity.ErrorForbidden:
+			writeErrorResponse(w, http.StatusForbidden, appErr.Code, appErr.Message)
+		default:
+			writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, appErr.Message)
+		}
+	} else {
+		h.logger.Error("Internal server error: %v", err)
+		writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, "internal server error")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/PaulLocust/Avito-review/internal/dto"
 	"github.com/PaulLocust/Avito-review/internal/entity"
@@ -30,9 +31,13 @@ func newUserHandlers(userUC usecase.UserUseCase, l logger.Interface) *userHandle
 // @Tags Users
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth || BearerAuth
 // @Param request body dto.PostUsersSetIsActiveJSONBody true "Данные пользователя"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
 // @Success 200 {object} map[string]interface{} "Обновлённый пользователь"
 // @Failure 404 {object} dto.ErrorResponse "Пользователь не найден"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь состоит в другой команде"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
 // @Router /users/setIsActive [post]
 func (h *userHandlers) setIsActive(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info("POST /api/v1/users/setIsActive")
@@ -50,25 +55,167 @@ func (h *userHandlers) setIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"user": toUserDTO(user),
+	})
+}
+
+// GetUser возвращает пользователя
+// @Summary Получить пользователя
+// @Description Возвращает пользователя по идентификатору (удаленные пользователи не возвращаются) и его загрузку: число открытых ревью и действующий лимит max_open_reviews
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param user_id query string true "Идентификатор пользователя"
+// @Success 200 {object} map[string]interface{} "Пользователь и его загрузка"
+// @Failure 400 {object} dto.ErrorResponse "Не передан user_id"
+// @Failure 404 {object} dto.ErrorResponse "Пользователь не найден"
+// @Router /users/get [get]
+func (h *userHandlers) getUser(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/users/get")
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "user_id is required")
+		return
+	}
+
+	user, load, err := h.userUC.GetUser(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"user": toUserDTO(user),
+		"load": dto.ReviewLoad{
+			OpenReviews:    load.OpenReviews,
+			MaxOpenReviews: load.MaxOpenReviews,
+			AtCapacity:     load.AtCapacity,
+		},
+	})
+}
+
+// ListUsers возвращает пользователей с фильтрами
+// @Summary Список пользователей
+// @Description Возвращает неудаленных пользователей, отсортированных по user_id
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param team_name query string false "Команда пользователей"
+// @Param is_active query boolean false "Флаг активности"
+// @Success 200 {object} map[string]interface{} "Список пользователей"
+// @Failure 400 {object} dto.ErrorResponse "Некорректные параметры"
+// @Failure 404 {object} dto.ErrorResponse "Команда не найдена"
+// @Router /users/list [get]
+func (h *userHandlers) listUsers(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/users/list")
+
+	query := r.URL.Query()
+	filter := entity.UserFilter{TeamName: query.Get("team_name")}
+	if raw := query.Get("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "is_active must be a boolean")
+			return
+		}
+		filter.IsActive = &isActive
+	}
+
+	users, err := h.userUC.ListUsers(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
 	// Конвертируем в DTO
-	response := dto.User{
-		UserId:   user.ID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+	response := make([]dto.User, len(users))
+	for i := range users {
+		response[i] = toUserDTO(&users[i])
 	}
 
 	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
-		"user": response,
+		"users": response,
 	})
 }
 
+// UpdateUser меняет имя, команду и лимит открытых ревью пользователя
+// @Summary Изменить пользователя
+// @Description Меняет имя, команду и/или личный лимит открытых ревью пользователя (max_open_reviews, 0 - лимит команды). При переводе в другую команду открытые ревью пользователя переназначаются на активных участников прежней команды. Переводить может пользователь токена из прежней команды, новая команда должна существовать
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostUsersUpdateJSONBody true "Изменения пользователя"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} dto.UserChange "Обновлённый пользователь и замены в его ревью"
+// @Failure 400 {object} dto.ErrorResponse "Некорректные изменения"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь вне команды пользователя токена"
+// @Failure 404 {object} dto.ErrorResponse "Пользователь или команда не найдены"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /users/update [post]
+func (h *userHandlers) updateUser(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/users/update")
+
+	var req dto.PostUsersUpdateJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	result, err := h.userUC.UpdateUser(r.Context(), req.UserId, req.Username, req.TeamName, req.MaxOpenReviews)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toUserChangeDTO(result))
+}
+
+// DeleteUser мягко удаляет пользователя
+// @Summary Удалить пользователя
+// @Description Помечает пользователя удаленным и неактивным, его открытые ревью переназначаются на активных участников команды. Повторное добавление через /team/add восстанавливает пользователя
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostUsersDeleteJSONBody true "Идентификатор пользователя"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 200 {object} dto.UserChange "Удаленный пользователь и замены в его ревью"
+// @Failure 400 {object} dto.ErrorResponse "Не передан user_id"
+// @Failure 403 {object} dto.ErrorResponse "Пользователь состоит в другой команде"
+// @Failure 404 {object} dto.ErrorResponse "Пользователь не найден"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /users/delete [post]
+func (h *userHandlers) deleteUser(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/users/delete")
+
+	var req dto.PostUsersDeleteJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	result, err := h.userUC.DeleteUser(r.Context(), req.UserId)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toUserChangeDTO(result))
+}
+
 // GetReviews возвращает PR'ы, где пользователь назначен ревьювером
 // @Summary Получить PR'ы, где пользователь назначен ревьювером
 // @Description Возвращает список pull requests, назначенных пользователю на ревью
 // @Tags Users
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth || BearerAuth
 // @Param user_id query string true "Идентификатор пользователя"
 // @Success 200 {object} map[string]interface{} "Список PR'ов пользователя"
 // @Router /users/getReview [get]
@@ -104,12 +251,37 @@ func (h *userHandlers) getReviews(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func toUserDTO(user *entity.User) dto.User {
+	response := dto.User{
+		UserId:   user.ID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+	if user.MaxOpenReviews > 0 {
+		maxOpenReviews := user.MaxOpenReviews
+		response.MaxOpenReviews = &maxOpenReviews
+	}
+	return response
+}
+
+func toUserChangeDTO(change *entity.UserChange) dto.UserChange {
+	return dto.UserChange{
+		User:          toUserDTO(&change.User),
+		Reassignments: toReassignmentsDTO(change.Reassignments),
+	}
+}
+
 func (h *userHandlers) handleError(w http.ResponseWriter, err error) {
 	var appErr entity.AppError
 	if errors.As(err, &appErr) {
 		switch appErr.Code {
+		case entity.ErrorInvalidInput:
+			writeErrorResponse(w, http.StatusBadRequest, appErr.Code, appErr.Message)
 		case entity.ErrorNotFound:
 			writeErrorResponse(w, http.StatusNotFound, appErr.Code, appErr.Message)
+		case entity.ErrorForbidden:
+			writeErrorResponse(w, http.StatusForbidden, appErr.Code, appErr.Message)
 		default:
 			writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, appErr.Message)
 		}
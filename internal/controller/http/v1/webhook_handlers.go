@@ -0,0 +1,201 @@
+// internal/controller/http/v1/webhook_handlers.go
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/PaulLocust/Avito-review/internal/dto"
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/usecase"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type webhookHandlers struct {
+	webhookUC usecase.WebhookUseCase
+	logger    logger.Interface
+}
+
+func newWebhookHandlers(webhookUC usecase.WebhookUseCase, l logger.Interface) *webhookHandlers {
+	return &webhookHandlers{
+		webhookUC: webhookUC,
+		logger:    l,
+	}
+}
+
+// CreateWebhook регистрирует подписку на события
+// @Summary Подписать URL на события сервиса
+// @Description Регистрирует вебхук. События отправляются POST-запросом с JSON-телом и подписью X-Webhook-Signature: sha256=<HMAC-SHA256 тела по секрету>. Если secret не передан, он генерируется; секрет возвращается только в ответе на создание. Неуспешные доставки повторяются с экспоненциальной задержкой
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param request body dto.PostWebhooksJSONBody true "URL и типы событий"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ"
+// @Success 201 {object} map[string]interface{} "Вебхук создан"
+// @Failure 400 {object} dto.ErrorResponse "Некорректный URL или тип события"
+// @Failure 422 {object} dto.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Router /webhooks [post]
+func (h *webhookHandlers) createWebhook(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("POST /api/v1/webhooks")
+
+	var req dto.PostWebhooksJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("Failed to decode request body: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "invalid request body")
+		return
+	}
+
+	eventTypes := make([]entity.WebhookEventType, len(req.EventTypes))
+	for i, eventType := range req.EventTypes {
+		eventTypes[i] = entity.WebhookEventType(eventType)
+	}
+	var secret string
+	if req.Secret != nil {
+		secret = *req.Secret
+	}
+
+	webhook, err := h.webhookUC.CreateWebhook(r.Context(), req.Url, eventTypes, secret)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Секрет отдаём только при создании
+	response := toWebhookDTO(webhook)
+	response.Secret = &webhook.Secret
+
+	writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
+		"webhook": response,
+	})
+}
+
+// ListWebhooks возвращает подписки
+// @Summary Список вебхуков
+// @Description Возвращает зарегистрированные вебхуки без секретов
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Success 200 {object} map[string]interface{} "Список вебхуков"
+// @Router /webhooks [get]
+func (h *webhookHandlers) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/webhooks")
+
+	webhooks, err := h.webhookUC.ListWebhooks(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response := make([]dto.Webhook, len(webhooks))
+	for i := range webhooks {
+		response[i] = toWebhookDTO(&webhooks[i])
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"webhooks": response,
+	})
+}
+
+// ListDeliveries возвращает журнал доставок вебхука
+// @Summary Журнал доставок вебхука
+// @Description Возвращает последние доставки вебхука (новые первыми): статус, число попыток, код ответа и ошибку последней попытки
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth || BearerAuth
+// @Param webhook_id query int true "Идентификатор вебхука"
+// @Param limit query int false "Количество записей (1..100, по умолчанию 50)"
+// @Success 200 {object} map[string]interface{} "Журнал доставок"
+// @Failure 400 {object} dto.ErrorResponse "Некорректные параметры"
+// @Failure 404 {object} dto.ErrorResponse "Вебхук не найден"
+// @Router /webhooks/deliveries [get]
+func (h *webhookHandlers) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info("GET /api/v1/webhooks/deliveries")
+
+	query := r.URL.Query()
+	webhookID, err := strconv.ParseInt(query.Get("webhook_id"), 10, 64)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "webhook_id must be an integer")
+		return
+	}
+	var limit int
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, entity.ErrorInvalidInput, "limit must be an integer")
+			return
+		}
+	}
+
+	deliveries, err := h.webhookUC.ListDeliveries(r.Context(), webhookID, limit)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Конвертируем в DTO
+	response := make([]dto.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		var payload map[string]interface{}
+		if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+			h.logger.Error("Failed to decode payload of delivery %d: %v", delivery.ID, err)
+		}
+		response[i] = dto.WebhookDelivery{
+			Id:          delivery.ID,
+			WebhookId:   delivery.WebhookID,
+			EventId:     delivery.EventID,
+			EventType:   dto.WebhookEventType(delivery.EventType),
+			Payload:     payload,
+			Status:      dto.WebhookDeliveryStatus(delivery.Status),
+			Attempts:    delivery.Attempts,
+			CreatedAt:   delivery.CreatedAt,
+			UpdatedAt:   delivery.UpdatedAt,
+			DeliveredAt: delivery.DeliveredAt,
+		}
+		if delivery.ResponseCode != 0 {
+			responseCode := delivery.ResponseCode
+			response[i].ResponseCode = &responseCode
+		}
+		if delivery.LastError != "" {
+			lastError := delivery.LastError
+			response[i].LastError = &lastError
+		}
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"deliveries": response,
+	})
+}
+
+func toWebhookDTO(webhook *entity.Webhook) dto.Webhook {
+	response := dto.Webhook{
+		Id:         webhook.ID,
+		Url:        webhook.URL,
+		EventTypes: make([]dto.WebhookEventType, len(webhook.EventTypes)),
+		CreatedAt:  webhook.CreatedAt,
+	}
+	for i, eventType := range webhook.EventTypes {
+		response.EventTypes[i] = dto.WebhookEventType(eventType)
+	}
+	return response
+}
+
+func (h *webhookHandlers) handleError(w http.ResponseWriter, err error) {
+	var appErr entity.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Code {
+		case entity.ErrorInvalidInput:
+			writeErrorResponse(w, http.StatusBadRequest, appErr.Code, appErr.Message)
+		case entity.ErrorNotFound:
+			writeErrorResponse(w, http.StatusNotFound, appErr.Code, appErr.Message)
+		default:
+			writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, appErr.Message)
+		}
+	} else {
+		h.logger.Error("Internal server error: %v", err)
+		writeErrorResponse(w, http.StatusInternalServerError, entity.ErrorInvalidInput, "internal server error")
+	}
+}
@@ -7,28 +7,148 @@ import (
 	"time"
 )
 
+const (
+	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
+)
+
+// Defines values for ApiKeyRole.
+const (
+	Admin    ApiKeyRole = "admin"
+	Bot      ApiKeyRole = "bot"
+	ReadOnly ApiKeyRole = "read-only"
+	TeamLead ApiKeyRole = "team-lead"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	FORBIDDEN            ErrorResponseErrorCode = "FORBIDDEN"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INVALIDINPUT         ErrorResponseErrorCode = "INVALID_INPUT"
+	INVALIDTRANSITION    ErrorResponseErrorCode = "INVALID_TRANSITION"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTAPPROVED          ErrorResponseErrorCode = "NOT_APPROVED"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	PRCLOSED             ErrorResponseErrorCode = "PR_CLOSED"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	REQUESTINPROGRESS    ErrorResponseErrorCode = "REQUEST_IN_PROGRESS"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	TEAMHASOPENPRS       ErrorResponseErrorCode = "TEAM_HAS_OPEN_PRS"
+	UNAUTHORIZED         ErrorResponseErrorCode = "UNAUTHORIZED"
+)
+
+// Defines values for PRReviewerStatsStatus.
+const (
+	PRReviewerStatsStatusCLOSED PRReviewerStatsStatus = "CLOSED"
+	PRReviewerStatsStatusDRAFT  PRReviewerStatsStatus = "DRAFT"
+	PRReviewerStatsStatusMERGED PRReviewerStatsStatus = "MERGED"
+	PRReviewerStatsStatusOPEN   PRReviewerStatsStatus = "OPEN"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
+// Defines values for PullRequestEventType.
+const (
+	Closed           PullRequestEventType = "closed"
+	Created          PullRequestEventType = "created"
+	Merged           PullRequestEventType = "merged"
+	ReadyForReview   PullRequestEventType = "ready_for_review"
+	Reopened         PullRequestEventType = "reopened"
+	ReviewerAssigned PullRequestEventType = "reviewer_assigned"
+	ReviewerRemoved  PullRequestEventType = "reviewer_removed"
+	ReviewerReplaced PullRequestEventType = "reviewer_replaced"
+)
+
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for ReviewState.
+const (
+	ReviewStateAPPROVED         ReviewState = "APPROVED"
+	ReviewStateCHANGESREQUESTED ReviewState = "CHANGES_REQUESTED"
+	ReviewStateCOMMENTED        ReviewState = "COMMENTED"
+	ReviewStatePENDING          ReviewState = "PENDING"
+)
+
+// Defines values for TeamSettingsStrategy.
+const (
+	LeastLoaded TeamSettingsStrategy = "least_loaded"
+	Random      TeamSettingsStrategy = "random"
+)
+
+// Defines values for GetPullRequestListParamsStatus.
+const (
+	GetPullRequestListParamsStatusCLOSED GetPullRequestListParamsStatus = "CLOSED"
+	GetPullRequestListParamsStatusDRAFT  GetPullRequestListParamsStatus = "DRAFT"
+	GetPullRequestListParamsStatusMERGED GetPullRequestListParamsStatus = "MERGED"
+	GetPullRequestListParamsStatusOPEN   GetPullRequestListParamsStatus = "OPEN"
+)
+
+// Defines values for GetPullRequestListParamsOrder.
+const (
+	Asc  GetPullRequestListParamsOrder = "asc"
+	Desc GetPullRequestListParamsOrder = "desc"
+)
+
+// Defines values for PostPullRequestReviewJSONBodyState.
+const (
+	PostPullRequestReviewJSONBodyStateAPPROVED         PostPullRequestReviewJSONBodyState = "APPROVED"
+	PostPullRequestReviewJSONBodyStateCHANGESREQUESTED PostPullRequestReviewJSONBodyState = "CHANGES_REQUESTED"
+	PostPullRequestReviewJSONBodyStateCOMMENTED        PostPullRequestReviewJSONBodyState = "COMMENTED"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Delivered WebhookDeliveryStatus = "delivered"
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for WebhookEventType.
+const (
+	PrCreated          WebhookEventType = "pr.created"
+	PrMerged           WebhookEventType = "pr.merged"
+	ReviewerReassigned WebhookEventType = "reviewer.reassigned"
+	UserDeactivated    WebhookEventType = "user.deactivated"
+)
+
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        int64     `json:"id"`
+
+	// Key Значение ключа (только в ответе на выдачу)
+	Key  *string `json:"key,omitempty"`
+	Name string  `json:"name"`
+
+	// Prefix Начало ключа для отличия ключей в списке
+	Prefix    string     `json:"prefix"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Role      ApiKeyRole `json:"role"`
+}
+
+// ApiKeyRole defines model for ApiKey.Role.
+type ApiKeyRole string
+
+// AssignmentStats defines model for AssignmentStats.
+type AssignmentStats struct {
+	PullRequests []PRReviewerStats     `json:"pull_requests"`
+	Teams        []TeamAssignmentStats `json:"teams"`
+	Users        []UserAssignmentStats `json:"users"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -40,21 +160,75 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// PRReviewerStats defines model for PRReviewerStats.
+type PRReviewerStats struct {
+	AuthorId      string                `json:"author_id"`
+	PullRequestId string                `json:"pull_request_id"`
+	ReviewerCount int                   `json:"reviewer_count"`
+	Status        PRReviewerStatsStatus `json:"status"`
+}
+
+// PRReviewerStatsStatus defines model for PRReviewerStats.Status.
+type PRReviewerStatsStatus string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
 	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	AuthorId          string   `json:"author_id"`
+
+	// AuthorTeam Команда автора (только в /pullRequest/get)
+	AuthorTeam *string `json:"author_team,omitempty"`
+
+	// CapacityWarning Назначено меньше reviewer_count ревьюверов, потому что остальные участники достигли max_open_reviews (только в ответе на назначение)
+	CapacityWarning *bool      `json:"capacity_warning,omitempty"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	MergedAt        *time.Time `json:"mergedAt"`
+	PullRequestId   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+
+	// Reviews Решения назначенных ревьюверов
+	Reviews []Review          `json:"reviews"`
+	Status  PullRequestStatus `json:"status"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
+// PullRequestEvent defines model for PullRequestEvent.
+type PullRequestEvent struct {
+	// Actor Инициатор изменения (владелец ключа или пользователь токена, X-Actor от admin и bot, по умолчанию system)
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        int64     `json:"id"`
+
+	// NewUserId Назначенный ревьювер
+	NewUserId *string `json:"new_user_id,omitempty"`
+
+	// OldUserId Снятый ревьювер
+	OldUserId *string `json:"old_user_id,omitempty"`
+
+	// Reason Причина изменения состава ревьюверов (create, ready, reopen, reassign, deactivation, user_moved, user_deleted, unavailable)
+	Reason *string              `json:"reason,omitempty"`
+	Type   PullRequestEventType `json:"type"`
+}
+
+// PullRequestEventType defines model for PullRequestEvent.Type.
+type PullRequestEventType string
+
+// PullRequestHistory defines model for PullRequestHistory.
+type PullRequestHistory struct {
+	Events        []PullRequestEvent `json:"events"`
+	PullRequestId string             `json:"pull_request_id"`
+}
+
+// PullRequestPage defines model for PullRequestPage.
+type PullRequestPage struct {
+	// NextCursor Курсор следующей страницы (отсутствует на последней странице)
+	NextCursor   *string       `json:"next_cursor,omitempty"`
+	PullRequests []PullRequest `json:"pull_requests"`
+}
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
 	AuthorId        string                 `json:"author_id"`
@@ -66,12 +240,72 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// Review defines model for Review.
+type Review struct {
+	State  ReviewState `json:"state"`
+	UserId string      `json:"user_id"`
+}
+
+// ReviewLoad defines model for ReviewLoad.
+type ReviewLoad struct {
+	// AtCapacity Пользователь достиг лимита и не получает новые ревью
+	AtCapacity bool `json:"at_capacity"`
+
+	// MaxOpenReviews Действующий лимит: личный или команды (0 - без ограничения)
+	MaxOpenReviews int `json:"max_open_reviews"`
+
+	// OpenReviews Число открытых PR, где пользователь назначен ревьювером
+	OpenReviews int `json:"open_reviews"`
+}
+
+// ReviewState defines model for ReviewState.
+type ReviewState string
+
+// ReviewerReassignment defines model for ReviewerReassignment.
+type ReviewerReassignment struct {
+	// NewUserId user_id нового ревьювера (отсутствует, если замены не нашлось и ревьювер снят)
+	NewUserId     *string `json:"new_user_id,omitempty"`
+	OldUserId     string  `json:"old_user_id"`
+	PullRequestId string  `json:"pull_request_id"`
+}
+
 // Team defines model for Team.
 type Team struct {
 	Members  []TeamMember `json:"members"`
 	TeamName string       `json:"team_name"`
 }
 
+// TeamAssignmentStats defines model for TeamAssignmentStats.
+type TeamAssignmentStats struct {
+	Merged   int    `json:"merged"`
+	Open     int    `json:"open"`
+	TeamName string `json:"team_name"`
+	Total    int    `json:"total"`
+}
+
+// TeamChange defines model for TeamChange.
+type TeamChange struct {
+	// Reassignments Замены затронутых пользователей в их открытых ревью
+	Reassignments []ReviewerReassignment `json:"reassignments"`
+	Team          Team                   `json:"team"`
+}
+
+// TeamDeactivation defines model for TeamDeactivation.
+type TeamDeactivation struct {
+	DeactivatedUsers []string               `json:"deactivated_users"`
+	Reassignments    []ReviewerReassignment `json:"reassignments"`
+	TeamName         string                 `json:"team_name"`
+}
+
+// TeamDeletion defines model for TeamDeletion.
+type TeamDeletion struct {
+	// ClosedPullRequests Незавершенные PR авторов из команды, закрытые принудительным удалением
+	ClosedPullRequests []string               `json:"closed_pull_requests"`
+	DeletedUsers       []string               `json:"deleted_users"`
+	Reassignments      []ReviewerReassignment `json:"reassignments"`
+	TeamName           string                 `json:"team_name"`
+}
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
 	IsActive bool   `json:"is_active"`
@@ -79,67 +313,631 @@ type TeamMember struct {
 	Username string `json:"username"`
 }
 
+// TeamSettings defines model for TeamSettings.
+type TeamSettings struct {
+	// MaxOpenReviews Лимит открытых ревью участника по умолчанию (0 - без ограничения; если не задано - не меняется)
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+
+	// MinRequired Минимальное число ревьюверов, без которого PR не создаётся
+	MinRequired int `json:"min_required"`
+
+	// RequiredApprovals Сколько одобрений нужно для мерджа PR (0 - мердж без одобрений; если не задано - не меняется)
+	RequiredApprovals *int `json:"required_approvals,omitempty"`
+
+	// ReviewerCount Сколько ревьюверов назначать при создании PR
+	ReviewerCount int `json:"reviewer_count"`
+
+	// Strategy Стратегия выбора ревьюверов (если не задана - стратегия сервиса по умолчанию)
+	Strategy *TeamSettingsStrategy `json:"strategy,omitempty"`
+	TeamName string                `json:"team_name"`
+}
+
+// TeamSettingsStrategy Стратегия выбора ревьюверов (если не задана - стратегия сервиса по умолчанию)
+type TeamSettingsStrategy string
+
+// TeamSummary defines model for TeamSummary.
+type TeamSummary struct {
+	ActiveMembersCount int    `json:"active_members_count"`
+	MembersCount       int    `json:"members_count"`
+	TeamName           string `json:"team_name"`
+}
+
+// TeamSync defines model for TeamSync.
+type TeamSync struct {
+	// Added Новые, восстановленные и перешедшие из других команд участники
+	Added []TeamMember `json:"added"`
+
+	// Deactivated Участники, отсутствующие в списке или переданные неактивными
+	Deactivated []string `json:"deactivated"`
+
+	// DryRun Изменения только рассчитаны и не сохранены
+	DryRun        bool                   `json:"dry_run"`
+	Reassignments []ReviewerReassignment `json:"reassignments"`
+	TeamCreated   bool                   `json:"team_created"`
+	TeamName      string                 `json:"team_name"`
+
+	// Updated Участники, у которых изменилось имя или которые снова активны
+	Updated []TeamMember `json:"updated"`
+}
+
+// Unavailability defines model for Unavailability.
+type Unavailability struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// From Начало периода (включительно)
+	From time.Time `json:"from"`
+	Id   int64     `json:"id"`
+
+	// ReassignedAt Когда открытые ревью пользователя переназначены после начала периода
+	ReassignedAt *time.Time `json:"reassigned_at,omitempty"`
+	Reason       *string    `json:"reason,omitempty"`
+
+	// To Конец периода (не включительно)
+	To     time.Time `json:"to"`
+	UserId string    `json:"user_id"`
+}
+
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
+	IsActive bool `json:"is_active"`
+
+	// MaxOpenReviews Личный лимит открытых ревью (если не задан - лимит команды)
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
+	TeamName       string `json:"team_name"`
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+}
+
+// UserAssignmentStats defines model for UserAssignmentStats.
+type UserAssignmentStats struct {
+	Merged   int    `json:"merged"`
+	Open     int    `json:"open"`
 	TeamName string `json:"team_name"`
+	Total    int    `json:"total"`
 	UserId   string `json:"user_id"`
 	Username string `json:"username"`
 }
 
+// UserChange defines model for UserChange.
+type UserChange struct {
+	// Reassignments Замены пользователя в его открытых ревью
+	Reassignments []ReviewerReassignment `json:"reassignments"`
+	User          User                   `json:"user"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt  time.Time          `json:"created_at"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	Id         int64              `json:"id"`
+
+	// Secret Ключ HMAC-подписи (только в ответе на создание)
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int              `json:"attempts"`
+	CreatedAt   time.Time        `json:"created_at"`
+	DeliveredAt *time.Time       `json:"delivered_at,omitempty"`
+	EventId     string           `json:"event_id"`
+	EventType   WebhookEventType `json:"event_type"`
+	Id          int64            `json:"id"`
+
+	// LastError Ошибка последней попытки
+	LastError *string `json:"last_error,omitempty"`
+
+	// Payload Тело запроса доставки
+	Payload map[string]interface{} `json:"payload"`
+
+	// ResponseCode Код ответа последней попытки
+	ResponseCode *int                  `json:"response_code,omitempty"`
+	Status       WebhookDeliveryStatus `json:"status"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	WebhookId    int64                 `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// PostApiKeysJSONBody defines parameters for PostApiKeys.
+type PostApiKeysJSONBody struct {
+	Name string     `json:"name"`
+	Role ApiKeyRole `json:"role"`
+}
+
+// PostApiKeysParams defines parameters for PostApiKeys.
+type PostApiKeysParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostApiKeysRevokeJSONBody defines parameters for PostApiKeysRevoke.
+type PostApiKeysRevokeJSONBody struct {
+	Id int64 `json:"id"`
+}
+
+// PostApiKeysRevokeParams defines parameters for PostApiKeysRevoke.
+type PostApiKeysRevokeParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestCloseParams defines parameters for PostPullRequestClose.
+type PostPullRequestCloseParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
+	AuthorId string `json:"author_id"`
+
+	// Draft Создать черновик (DRAFT) без ревьюверов
+	Draft           *bool  `json:"draft,omitempty"`
 	PullRequestId   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 }
 
+// PostPullRequestCreateParams defines parameters for PostPullRequestCreate.
+type PostPullRequestCreateParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetPullRequestGetParams defines parameters for GetPullRequestGet.
+type GetPullRequestGetParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestHistoryParams defines parameters for GetPullRequestHistory.
+type GetPullRequestHistoryParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	// Status Статус PR
+	Status *GetPullRequestListParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// AuthorId Автор PR
+	AuthorId *string `form:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// ReviewerId Назначенный ревьювер
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName Команда автора PR
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Name Подстрока названия PR (без учёта регистра)
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// CreatedFrom PR, созданные не раньше этого момента
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo PR, созданные раньше этого момента
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// MergedFrom PR, смердженные не раньше этого момента
+	MergedFrom *time.Time `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+
+	// MergedTo PR, смердженные раньше этого момента
+	MergedTo *time.Time `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+
+	// Order Сортировка по времени создания (по умолчанию desc)
+	Order *GetPullRequestListParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Размер страницы (по умолчанию 20)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor предыдущей страницы
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetPullRequestListParamsStatus defines parameters for GetPullRequestList.
+type GetPullRequestListParamsStatus string
+
+// GetPullRequestListParamsOrder defines parameters for GetPullRequestList.
+type GetPullRequestListParamsOrder string
+
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestMergeParams defines parameters for PostPullRequestMerge.
+type PostPullRequestMergeParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostPullRequestReadyJSONBody defines parameters for PostPullRequestReady.
+type PostPullRequestReadyJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReadyParams defines parameters for PostPullRequestReady.
+type PostPullRequestReadyParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
 	OldUserId     string `json:"old_user_id"`
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReassignParams defines parameters for PostPullRequestReassign.
+type PostPullRequestReassignParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReopenParams defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	PullRequestId string                             `json:"pull_request_id"`
+	State         PostPullRequestReviewJSONBodyState `json:"state"`
+	UserId        string                             `json:"user_id"`
+}
+
+// PostPullRequestReviewParams defines parameters for PostPullRequestReview.
+type PostPullRequestReviewParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostPullRequestReviewJSONBodyState defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBodyState string
+
+// GetStatsAssignmentsParams defines parameters for GetStatsAssignments.
+type GetStatsAssignmentsParams struct {
+	// TeamName Ограничить статистику командой
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// From Учитывать PR, созданные не раньше этого момента
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Учитывать PR, созданные раньше этого момента
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// PostTeamAddParams defines parameters for PostTeamAdd.
+type PostTeamAddParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostTeamAddMembersJSONBody defines parameters for PostTeamAddMembers.
+type PostTeamAddMembersJSONBody struct {
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
+// PostTeamAddMembersParams defines parameters for PostTeamAddMembers.
+type PostTeamAddMembersParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostTeamDeactivateUsersJSONBody defines parameters for PostTeamDeactivateUsers.
+type PostTeamDeactivateUsersJSONBody struct {
+	TeamName string   `json:"team_name"`
+	UserIds  []string `json:"user_ids"`
+}
+
+// PostTeamDeactivateUsersParams defines parameters for PostTeamDeactivateUsers.
+type PostTeamDeactivateUsersParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostTeamDeleteJSONBody defines parameters for PostTeamDelete.
+type PostTeamDeleteJSONBody struct {
+	// Force Закрыть незавершенные PR авторов из команды вместо отказа
+	Force    *bool  `json:"force,omitempty"`
+	TeamName string `json:"team_name"`
+}
+
+// PostTeamDeleteParams defines parameters for PostTeamDelete.
+type PostTeamDeleteParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // GetTeamGetParams defines parameters for GetTeamGet.
 type GetTeamGetParams struct {
 	// TeamName Уникальное имя команды
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// PostTeamRemoveMembersJSONBody defines parameters for PostTeamRemoveMembers.
+type PostTeamRemoveMembersJSONBody struct {
+	TeamName string   `json:"team_name"`
+	UserIds  []string `json:"user_ids"`
+}
+
+// PostTeamRemoveMembersParams defines parameters for PostTeamRemoveMembers.
+type PostTeamRemoveMembersParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostTeamRenameJSONBody defines parameters for PostTeamRename.
+type PostTeamRenameJSONBody struct {
+	// NewTeamName Новое имя команды
+	NewTeamName string `json:"new_team_name"`
+	TeamName    string `json:"team_name"`
+}
+
+// PostTeamRenameParams defines parameters for PostTeamRename.
+type PostTeamRenameParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetTeamSettingsParams defines parameters for GetTeamSettings.
+type GetTeamSettingsParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// PostTeamSettingsParams defines parameters for PostTeamSettings.
+type PostTeamSettingsParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PutTeamSyncParams defines parameters for PutTeamSync.
+type PutTeamSyncParams struct {
+	// DryRun Только рассчитать расхождение, не применяя его
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostUsersDeleteJSONBody defines parameters for PostUsersDelete.
+type PostUsersDeleteJSONBody struct {
+	UserId string `json:"user_id"`
+}
+
+// PostUsersDeleteParams defines parameters for PostUsersDelete.
+type PostUsersDeleteParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetUsersGetParams defines parameters for GetUsersGet.
+type GetUsersGetParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
 }
 
+// GetUsersListParams defines parameters for GetUsersList.
+type GetUsersListParams struct {
+	// TeamName Команда пользователей
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// IsActive Флаг активности
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+}
+
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
 type PostUsersSetIsActiveJSONBody struct {
 	IsActive bool   `json:"is_active"`
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersSetIsActiveParams defines parameters for PostUsersSetIsActive.
+type PostUsersSetIsActiveParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetUsersUnavailabilityParams defines parameters for GetUsersUnavailability.
+type GetUsersUnavailabilityParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// PostUsersUnavailabilityJSONBody defines parameters for PostUsersUnavailability.
+type PostUsersUnavailabilityJSONBody struct {
+	// From Начало периода (включительно)
+	From time.Time `json:"from"`
+
+	// Reason Причина (отпуск, больничный)
+	Reason *string `json:"reason,omitempty"`
+
+	// To Конец периода (не включительно)
+	To     time.Time `json:"to"`
+	UserId string    `json:"user_id"`
+}
+
+// PostUsersUnavailabilityParams defines parameters for PostUsersUnavailability.
+type PostUsersUnavailabilityParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostUsersUnavailabilityDeleteJSONBody defines parameters for PostUsersUnavailabilityDelete.
+type PostUsersUnavailabilityDeleteJSONBody struct {
+	Id int64 `json:"id"`
+}
+
+// PostUsersUnavailabilityDeleteParams defines parameters for PostUsersUnavailabilityDelete.
+type PostUsersUnavailabilityDeleteParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostUsersUnavailabilityUpdateJSONBody defines parameters for PostUsersUnavailabilityUpdate.
+type PostUsersUnavailabilityUpdateJSONBody struct {
+	// From Начало периода (включительно)
+	From time.Time `json:"from"`
+	Id   int64     `json:"id"`
+
+	// Reason Причина (отпуск, больничный)
+	Reason *string `json:"reason,omitempty"`
+
+	// To Конец периода (не включительно)
+	To time.Time `json:"to"`
+}
+
+// PostUsersUnavailabilityUpdateParams defines parameters for PostUsersUnavailabilityUpdate.
+type PostUsersUnavailabilityUpdateParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostUsersUpdateJSONBody defines parameters for PostUsersUpdate.
+type PostUsersUpdateJSONBody struct {
+	// MaxOpenReviews Личный лимит открытых ревью (0 - лимит команды)
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+
+	// TeamName Новая команда; открытые ревью пользователя переназначаются внутри прежней команды
+	TeamName *string `json:"team_name,omitempty"`
+	UserId   string  `json:"user_id"`
+
+	// Username Новое имя пользователя
+	Username *string `json:"username,omitempty"`
+}
+
+// PostUsersUpdateParams defines parameters for PostUsersUpdate.
+type PostUsersUpdateParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetWebhooksDeliveriesParams defines parameters for GetWebhooksDeliveries.
+type GetWebhooksDeliveriesParams struct {
+	// WebhookId Идентификатор вебхука
+	WebhookId int64 `form:"webhook_id" json:"webhook_id"`
+
+	// Limit Количество записей (по умолчанию 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostWebhooksJSONBody defines parameters for PostWebhooks.
+type PostWebhooksJSONBody struct {
+	EventTypes []WebhookEventType `json:"event_types"`
+
+	// Secret Ключ HMAC-подписи (если не задан - генерируется)
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// PostWebhooksParams defines parameters for PostWebhooks.
+type PostWebhooksParams struct {
+	// IdempotencyKey Ключ идемпотентности: повтор запроса с тем же ключом возвращает сохраненный ответ
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostApiKeysJSONRequestBody defines body for PostApiKeys for application/json ContentType.
+type PostApiKeysJSONRequestBody PostApiKeysJSONBody
+
+// PostApiKeysRevokeJSONRequestBody defines body for PostApiKeysRevoke for application/json ContentType.
+type PostApiKeysRevokeJSONRequestBody PostApiKeysRevokeJSONBody
+
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 
+// PostPullRequestReadyJSONRequestBody defines body for PostPullRequestReady for application/json ContentType.
+type PostPullRequestReadyJSONRequestBody PostPullRequestReadyJSONBody
+
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamAddMembersJSONRequestBody defines body for PostTeamAddMembers for application/json ContentType.
+type PostTeamAddMembersJSONRequestBody PostTeamAddMembersJSONBody
+
+// PostTeamDeactivateUsersJSONRequestBody defines body for PostTeamDeactivateUsers for application/json ContentType.
+type PostTeamDeactivateUsersJSONRequestBody PostTeamDeactivateUsersJSONBody
+
+// PostTeamDeleteJSONRequestBody defines body for PostTeamDelete for application/json ContentType.
+type PostTeamDeleteJSONRequestBody PostTeamDeleteJSONBody
+
+// PostTeamRemoveMembersJSONRequestBody defines body for PostTeamRemoveMembers for application/json ContentType.
+type PostTeamRemoveMembersJSONRequestBody PostTeamRemoveMembersJSONBody
+
+// PostTeamRenameJSONRequestBody defines body for PostTeamRename for application/json ContentType.
+type PostTeamRenameJSONRequestBody PostTeamRenameJSONBody
+
+// PostTeamSettingsJSONRequestBody defines body for PostTeamSettings for application/json ContentType.
+type PostTeamSettingsJSONRequestBody = TeamSettings
+
+// PutTeamSyncJSONRequestBody defines body for PutTeamSync for application/json ContentType.
+type PutTeamSyncJSONRequestBody = Team
+
+// PostUsersDeleteJSONRequestBody defines body for PostUsersDelete for application/json ContentType.
+type PostUsersDeleteJSONRequestBody PostUsersDeleteJSONBody
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostUsersUnavailabilityJSONRequestBody defines body for PostUsersUnavailability for application/json ContentType.
+type PostUsersUnavailabilityJSONRequestBody PostUsersUnavailabilityJSONBody
+
+// PostUsersUnavailabilityDeleteJSONRequestBody defines body for PostUsersUnavailabilityDelete for application/json ContentType.
+type PostUsersUnavailabilityDeleteJSONRequestBody PostUsersUnavailabilityDeleteJSONBody
+
+// PostUsersUnavailabilityUpdateJSONRequestBody defines body for PostUsersUnavailabilityUpdate for application/json ContentType.
+type PostUsersUnavailabilityUpdateJSONRequestBody PostUsersUnavailabilityUpdateJSONBody
+
+// PostUsersUpdateJSONRequestBody defines body for PostUsersUpdate for application/json ContentType.
+type PostUsersUpdateJSONRequestBody PostUsersUpdateJSONBody
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody PostWebhooksJSONBody
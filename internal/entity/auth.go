@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+)
+
+// Role - роль владельца API-ключа
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team-lead"
+	RoleBot      Role = "bot"
+	RoleReadOnly Role = "read-only"
+)
+
+// Permission - право на группу операций API
+type Permission string
+
+const (
+	PermissionRead        Permission = "read"         // чтение команд, пользователей, PR и статистики
+	PermissionReview      Permission = "review"       // создание PR, смена статуса, переназначение, ревью
+	PermissionManageTeams Permission = "manage_teams" // команды, их настройки и активность пользователей
+	PermissionAdmin       Permission = "admin"        // вебхуки и API-ключи
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin:    {PermissionRead, PermissionReview, PermissionManageTeams, PermissionAdmin},
+	RoleTeamLead: {PermissionRead, PermissionReview, PermissionManageTeams},
+	RoleBot:      {PermissionRead, PermissionReview},
+	RoleReadOnly: {PermissionRead},
+}
+
+func (r Role) IsKnown() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can сообщает, есть ли у роли право permission
+func (r Role) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey - выданный API-ключ. Сам ключ не хранится, только его SHA-256
+type APIKey struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Role      Role       `json:"role"`
+	Prefix    string     `json:"prefix"` // начало ключа, чтобы отличать ключи в списке
+	KeyHash   string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Principal возвращает инициатора запросов, аутентифицированных ключом
+func (k *APIKey) Principal() *Principal {
+	return &Principal{Name: k.Name, Role: k.Role, KeyID: k.ID}
+}
+
+// Principal - аутентифицированный инициатор запроса
+type Principal struct {
+	Name string
+	Role Role
+	// UserID - пользователь сервиса, от имени которого действует bearer-токен.
+	// У API-ключей пусто: ограничения по команде и автору PR к ним не применяются
+	UserID string
+	// KeyID - идентификатор API-ключа; 0 у пользователей токена и встроенных инициаторов (bootstrap, anonymous)
+	KeyID int64
+}
+
+// IdempotencyScope возвращает пространство ключей идемпотентности инициатора. Имя не подходит:
+// имена API-ключей не уникальны и могут совпасть с user_id пользователя токена
+func (p *Principal) IdempotencyScope() string {
+	switch {
+	case p.KeyID != 0:
+		return "key:" + strconv.FormatInt(p.KeyID, 10)
+	case p.UserID != "":
+		return "jwt:" + p.UserID
+	default:
+		return "builtin:" + p.Name
+	}
+}
@@ -5,13 +5,22 @@ import "fmt"
 type ErrorCode string
 
 const (
-	ErrorTeamExists   ErrorCode = "TEAM_EXISTS"
-	ErrorPRExists     ErrorCode = "PR_EXISTS"
-	ErrorPRMerged     ErrorCode = "PR_MERGED"
-	ErrorNotAssigned  ErrorCode = "NOT_ASSIGNED"
-	ErrorNoCandidate  ErrorCode = "NO_CANDIDATE"
-	ErrorNotFound     ErrorCode = "NOT_FOUND"
-	ErrorInvalidInput ErrorCode = "INVALID_INPUT"
+	ErrorTeamExists        ErrorCode = "TEAM_EXISTS"
+	ErrorPRExists          ErrorCode = "PR_EXISTS"
+	ErrorPRMerged          ErrorCode = "PR_MERGED"
+	ErrorPRClosed          ErrorCode = "PR_CLOSED"
+	ErrorNotAssigned       ErrorCode = "NOT_ASSIGNED"
+	ErrorNoCandidate       ErrorCode = "NO_CANDIDATE"
+	ErrorNotFound          ErrorCode = "NOT_FOUND"
+	ErrorInvalidInput      ErrorCode = "INVALID_INPUT"
+	ErrorNotApproved       ErrorCode = "NOT_APPROVED"
+	ErrorInvalidTransition ErrorCode = "INVALID_TRANSITION"
+	ErrorUnauthorized      ErrorCode = "UNAUTHORIZED"
+	ErrorForbidden         ErrorCode = "FORBIDDEN"
+	ErrorTeamHasOpenPRs    ErrorCode = "TEAM_HAS_OPEN_PRS"
+
+	ErrorIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrorRequestInProgress    ErrorCode = "REQUEST_IN_PROGRESS"
 )
 
 type AppError struct {
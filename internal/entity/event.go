@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// PREventType - тип события в журнале изменений PR
+type PREventType string
+
+const (
+	EventCreated          PREventType = "created"
+	EventReviewerAssigned PREventType = "reviewer_assigned"
+	EventReviewerReplaced PREventType = "reviewer_replaced"
+	EventReviewerRemoved  PREventType = "reviewer_removed"
+	EventMerged           PREventType = "merged"
+	EventClosed           PREventType = "closed"
+	EventReopened         PREventType = "reopened"
+	EventReadyForReview   PREventType = "ready_for_review"
+)
+
+// Причины изменения состава ревьюверов
+const (
+	ReasonCreate       = "create"
+	ReasonReassign     = "reassign"
+	ReasonReady        = "ready"
+	ReasonReopen       = "reopen"
+	ReasonDeactivation = "deactivation"
+	ReasonUserMoved    = "user_moved"
+	ReasonUserDeleted  = "user_deleted"
+	ReasonUnavailable  = "unavailable"
+)
+
+// PREvent - запись журнала изменений PR (только добавление)
+type PREvent struct {
+	ID            int64       `json:"id"`
+	PullRequestID string      `json:"pull_request_id"`
+	Type          PREventType `json:"type"`
+	Actor         string      `json:"actor"`
+	OldUserID     string      `json:"old_user_id,omitempty"`
+	NewUserID     string      `json:"new_user_id,omitempty"`
+	Reason        string      `json:"reason,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// NewPREvent создает запись журнала для PR с текущим временем
+func NewPREvent(prID string, eventType PREventType, actor string) PREvent {
+	return PREvent{
+		PullRequestID: prID,
+		Type:          eventType,
+		Actor:         actor,
+		CreatedAt:     time.Now(),
+	}
+}
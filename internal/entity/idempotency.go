@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// IdempotencyRecord - ответ на запрос с заголовком Idempotency-Key, повторяемый для его дубликатов
+type IdempotencyRecord struct {
+	Scope       string // инициатор запроса: одинаковые ключи разных клиентов не пересекаются
+	Key         string
+	Fingerprint string // хэш метода, пути и тела запроса
+	StatusCode  int    // 0 - запрос еще выполняется
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.StatusCode != 0
+}
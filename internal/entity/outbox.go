@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxMessage - событие, записанное в outbox в одной транзакции с изменением и ожидающее публикации
+type OutboxMessage struct {
+	ID        int64            `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	Payload   json.RawMessage  `json:"payload"` // данные события
+	Attempts  int              `json:"attempts"`
+	LastError string           `json:"last_error,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	SentAt    *time.Time       `json:"sent_at,omitempty"`
+}
@@ -5,18 +5,70 @@ import "time"
 type PRStatus string
 
 const (
+	StatusDraft  PRStatus = "DRAFT"  // ревьюверы не назначаются, пока PR не помечен готовым
 	StatusOpen   PRStatus = "OPEN"
 	StatusMerged PRStatus = "MERGED"
+	StatusClosed PRStatus = "CLOSED" // закрыт без мерджа
 )
 
+// ReviewState - решение ревьювера по PR
+type ReviewState string
+
+const (
+	ReviewPending          ReviewState = "PENDING"
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewCommented        ReviewState = "COMMENTED"
+)
+
+// IsDecision сообщает, может ли ревьювер выставить это состояние сам
+func (s ReviewState) IsDecision() bool {
+	switch s {
+	case ReviewApproved, ReviewChangesRequested, ReviewCommented:
+		return true
+	}
+	return false
+}
+
 type PullRequest struct {
 	ID               string     `json:"pull_request_id"`
 	Name             string     `json:"pull_request_name"`
 	AuthorID         string     `json:"author_id"`
+	AuthorTeam       string     `json:"author_team,omitempty"` // заполняется только в GetPR usecase
 	Status           PRStatus   `json:"status"`
 	AssignedReviewers []string  `json:"assigned_reviewers"`
+	Reviews          []Review   `json:"reviews"`
 	CreatedAt        time.Time  `json:"createdAt"`
 	MergedAt         *time.Time `json:"mergedAt,omitempty"`
+	// CapacityWarning заполняется при назначении: ревьюверов меньше reviewer_count, потому что остальные кандидаты
+	// достигли лимита открытых ревью
+	CapacityWarning bool `json:"capacity_warning,omitempty"`
+}
+
+// Review - состояние ревью одного назначенного ревьювера
+type Review struct {
+	ReviewerID string      `json:"user_id"`
+	State      ReviewState `json:"state"`
+}
+
+// Approvals возвращает число ревьюверов, одобривших PR
+func (pr *PullRequest) Approvals() int {
+	count := 0
+	for _, review := range pr.Reviews {
+		if review.State == ReviewApproved {
+			count++
+		}
+	}
+	return count
+}
+
+// PendingReviews возвращает список ревью в состоянии PENDING для указанных ревьюверов
+func PendingReviews(reviewerIDs []string) []Review {
+	reviews := make([]Review, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		reviews = append(reviews, Review{ReviewerID: id, State: ReviewPending})
+	}
+	return reviews
 }
 
 // Short version for user reviews list
@@ -25,4 +77,4 @@ type PullRequestShort struct {
 	Name     string   `json:"pull_request_name"`
 	AuthorID string   `json:"author_id"`
 	Status   PRStatus `json:"status"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// SortOrder - направление сортировки списка PR по времени создания
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// PRCursor - позиция последнего PR предыдущей страницы (keyset-пагинация по created_at, id)
+type PRCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+// PRFilter - фильтр списка PR (пустые поля не ограничивают выборку)
+type PRFilter struct {
+	Status       PRStatus
+	AuthorID     string
+	ReviewerID   string
+	TeamName     string // команда автора
+	NameContains string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	MergedFrom   *time.Time
+	MergedTo     *time.Time
+	After        *PRCursor // PR строго после курсора в порядке Order
+	Order        SortOrder
+	Limit        int
+}
+
+// PRPage - страница списка PR; NextCursor пуст на последней странице
+type PRPage struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// StatsFilter - фильтр статистики назначений (пустые поля не ограничивают выборку)
+type StatsFilter struct {
+	TeamName string
+	From     *time.Time
+	To       *time.Time
+}
+
+// UserAssignmentStats - количество назначений пользователя ревьювером
+type UserAssignmentStats struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	Total    int    `json:"total"`
+	Open     int    `json:"open"`
+	Merged   int    `json:"merged"`
+}
+
+// TeamAssignmentStats - количество назначений ревьюверов команды
+type TeamAssignmentStats struct {
+	TeamName string `json:"team_name"`
+	Total    int    `json:"total"`
+	Open     int    `json:"open"`
+	Merged   int    `json:"merged"`
+}
+
+// PRReviewerStats - количество ревьюверов PR
+type PRReviewerStats struct {
+	PullRequestID string   `json:"pull_request_id"`
+	AuthorID      string   `json:"author_id"`
+	Status        PRStatus `json:"status"`
+	ReviewerCount int      `json:"reviewer_count"`
+}
+
+// AssignmentStats - статистика назначений ревьюверов
+type AssignmentStats struct {
+	Users        []UserAssignmentStats `json:"users"`
+	Teams        []TeamAssignmentStats `json:"teams"`
+	PullRequests []PRReviewerStats     `json:"pull_requests"`
+}
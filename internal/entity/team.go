@@ -1,5 +1,8 @@
 package entity
 
+// DefaultReviewerCount - количество ревьюверов, если для команды не заданы настройки
+const DefaultReviewerCount = 2
+
 type Team struct {
     Name    string       `json:"team_name"`
     Members []TeamMember `json:"members"`
@@ -9,4 +12,89 @@ type TeamMember struct {
     UserID   string `json:"user_id"`
     Username string `json:"username"`
     IsActive bool   `json:"is_active"`
-}
\ No newline at end of file
+}
+
+// TeamSummary - команда в списке команд
+type TeamSummary struct {
+    Name         string `json:"team_name"`
+    MembersCount int    `json:"members_count"`
+    ActiveCount  int    `json:"active_members_count"`
+}
+
+// TeamChange - состав команды после изменения и замены в открытых ревью затронутых пользователей
+type TeamChange struct {
+    Team          Team                   `json:"team"`
+    Reassignments []ReviewerReassignment `json:"reassignments"`
+}
+
+// TeamDeletion - результат удаления команды
+type TeamDeletion struct {
+    TeamName           string                 `json:"team_name"`
+    DeletedUsers       []string               `json:"deleted_users"`
+    ClosedPullRequests []string               `json:"closed_pull_requests"` // незавершенные PR, закрытые принудительным удалением
+    Reassignments      []ReviewerReassignment `json:"reassignments"`
+}
+
+// TeamSync - расхождение состава команды с желаемым (из внешней системы) и его применение
+type TeamSync struct {
+    TeamName      string                 `json:"team_name"`
+    DryRun        bool                   `json:"dry_run"`      // изменения только рассчитаны и не сохранены
+    TeamCreated   bool                   `json:"team_created"`
+    Added         []TeamMember           `json:"added"`        // новые, восстановленные и перешедшие из других команд
+    Updated       []TeamMember           `json:"updated"`      // изменилось имя или участник снова активен
+    Deactivated   []string               `json:"deactivated"`  // отсутствуют в списке или переданы неактивными
+    Reassignments []ReviewerReassignment `json:"reassignments"`
+}
+
+// TeamSettings - политика назначения ревьюверов для команды
+type TeamSettings struct {
+    TeamName          string `json:"team_name"`
+    ReviewerCount     int    `json:"reviewer_count"`
+    MinRequired       int    `json:"min_required"`
+    Strategy          string `json:"strategy,omitempty"` // пустая строка - стратегия по умолчанию для сервиса
+    RequiredApprovals int    `json:"required_approvals"` // 0 - мердж без одобрений
+    MaxOpenReviews    int    `json:"max_open_reviews"`   // лимит открытых ревью участника по умолчанию, 0 - без ограничения
+}
+
+// ReviewLimit возвращает действующий лимит открытых ревью пользователя: личный или команды, 0 - без ограничения
+func (s *TeamSettings) ReviewLimit(user User) int {
+    if user.MaxOpenReviews > 0 {
+        return user.MaxOpenReviews
+    }
+    return s.MaxOpenReviews
+}
+
+// TeamSettingsUpdate - новые настройки команды; nil-поля сохраняют текущее значение
+type TeamSettingsUpdate struct {
+    TeamName          string
+    ReviewerCount     int
+    MinRequired       int
+    Strategy          string // пустая строка - стратегия сервиса по умолчанию
+    RequiredApprovals *int
+    MaxOpenReviews    *int
+}
+
+// Apply возвращает настройки current с примененными изменениями
+func (u TeamSettingsUpdate) Apply(current TeamSettings) TeamSettings {
+    settings := current
+    settings.TeamName = u.TeamName
+    settings.ReviewerCount = u.ReviewerCount
+    settings.MinRequired = u.MinRequired
+    settings.Strategy = u.Strategy
+    if u.RequiredApprovals != nil {
+        settings.RequiredApprovals = *u.RequiredApprovals
+    }
+    if u.MaxOpenReviews != nil {
+        settings.MaxOpenReviews = *u.MaxOpenReviews
+    }
+    return settings
+}
+
+// DefaultTeamSettings возвращает настройки команды по умолчанию
+func DefaultTeamSettings(teamName string) *TeamSettings {
+    return &TeamSettings{
+        TeamName:      teamName,
+        ReviewerCount: DefaultReviewerCount,
+        MinRequired:   0,
+    }
+}
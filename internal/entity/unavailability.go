@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Unavailability - период [From, To), в который пользователь не получает ревью (отпуск, больничный)
+type Unavailability struct {
+	ID           int64      `json:"id"`
+	UserID       string     `json:"user_id"`
+	From         time.Time  `json:"from"`
+	To           time.Time  `json:"to"`
+	Reason       string     `json:"reason,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ReassignedAt *time.Time `json:"reassigned_at,omitempty"` // когда открытые ревью пользователя переназначены фоновым процессом
+}
+
+// Covers сообщает, приходится ли момент t на период
+func (u Unavailability) Covers(t time.Time) bool {
+	return !t.Before(u.From) && t.Before(u.To)
+}
@@ -1,8 +1,46 @@
 package entity
 
+import "time"
+
 type User struct {
-	ID       string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
-}
\ No newline at end of file
+	ID        string     `json:"user_id"`
+	Username  string     `json:"username"`
+	TeamName  string     `json:"team_name"`
+	IsActive  bool       `json:"is_active"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // удаленный пользователь скрыт, но остается автором своих PR
+	// MaxOpenReviews - личный лимит открытых ревью, 0 - действует лимит команды
+	MaxOpenReviews int `json:"max_open_reviews,omitempty"`
+}
+
+// ReviewLoad - текущая загрузка пользователя открытыми ревью
+type ReviewLoad struct {
+	OpenReviews    int  `json:"open_reviews"`
+	MaxOpenReviews int  `json:"max_open_reviews"` // действующий лимит (личный или команды), 0 - без ограничения
+	AtCapacity     bool `json:"at_capacity"`
+}
+
+// UserFilter - условия выборки пользователей (пустые поля не ограничивают выборку)
+type UserFilter struct {
+	TeamName string
+	IsActive *bool
+}
+
+// UserChange - результат изменения пользователя с заменами в его открытых ревью
+type UserChange struct {
+	User          User                   `json:"user"`
+	Reassignments []ReviewerReassignment `json:"reassignments"`
+}
+
+// ReviewerReassignment - замена ревьювера в PR (NewUserID пустой, если замены не нашлось и ревьювер снят)
+type ReviewerReassignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"new_user_id,omitempty"`
+}
+
+// TeamDeactivation - результат массовой деактивации пользователей команды
+type TeamDeactivation struct {
+	TeamName         string                 `json:"team_name"`
+	DeactivatedUsers []string               `json:"deactivated_users"`
+	Reassignments    []ReviewerReassignment `json:"reassignments"`
+}
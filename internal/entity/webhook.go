@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEventType - тип события, на которое можно подписать вебхук
+type WebhookEventType string
+
+const (
+	WebhookPRCreated          WebhookEventType = "pr.created"
+	WebhookPRMerged           WebhookEventType = "pr.merged"
+	WebhookReviewerReassigned WebhookEventType = "reviewer.reassigned"
+	WebhookUserDeactivated    WebhookEventType = "user.deactivated"
+)
+
+// IsKnown сообщает, поддерживается ли тип события
+func (t WebhookEventType) IsKnown() bool {
+	switch t {
+	case WebhookPRCreated, WebhookPRMerged, WebhookReviewerReassigned, WebhookUserDeactivated:
+		return true
+	}
+	return false
+}
+
+// Webhook - подписка внешнего получателя на события сервиса
+type Webhook struct {
+	ID         int64              `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	Secret     string             `json:"-"` // ключ HMAC-подписи, возвращается только при создании
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// Subscribed сообщает, подписан ли вебхук на тип события
+func (w *Webhook) Subscribed(eventType WebhookEventType) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent - событие для отправки подписчикам, сериализуется в тело запроса
+type WebhookEvent struct {
+	ID         string           `json:"id"`
+	Type       WebhookEventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       any              `json:"data"`
+}
+
+// DeliveryStatus - состояние доставки события одному вебхуку
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed" // попытки исчерпаны
+)
+
+// WebhookDelivery - запись журнала доставки события вебхуку
+type WebhookDelivery struct {
+	ID           int64            `json:"id"`
+	WebhookID    int64            `json:"webhook_id"`
+	EventID      string           `json:"event_id"`
+	EventType    WebhookEventType `json:"event_type"`
+	Payload      json.RawMessage  `json:"payload"`
+	Status       DeliveryStatus   `json:"status"`
+	Attempts     int              `json:"attempts"`
+	ResponseCode int              `json:"response_code,omitempty"` // код ответа последней попытки
+	LastError    string           `json:"last_error,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	DeliveredAt  *time.Time       `json:"delivered_at,omitempty"`
+}
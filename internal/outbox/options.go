@@ -0,0 +1,24 @@
+package outbox
+
+import "time"
+
+// Option - relay option type.
+type Option func(*Relay)
+
+// Interval sets delay between polls of the outbox table.
+func Interval(interval time.Duration) Option {
+	return func(r *Relay) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// BatchSize sets maximum number of messages published per poll.
+func BatchSize(n int) Option {
+	return func(r *Relay) {
+		if n > 0 {
+			r.batchSize = n
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package outbox publishes events recorded in the outbox table.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+const (
+	_defaultInterval  = time.Second
+	_defaultBatchSize = 100
+)
+
+// EventPublisher публикует событие из outbox. Ошибка означает, что событие не опубликовано
+// и будет отправлено повторно, поэтому публикация должна допускать дубликаты
+type EventPublisher interface {
+	Publish(ctx context.Context, msg entity.OutboxMessage) error
+}
+
+// Relay периодически читает неотправленные события outbox, публикует их и помечает отправленными.
+// Событие помечается только после успешной публикации (at-least-once): при падении между
+// публикацией и отметкой событие будет опубликовано повторно
+type Relay struct {
+	repo      repository.OutboxRepository
+	publisher EventPublisher
+	logger    logger.Interface
+
+	interval  time.Duration
+	batchSize int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates relay, Start must be called to begin publishing.
+func New(repo repository.OutboxRepository, publisher EventPublisher, l logger.Interface, opts ...Option) *Relay {
+	r := &Relay{
+		repo:      repo,
+		publisher: publisher,
+		logger:    l,
+		interval:  _defaultInterval,
+		batchSize: _defaultBatchSize,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Start запускает фоновую публикацию
+func (r *Relay) Start() {
+	go r.run()
+}
+
+// Stop дожидается завершения текущего прохода; неопубликованные события останутся в outbox
+func (r *Relay) Stop() {
+	close(r.stop)
+	<-r.done
+}
+
+func (r *Relay) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// Публикуем пачками, пока outbox не опустеет, затем ждём следующего тика
+		for r.relayBatch() == r.batchSize {
+			select {
+			case <-r.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayBatch публикует одну пачку событий и возвращает количество успешно опубликованных
+func (r *Relay) relayBatch() int {
+	ctx := context.Background()
+
+	messages, err := r.repo.GetPendingOutbox(ctx, r.batchSize)
+	if err != nil {
+		r.logger.Error("Failed to get pending outbox messages: %v", err)
+		return 0
+	}
+
+	published := 0
+	for _, msg := range messages {
+		if err := r.publisher.Publish(ctx, msg); err != nil {
+			r.logger.Warn("Failed to publish outbox message %d (%s), attempt %d: %v", msg.ID, msg.Type, msg.Attempts+1, err)
+			if err := r.repo.MarkOutboxFailed(ctx, msg.ID, err.Error()); err != nil {
+				r.logger.Error("Failed to mark outbox message %d failed: %v", msg.ID, err)
+			}
+			continue
+		}
+
+		if err := r.repo.MarkOutboxSent(ctx, msg.ID); err != nil {
+			r.logger.Error("Failed to mark outbox message %d sent: %v", msg.ID, err)
+			continue
+		}
+		published++
+	}
+
+	if published > 0 {
+		r.logger.Debug("Published %d outbox messages", published)
+	}
+	return published
+}
@@ -0,0 +1,74 @@
+// apikey.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type apiKeyRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewAPIKeyRepository(s *Storage, l logger.Interface) repository.APIKeyRepository {
+	return &apiKeyRepo{s: s, logger: l}
+}
+
+func (r *apiKeyRepo) CreateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	r.logger.Debug("Creating API key %s with role %s", key.Name, key.Role)
+
+	defer r.s.lock(ctx)()
+
+	for _, existing := range r.s.apiKeys {
+		if existing.KeyHash == key.KeyHash {
+			return fmt.Errorf("apiKeyRepo - CreateAPIKey: %w", repository.ErrAlreadyExists)
+		}
+	}
+
+	key.ID = int64(len(r.s.apiKeys) + 1)
+	key.CreatedAt = time.Now()
+	r.s.apiKeys = append(r.s.apiKeys, *key)
+	return nil
+}
+
+func (r *apiKeyRepo) GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	defer r.s.rlock(ctx)()
+
+	for _, key := range r.s.apiKeys {
+		if key.KeyHash == keyHash {
+			return &key, nil
+		}
+	}
+	return nil, fmt.Errorf("apiKeyRepo - GetAPIKeyByHash: %w", repository.ErrNotFound)
+}
+
+func (r *apiKeyRepo) ListAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	r.logger.Debug("Listing API keys")
+
+	defer r.s.rlock(ctx)()
+
+	return append([]entity.APIKey{}, r.s.apiKeys...), nil
+}
+
+func (r *apiKeyRepo) RevokeAPIKey(ctx context.Context, id int64) (*entity.APIKey, error) {
+	r.logger.Debug("Revoking API key: %d", id)
+
+	defer r.s.lock(ctx)()
+
+	if id < 1 || id > int64(len(r.s.apiKeys)) {
+		return nil, fmt.Errorf("apiKeyRepo - RevokeAPIKey: %w", repository.ErrNotFound)
+	}
+	key := &r.s.apiKeys[id-1]
+	if key.RevokedAt == nil {
+		now := time.Now()
+		key.RevokedAt = &now
+	}
+	revoked := *key
+	return &revoked, nil
+}
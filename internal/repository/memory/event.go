@@ -0,0 +1,55 @@
+// event.go
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type eventRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewEventRepository(s *Storage, l logger.Interface) repository.EventRepository {
+	return &eventRepo{s: s, logger: l}
+}
+
+func (r *eventRepo) AddEvents(ctx context.Context, events []entity.PREvent) error {
+	r.logger.Debug("Adding %d PR events", len(events))
+
+	defer r.s.lock(ctx)()
+
+	for _, event := range events {
+		if _, ok := r.s.prs[event.PullRequestID]; !ok {
+			return fmt.Errorf("eventRepo - AddEvents: PR %s: %w", event.PullRequestID, errForeignKey)
+		}
+	}
+	for _, event := range events {
+		event.ID = int64(len(r.s.events) + 1)
+		r.s.events = append(r.s.events, event)
+	}
+
+	r.logger.Debug("Added %d PR events", len(events))
+	return nil
+}
+
+func (r *eventRepo) GetPREvents(ctx context.Context, prID string) ([]entity.PREvent, error) {
+	r.logger.Debug("Getting events of PR: %s", prID)
+
+	defer r.s.rlock(ctx)()
+
+	events := []entity.PREvent{}
+	for _, event := range r.s.events {
+		if event.PullRequestID == prID {
+			events = append(events, event)
+		}
+	}
+
+	r.logger.Debug("Found %d events for PR %s", len(events), prID)
+	return events, nil
+}
@@ -0,0 +1,84 @@
+// idempotency.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type idempotencyID struct {
+	scope string
+	key   string
+}
+
+type idempotencyRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewIdempotencyRepository(s *Storage, l logger.Interface) repository.IdempotencyRepository {
+	return &idempotencyRepo{s: s, logger: l}
+}
+
+func (r *idempotencyRepo) ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	id := idempotencyID{scope: record.Scope, key: record.Key}
+	now := time.Now()
+	if existing, ok := r.s.idempotency[id]; ok && existing.ExpiresAt.After(now) {
+		return &existing, false, nil
+	}
+
+	record.StatusCode = 0
+	record.ContentType = ""
+	record.Body = nil
+	record.CreatedAt = now
+	r.s.idempotency[id] = *record
+	return record, true, nil
+}
+
+func (r *idempotencyRepo) CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	id := idempotencyID{scope: record.Scope, key: record.Key}
+	existing, ok := r.s.idempotency[id]
+	if !ok {
+		return fmt.Errorf("idempotencyRepo - CompleteIdempotencyKey: %w", repository.ErrNotFound)
+	}
+	existing.StatusCode = record.StatusCode
+	existing.ContentType = record.ContentType
+	existing.Body = append([]byte(nil), record.Body...)
+	existing.ExpiresAt = record.ExpiresAt
+	r.s.idempotency[id] = existing
+	return nil
+}
+
+func (r *idempotencyRepo) DeleteIdempotencyKey(ctx context.Context, scope, key string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.idempotency, idempotencyID{scope: scope, key: key})
+	return nil
+}
+
+func (r *idempotencyRepo) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	var deleted int64
+	for id, record := range r.s.idempotency {
+		if !record.ExpiresAt.After(now) {
+			delete(r.s.idempotency, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,73 @@
+// outbox.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type outboxRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewOutboxRepository(s *Storage, l logger.Interface) repository.OutboxRepository {
+	return &outboxRepo{s: s, logger: l}
+}
+
+func (r *outboxRepo) AddOutboxMessage(ctx context.Context, msg *entity.OutboxMessage) error {
+	r.logger.Debug("Adding %s to outbox", msg.Type)
+
+	defer r.s.lock(ctx)()
+
+	msg.ID = int64(len(r.s.outbox) + 1)
+	msg.CreatedAt = time.Now()
+	r.s.outbox = append(r.s.outbox, *msg)
+	return nil
+}
+
+func (r *outboxRepo) GetPendingOutbox(ctx context.Context, limit int) ([]entity.OutboxMessage, error) {
+	defer r.s.rlock(ctx)()
+
+	var messages []entity.OutboxMessage
+	for _, msg := range r.s.outbox {
+		if len(messages) == limit {
+			break
+		}
+		if msg.SentAt == nil {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+func (r *outboxRepo) MarkOutboxSent(ctx context.Context, id int64) error {
+	return r.update(ctx, id, func(msg *entity.OutboxMessage) {
+		now := time.Now()
+		msg.Attempts++
+		msg.LastError = ""
+		msg.SentAt = &now
+	})
+}
+
+func (r *outboxRepo) MarkOutboxFailed(ctx context.Context, id int64, lastError string) error {
+	return r.update(ctx, id, func(msg *entity.OutboxMessage) {
+		msg.Attempts++
+		msg.LastError = lastError
+	})
+}
+
+func (r *outboxRepo) update(ctx context.Context, id int64, fn func(msg *entity.OutboxMessage)) error {
+	defer r.s.lock(ctx)()
+
+	if id < 1 || id > int64(len(r.s.outbox)) {
+		return fmt.Errorf("outboxRepo - update: %w", repository.ErrNotFound)
+	}
+	fn(&r.s.outbox[id-1])
+	return nil
+}
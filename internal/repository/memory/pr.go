@@ -0,0 +1,294 @@
+// pr.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type prRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewPRRepository(s *Storage, l logger.Interface) repository.PRRepository {
+	return &prRepo{s: s, logger: l}
+}
+
+func (r *prRepo) CreatePR(ctx context.Context, pr *entity.PullRequest) error {
+	r.logger.Debug("Creating PR: %s with %d reviewers", pr.ID, len(pr.AssignedReviewers))
+
+	defer r.s.lock(ctx)()
+
+	if _, exists := r.s.prs[pr.ID]; exists {
+		return fmt.Errorf("prRepo - CreatePR - Insert PR: %w", repository.ErrAlreadyExists)
+	}
+	if _, ok := r.s.users[pr.AuthorID]; !ok {
+		return fmt.Errorf("prRepo - CreatePR - Insert PR: author %s: %w", pr.AuthorID, errForeignKey)
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		if _, ok := r.s.users[reviewerID]; !ok {
+			return fmt.Errorf("prRepo - CreatePR - Insert reviewer %s: %w", reviewerID, errForeignKey)
+		}
+	}
+
+	stored := copyPR(pr)
+	r.s.prs[pr.ID] = &stored
+
+	r.logger.Info("PR created successfully: %s with %d reviewers", pr.ID, len(pr.AssignedReviewers))
+	return nil
+}
+
+func (r *prRepo) GetPR(ctx context.Context, id string) (*entity.PullRequest, error) {
+	r.logger.Debug("Getting PR: %s", id)
+
+	defer r.s.rlock(ctx)()
+
+	pr, ok := r.s.prs[id]
+	if !ok {
+		r.logger.Warn("PR not found: %s", id)
+		return nil, fmt.Errorf("prRepo - GetPR - Query PR: %w", repository.ErrNotFound)
+	}
+
+	result := r.s.prWithReviews(pr)
+	return &result, nil
+}
+
+// GetPRForUpdate не требует отдельной блокировки: транзакции in-memory хранилища сериализованы
+func (r *prRepo) GetPRForUpdate(ctx context.Context, id string) (*entity.PullRequest, error) {
+	return r.GetPR(ctx, id)
+}
+
+func (r *prRepo) UpdatePR(ctx context.Context, pr *entity.PullRequest) error {
+	r.logger.Debug("Updating PR: %s, status: %s", pr.ID, pr.Status)
+
+	defer r.s.lock(ctx)()
+
+	stored, ok := r.s.prs[pr.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name = pr.Name
+	stored.Status = pr.Status
+	stored.MergedAt = nil
+	if pr.MergedAt != nil {
+		mergedAt := *pr.MergedAt
+		stored.MergedAt = &mergedAt
+	}
+
+	r.logger.Debug("PR updated successfully: %s", pr.ID)
+	return nil
+}
+
+func (r *prRepo) GetPRsByReviewer(ctx context.Context, userID string) ([]entity.PullRequest, error) {
+	r.logger.Debug("Getting PRs by reviewer: %s", userID)
+
+	defer r.s.rlock(ctx)()
+
+	var prs []entity.PullRequest
+	for _, pr := range r.s.prs {
+		if containsString(pr.AssignedReviewers, userID) {
+			prs = append(prs, r.s.prWithReviews(pr))
+		}
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].CreatedAt.After(prs[j].CreatedAt)
+	})
+
+	r.logger.Debug("Found %d PRs for reviewer %s", len(prs), userID)
+	return prs, nil
+}
+
+func (r *prRepo) ListPRs(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+	r.logger.Debug("Listing PRs: %+v", filter)
+
+	defer r.s.rlock(ctx)()
+
+	var prs []entity.PullRequest
+	for _, pr := range r.s.prs {
+		if r.matchesFilter(pr, filter) {
+			prs = append(prs, r.s.prWithReviews(pr))
+		}
+	}
+
+	// Порядок (created_at, id), как в keyset-пагинации PostgreSQL-репозитория
+	less := func(a, b entity.PullRequest) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		if filter.Order == entity.SortAsc {
+			return less(prs[i], prs[j])
+		}
+		return less(prs[j], prs[i])
+	})
+
+	if filter.After != nil {
+		cursor := entity.PullRequest{ID: filter.After.ID, CreatedAt: filter.After.CreatedAt}
+		start := sort.Search(len(prs), func(i int) bool {
+			if filter.Order == entity.SortAsc {
+				return less(cursor, prs[i])
+			}
+			return less(prs[i], cursor)
+		})
+		prs = prs[start:]
+	}
+	if filter.Limit > 0 && len(prs) > filter.Limit {
+		prs = prs[:filter.Limit]
+	}
+
+	r.logger.Debug("Listed %d PRs", len(prs))
+	return prs, nil
+}
+
+// matchesFilter проверяет условия фильтра, кроме курсора; вызывается под блокировкой
+func (r *prRepo) matchesFilter(pr *entity.PullRequest, filter entity.PRFilter) bool {
+	switch {
+	case filter.Status != "" && pr.Status != filter.Status:
+		return false
+	case filter.AuthorID != "" && pr.AuthorID != filter.AuthorID:
+		return false
+	case filter.ReviewerID != "" && !containsString(pr.AssignedReviewers, filter.ReviewerID):
+		return false
+	case filter.TeamName != "" && r.s.users[pr.AuthorID].TeamName != filter.TeamName:
+		return false
+	case filter.NameContains != "" && !strings.Contains(strings.ToLower(pr.Name), strings.ToLower(filter.NameContains)):
+		return false
+	case filter.CreatedFrom != nil && pr.CreatedAt.Before(*filter.CreatedFrom):
+		return false
+	case filter.CreatedTo != nil && !pr.CreatedAt.Before(*filter.CreatedTo):
+		return false
+	case (filter.MergedFrom != nil || filter.MergedTo != nil) && pr.MergedAt == nil:
+		return false
+	case filter.MergedFrom != nil && pr.MergedAt.Before(*filter.MergedFrom):
+		return false
+	case filter.MergedTo != nil && !pr.MergedAt.Before(*filter.MergedTo):
+		return false
+	}
+	return true
+}
+
+func (r *prRepo) AddReviewer(ctx context.Context, prID, userID string) error {
+	defer r.s.lock(ctx)()
+
+	pr, ok := r.s.prs[prID]
+	if !ok {
+		return fmt.Errorf("prRepo - AddReviewer: PR %s: %w", prID, errForeignKey)
+	}
+	if _, ok := r.s.users[userID]; !ok {
+		return fmt.Errorf("prRepo - AddReviewer: user %s: %w", userID, errForeignKey)
+	}
+	if !containsString(pr.AssignedReviewers, userID) {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+	}
+
+	r.logger.Debug("Reviewer %s added to PR %s", userID, prID)
+	return nil
+}
+
+func (r *prRepo) RemoveReviewer(ctx context.Context, prID, userID string) error {
+	defer r.s.lock(ctx)()
+
+	if pr, ok := r.s.prs[prID]; ok {
+		pr.AssignedReviewers = replaceString(pr.AssignedReviewers, userID, "")
+		r.s.dropReview(prID, userID)
+	}
+
+	r.logger.Debug("Reviewer %s removed from PR %s", userID, prID)
+	return nil
+}
+
+func (r *prRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
+	r.logger.Debug("Replacing reviewer %s with %s in PR %s", oldUserID, newUserID, prID)
+
+	defer r.s.lock(ctx)()
+
+	pr, ok := r.s.prs[prID]
+	if !ok {
+		return fmt.Errorf("prRepo - ReplaceReviewer - Add new: PR %s: %w", prID, errForeignKey)
+	}
+	if _, ok := r.s.users[newUserID]; !ok {
+		return fmt.Errorf("prRepo - ReplaceReviewer - Add new: user %s: %w", newUserID, errForeignKey)
+	}
+
+	reviewers := replaceString(pr.AssignedReviewers, oldUserID, "")
+	if containsString(reviewers, newUserID) {
+		return fmt.Errorf("prRepo - ReplaceReviewer - Add new: %w", repository.ErrAlreadyExists)
+	}
+	pr.AssignedReviewers = append(reviewers, newUserID)
+	r.s.dropReview(prID, oldUserID)
+
+	r.logger.Info("Reviewer replaced successfully: %s -> %s in PR %s", oldUserID, newUserID, prID)
+	return nil
+}
+
+func (r *prRepo) SetReviewState(ctx context.Context, prID, userID string, state entity.ReviewState) error {
+	r.logger.Debug("Setting review state of %s in PR %s to %s", userID, prID, state)
+
+	defer r.s.lock(ctx)()
+
+	pr, ok := r.s.prs[prID]
+	if !ok || !containsString(pr.AssignedReviewers, userID) {
+		r.logger.Warn("Reviewer %s not assigned to PR %s", userID, prID)
+		return fmt.Errorf("prRepo - SetReviewState: %w", repository.ErrNotFound)
+	}
+	if r.s.reviews[prID] == nil {
+		r.s.reviews[prID] = make(map[string]entity.ReviewState)
+	}
+	r.s.reviews[prID][userID] = state
+
+	r.logger.Debug("Review state of %s in PR %s set to %s", userID, prID, state)
+	return nil
+}
+
+func (r *prRepo) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	defer r.s.rlock(ctx)()
+
+	wanted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+
+	counts := make(map[string]int, len(userIDs))
+	for _, pr := range r.s.prs {
+		if pr.Status != entity.StatusOpen {
+			continue
+		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			if wanted[reviewerID] {
+				counts[reviewerID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// CountOpenReviewsForUpdate не требует отдельной блокировки: транзакции in-memory хранилища сериализованы
+func (r *prRepo) CountOpenReviewsForUpdate(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return r.CountOpenReviews(ctx, userIDs)
+}
+
+func (r *prRepo) GetUnfinishedPRIDsByTeam(ctx context.Context, teamName string) ([]string, error) {
+	defer r.s.rlock(ctx)()
+
+	prIDs := []string{}
+	for id, pr := range r.s.prs {
+		if pr.Status != entity.StatusOpen && pr.Status != entity.StatusDraft {
+			continue
+		}
+		if author, ok := r.s.users[pr.AuthorID]; ok && author.TeamName == teamName {
+			prIDs = append(prIDs, id)
+		}
+	}
+	sort.Strings(prIDs)
+	return prIDs, nil
+}
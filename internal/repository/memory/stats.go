@@ -0,0 +1,110 @@
+// stats.go
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type statsRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewStatsRepository(s *Storage, l logger.Interface) repository.StatsRepository {
+	return &statsRepo{s: s, logger: l}
+}
+
+func (r *statsRepo) GetAssignmentStats(ctx context.Context, filter entity.StatsFilter) (*entity.AssignmentStats, error) {
+	r.logger.Debug("Getting assignment stats: %+v", filter)
+
+	defer r.s.rlock(ctx)()
+
+	inPeriod := func(pr *entity.PullRequest) bool {
+		if filter.From != nil && pr.CreatedAt.Before(*filter.From) {
+			return false
+		}
+		if filter.To != nil && !pr.CreatedAt.Before(*filter.To) {
+			return false
+		}
+		return true
+	}
+
+	stats := &entity.AssignmentStats{
+		Users:        []entity.UserAssignmentStats{},
+		Teams:        []entity.TeamAssignmentStats{},
+		PullRequests: []entity.PRReviewerStats{},
+	}
+
+	userStats := make(map[string]*entity.UserAssignmentStats)
+	teamStats := make(map[string]*entity.TeamAssignmentStats)
+	for _, user := range r.s.users {
+		if filter.TeamName != "" && user.TeamName != filter.TeamName {
+			continue
+		}
+		userStats[user.ID] = &entity.UserAssignmentStats{UserID: user.ID, Username: user.Username, TeamName: user.TeamName}
+		if _, ok := teamStats[user.TeamName]; !ok {
+			teamStats[user.TeamName] = &entity.TeamAssignmentStats{TeamName: user.TeamName}
+		}
+	}
+
+	for _, pr := range r.s.prs {
+		if !inPeriod(pr) {
+			continue
+		}
+
+		for _, reviewerID := range pr.AssignedReviewers {
+			us, ok := userStats[reviewerID]
+			if !ok {
+				continue
+			}
+			ts := teamStats[us.TeamName]
+			us.Total++
+			ts.Total++
+			switch pr.Status {
+			case entity.StatusOpen:
+				us.Open++
+				ts.Open++
+			case entity.StatusMerged:
+				us.Merged++
+				ts.Merged++
+			}
+		}
+
+		if author, ok := r.s.users[pr.AuthorID]; ok && (filter.TeamName == "" || author.TeamName == filter.TeamName) {
+			stats.PullRequests = append(stats.PullRequests, entity.PRReviewerStats{
+				PullRequestID: pr.ID,
+				AuthorID:      pr.AuthorID,
+				Status:        pr.Status,
+				ReviewerCount: len(pr.AssignedReviewers),
+			})
+		}
+	}
+
+	for _, us := range userStats {
+		stats.Users = append(stats.Users, *us)
+	}
+	for _, ts := range teamStats {
+		stats.Teams = append(stats.Teams, *ts)
+	}
+
+	// Порядок как в SQL-реализации
+	sort.Slice(stats.Users, func(i, j int) bool {
+		if stats.Users[i].Total != stats.Users[j].Total {
+			return stats.Users[i].Total > stats.Users[j].Total
+		}
+		return stats.Users[i].UserID < stats.Users[j].UserID
+	})
+	sort.Slice(stats.Teams, func(i, j int) bool {
+		return stats.Teams[i].TeamName < stats.Teams[j].TeamName
+	})
+	sort.Slice(stats.PullRequests, func(i, j int) bool {
+		return stats.PullRequests[i].PullRequestID < stats.PullRequests[j].PullRequestID
+	})
+
+	return stats, nil
+}
@@ -0,0 +1,196 @@
+// Package memory implements repositories on top of in-memory maps.
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+// errForeignKey повторяет семантику внешних ключей PostgreSQL-схемы
+var errForeignKey = errors.New("foreign key violation")
+
+// Storage - общее хранилище для всех in-memory репозиториев.
+// Один мьютекс на всё хранилище: операции, затрагивающие несколько сущностей, атомарны
+type Storage struct {
+	mu         sync.RWMutex
+	txMu       sync.RWMutex    // транзакции Transactor берут на запись, операции вне транзакций - на чтение
+	teams      map[string]bool // имя -> команда удалена (deleted_at)
+	settings   map[string]entity.TeamSettings
+	users      map[string]entity.User
+	prs        map[string]*entity.PullRequest
+	reviews    map[string]map[string]entity.ReviewState // pr_id -> user_id -> решение, отсутствие - PENDING
+	events     []entity.PREvent                         // журнал изменений PR, id = индекс + 1
+	webhooks   []entity.Webhook                         // id = индекс + 1
+	deliveries []entity.WebhookDelivery                 // id = индекс + 1
+	outbox     []entity.OutboxMessage                   // id = индекс + 1
+	apiKeys    []entity.APIKey                          // id = индекс + 1
+	// Периоды недоступности удаляются, поэтому хранятся по id со своим счетчиком
+	unavailability    map[int64]entity.Unavailability
+	unavailabilitySeq int64
+	// Ключи идемпотентности пишутся вне транзакций и не входят в снимок,
+	// чтобы откат чужой транзакции не потерял резерв ключа
+	idempotency map[idempotencyID]entity.IdempotencyRecord
+}
+
+// NewStorage создает пустое хранилище
+func NewStorage() *Storage {
+	return &Storage{
+		teams:    make(map[string]bool),
+		settings: make(map[string]entity.TeamSettings),
+		users:    make(map[string]entity.User),
+		prs:      make(map[string]*entity.PullRequest),
+		reviews:  make(map[string]map[string]entity.ReviewState),
+
+		unavailability: make(map[int64]entity.Unavailability),
+
+		idempotency: make(map[idempotencyID]entity.IdempotencyRecord),
+	}
+}
+
+// lock блокирует хранилище на запись. Операция вне транзакции дожидается завершения текущей
+// транзакции Transactor: иначе откат к снимку стер бы ее изменения, а чтения видели бы незафиксированные данные
+func (s *Storage) lock(ctx context.Context) (unlock func()) {
+	inTx := ctx.Value(txKey{}) != nil
+	if !inTx {
+		s.txMu.RLock()
+	}
+	s.mu.Lock()
+	return func() {
+		s.mu.Unlock()
+		if !inTx {
+			s.txMu.RUnlock()
+		}
+	}
+}
+
+// rlock блокирует хранилище на чтение, вне транзакции дожидается ее завершения, как lock
+func (s *Storage) rlock(ctx context.Context) (unlock func()) {
+	inTx := ctx.Value(txKey{}) != nil
+	if !inTx {
+		s.txMu.RLock()
+	}
+	s.mu.RLock()
+	return func() {
+		s.mu.RUnlock()
+		if !inTx {
+			s.txMu.RUnlock()
+		}
+	}
+}
+
+// copyPR возвращает копию PR с отсортированным списком ревьюверов (как ORDER BY user_id)
+func copyPR(pr *entity.PullRequest) entity.PullRequest {
+	result := *pr
+	result.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
+	sort.Strings(result.AssignedReviewers)
+	if pr.MergedAt != nil {
+		mergedAt := *pr.MergedAt
+		result.MergedAt = &mergedAt
+	}
+	return result
+}
+
+// prWithReviews возвращает копию PR с решениями ревьюверов, вызывается под блокировкой
+func (s *Storage) prWithReviews(pr *entity.PullRequest) entity.PullRequest {
+	result := copyPR(pr)
+	result.Reviews = entity.PendingReviews(result.AssignedReviewers)
+	for i := range result.Reviews {
+		if state, ok := s.reviews[pr.ID][result.Reviews[i].ReviewerID]; ok {
+			result.Reviews[i].State = state
+		}
+	}
+	return result
+}
+
+// dropReview удаляет решение снятого с PR ревьювера, вызывается под блокировкой
+func (s *Storage) dropReview(prID, userID string) {
+	delete(s.reviews[prID], userID)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceString заменяет old на new, а при пустом new удаляет old из списка
+func replaceString(values []string, old, new string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		switch {
+		case v != old:
+			result = append(result, v)
+		case new != "":
+			result = append(result, new)
+		}
+	}
+	return result
+}
+
+// unavailableAt сообщает, идет ли у пользователя период недоступности в момент at, вызывается под блокировкой
+func (s *Storage) unavailableAt(userID string, at time.Time) bool {
+	for _, period := range s.unavailability {
+		if period.UserID == userID && period.Covers(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshot возвращает глубокую копию данных хранилища, вызывается под блокировкой
+func (s *Storage) snapshot() *Storage {
+	snap := NewStorage()
+	for name, deleted := range s.teams {
+		snap.teams[name] = deleted
+	}
+	for name, settings := range s.settings {
+		snap.settings[name] = settings
+	}
+	for id, user := range s.users {
+		snap.users[id] = user
+	}
+	for id, pr := range s.prs {
+		copied := copyPR(pr)
+		snap.prs[id] = &copied
+	}
+	for prID, states := range s.reviews {
+		snap.reviews[prID] = make(map[string]entity.ReviewState, len(states))
+		for userID, state := range states {
+			snap.reviews[prID][userID] = state
+		}
+	}
+	snap.events = append([]entity.PREvent(nil), s.events...)
+	snap.webhooks = append([]entity.Webhook(nil), s.webhooks...)
+	snap.deliveries = append([]entity.WebhookDelivery(nil), s.deliveries...)
+	snap.outbox = append([]entity.OutboxMessage(nil), s.outbox...)
+	snap.apiKeys = append([]entity.APIKey(nil), s.apiKeys...)
+	for id, period := range s.unavailability {
+		snap.unavailability[id] = period
+	}
+	snap.unavailabilitySeq = s.unavailabilitySeq
+	return snap
+}
+
+// restore заменяет данные хранилища данными снимка, вызывается под блокировкой
+func (s *Storage) restore(snap *Storage) {
+	s.teams = snap.teams
+	s.settings = snap.settings
+	s.users = snap.users
+	s.prs = snap.prs
+	s.reviews = snap.reviews
+	s.events = snap.events
+	s.webhooks = snap.webhooks
+	s.deliveries = snap.deliveries
+	s.outbox = snap.outbox
+	s.apiKeys = snap.apiKeys
+	s.unavailability = snap.unavailability
+	s.unavailabilitySeq = snap.unavailabilitySeq
+}
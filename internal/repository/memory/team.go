@@ -0,0 +1,180 @@
+// team.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type teamRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewTeamRepository(s *Storage, l logger.Interface) repository.TeamRepository {
+	return &teamRepo{s: s, logger: l}
+}
+
+func (r *teamRepo) CreateTeam(ctx context.Context, team *entity.Team) error {
+	r.logger.Debug("Creating team: %s with %d members", team.Name, len(team.Members))
+
+	defer r.s.lock(ctx)()
+
+	// Повторное создание восстанавливает удаленную команду
+	r.s.teams[team.Name] = false
+	for _, member := range team.Members {
+		r.s.users[member.UserID] = entity.User{
+			ID:             member.UserID,
+			Username:       member.Username,
+			TeamName:       team.Name,
+			IsActive:       member.IsActive,
+			MaxOpenReviews: r.s.users[member.UserID].MaxOpenReviews,
+		}
+	}
+
+	r.logger.Info("Team created successfully: %s with %d members", team.Name, len(team.Members))
+	return nil
+}
+
+func (r *teamRepo) GetTeam(ctx context.Context, name string) (*entity.Team, error) {
+	r.logger.Debug("Getting team: %s", name)
+
+	defer r.s.rlock(ctx)()
+
+	team := entity.Team{Name: name}
+	for _, user := range r.s.users {
+		if user.TeamName == name && user.DeletedAt == nil {
+			team.Members = append(team.Members, entity.TeamMember{
+				UserID:   user.ID,
+				Username: user.Username,
+				IsActive: user.IsActive,
+			})
+		}
+	}
+
+	if deleted, ok := r.s.teams[name]; !ok || deleted {
+		r.logger.Warn("Team not found: %s", name)
+		return nil, fmt.Errorf("team not found")
+	}
+	if team.Members == nil {
+		team.Members = []entity.TeamMember{}
+	}
+
+	sort.Slice(team.Members, func(i, j int) bool {
+		return team.Members[i].Username < team.Members[j].Username
+	})
+
+	r.logger.Debug("Found team %s with %d members", name, len(team.Members))
+	return &team, nil
+}
+
+func (r *teamRepo) TeamExists(ctx context.Context, name string) (bool, error) {
+	defer r.s.rlock(ctx)()
+
+	deleted, ok := r.s.teams[name]
+	exists := ok && !deleted
+	r.logger.Debug("Team %s exists: %v", name, exists)
+	return exists, nil
+}
+
+func (r *teamRepo) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	defer r.s.rlock(ctx)()
+
+	settings, ok := r.s.settings[teamName]
+	if !ok {
+		r.logger.Debug("No settings for team %s", teamName)
+		return nil, nil
+	}
+	return &settings, nil
+}
+
+func (r *teamRepo) UpsertTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.teams[settings.TeamName]; !ok {
+		return fmt.Errorf("teamRepo - UpsertTeamSettings: team %s: %w", settings.TeamName, errForeignKey)
+	}
+	r.s.settings[settings.TeamName] = *settings
+
+	r.logger.Info("Team settings saved: %s", settings.TeamName)
+	return nil
+}
+
+func (r *teamRepo) ListTeams(ctx context.Context) ([]entity.TeamSummary, error) {
+	defer r.s.rlock(ctx)()
+
+	summaries := make(map[string]*entity.TeamSummary)
+	for name, deleted := range r.s.teams {
+		if !deleted {
+			summaries[name] = &entity.TeamSummary{Name: name}
+		}
+	}
+	for _, user := range r.s.users {
+		summary, ok := summaries[user.TeamName]
+		if !ok || user.DeletedAt != nil {
+			continue
+		}
+		summary.MembersCount++
+		if user.IsActive {
+			summary.ActiveCount++
+		}
+	}
+
+	teams := make([]entity.TeamSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		teams = append(teams, *summary)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name < teams[j].Name
+	})
+
+	r.logger.Debug("Found %d teams", len(teams))
+	return teams, nil
+}
+
+func (r *teamRepo) RenameTeam(ctx context.Context, oldName, newName string) error {
+	defer r.s.lock(ctx)()
+
+	if deleted, ok := r.s.teams[oldName]; !ok || deleted {
+		return fmt.Errorf("teamRepo - RenameTeam: %w", repository.ErrNotFound)
+	}
+	if _, ok := r.s.teams[newName]; ok {
+		return fmt.Errorf("teamRepo - RenameTeam: %w", repository.ErrAlreadyExists)
+	}
+
+	// Как ON UPDATE CASCADE: переносим настройки и участников, включая удаленных
+	delete(r.s.teams, oldName)
+	r.s.teams[newName] = false
+	if settings, ok := r.s.settings[oldName]; ok {
+		delete(r.s.settings, oldName)
+		settings.TeamName = newName
+		r.s.settings[newName] = settings
+	}
+	for id, user := range r.s.users {
+		if user.TeamName == oldName {
+			user.TeamName = newName
+			r.s.users[id] = user
+		}
+	}
+
+	r.logger.Info("Team %s renamed to %s", oldName, newName)
+	return nil
+}
+
+func (r *teamRepo) DeleteTeam(ctx context.Context, name string) error {
+	defer r.s.lock(ctx)()
+
+	if deleted, ok := r.s.teams[name]; !ok || deleted {
+		return fmt.Errorf("teamRepo - DeleteTeam: %w", repository.ErrNotFound)
+	}
+	r.s.teams[name] = true
+	delete(r.s.settings, name)
+
+	r.logger.Info("Team deleted: %s", name)
+	return nil
+}
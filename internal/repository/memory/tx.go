@@ -0,0 +1,42 @@
+// tx.go
+package memory
+
+import (
+	"context"
+
+	"github.com/PaulLocust/Avito-review/internal/repository"
+)
+
+type txKey struct{}
+
+type transactor struct {
+	s *Storage
+}
+
+// NewTransactor возвращает Transactor для in-memory хранилища.
+// Транзакции выполняются строго по одной, при ошибке данные восстанавливаются из снимка.
+// Операции вне транзакций дожидаются ее завершения (Storage.lock), поэтому откат не теряет их изменения
+func NewTransactor(s *Storage) repository.Transactor {
+	return &transactor{s: s}
+}
+
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+
+	t.s.txMu.Lock()
+	defer t.s.txMu.Unlock()
+
+	t.s.mu.RLock()
+	snap := t.s.snapshot()
+	t.s.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, struct{}{})); err != nil {
+		t.s.mu.Lock()
+		t.s.restore(snap)
+		t.s.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+func TestRollbackKeepsWritesOutsideTransaction(t *testing.T) {
+	ctx := context.Background()
+	storage := NewStorage()
+	teamRepo := NewTeamRepository(storage, logger.New("error"))
+	tx := NewTransactor(storage)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	txDone := make(chan error, 1)
+	go func() {
+		txDone <- tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := teamRepo.CreateTeam(ctx, &entity.Team{Name: "rolled-back"}); err != nil {
+				return err
+			}
+			close(started)
+			<-release
+			return errors.New("rollback")
+		})
+	}()
+	<-started
+
+	// Запись и чтение вне транзакции ждут ее завершения
+	writeDone := make(chan error, 1)
+	go func() {
+		writeDone <- teamRepo.CreateTeam(ctx, &entity.Team{Name: "outside"})
+	}()
+	readDone := make(chan bool, 1)
+	go func() {
+		exists, _ := teamRepo.TeamExists(ctx, "rolled-back")
+		readDone <- exists
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if err := <-txDone; err == nil {
+		t.Fatal("transaction must fail")
+	}
+	if err := <-writeDone; err != nil {
+		t.Fatalf("CreateTeam outside transaction: %v", err)
+	}
+	if <-readDone {
+		t.Error("read outside transaction saw uncommitted team")
+	}
+
+	for name, want := range map[string]bool{"rolled-back": false, "outside": true} {
+		exists, err := teamRepo.TeamExists(ctx, name)
+		if err != nil {
+			t.Fatalf("TeamExists(%s): %v", name, err)
+		}
+		if exists != want {
+			t.Errorf("TeamExists(%s) = %v, want %v", name, exists, want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// unavailability.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type unavailabilityRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewUnavailabilityRepository(s *Storage, l logger.Interface) repository.UnavailabilityRepository {
+	return &unavailabilityRepo{s: s, logger: l}
+}
+
+func (r *unavailabilityRepo) CreateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	r.logger.Debug("Creating unavailability for user %s: %s - %s", period.UserID, period.From, period.To)
+
+	defer r.s.lock(ctx)()
+
+	r.s.unavailabilitySeq++
+	period.ID = r.s.unavailabilitySeq
+	period.CreatedAt = time.Now()
+	r.s.unavailability[period.ID] = *period
+	return nil
+}
+
+func (r *unavailabilityRepo) GetUnavailability(ctx context.Context, id int64) (*entity.Unavailability, error) {
+	defer r.s.rlock(ctx)()
+
+	period, ok := r.s.unavailability[id]
+	if !ok {
+		return nil, fmt.Errorf("unavailabilityRepo - GetUnavailability: %w", repository.ErrNotFound)
+	}
+	return &period, nil
+}
+
+func (r *unavailabilityRepo) ListUnavailability(ctx context.Context, userID string) ([]entity.Unavailability, error) {
+	r.logger.Debug("Listing unavailability for user %s", userID)
+
+	defer r.s.rlock(ctx)()
+
+	periods := []entity.Unavailability{}
+	for _, period := range r.s.unavailability {
+		if period.UserID == userID {
+			periods = append(periods, period)
+		}
+	}
+	sortUnavailability(periods)
+	return periods, nil
+}
+
+func (r *unavailabilityRepo) UpdateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	r.logger.Debug("Updating unavailability %d", period.ID)
+
+	defer r.s.lock(ctx)()
+
+	existing, ok := r.s.unavailability[period.ID]
+	if !ok {
+		return fmt.Errorf("unavailabilityRepo - UpdateUnavailability: %w", repository.ErrNotFound)
+	}
+	existing.From = period.From
+	existing.To = period.To
+	existing.Reason = period.Reason
+	existing.ReassignedAt = period.ReassignedAt
+	r.s.unavailability[period.ID] = existing
+	return nil
+}
+
+func (r *unavailabilityRepo) DeleteUnavailability(ctx context.Context, id int64) error {
+	r.logger.Debug("Deleting unavailability %d", id)
+
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.unavailability[id]; !ok {
+		return fmt.Errorf("unavailabilityRepo - DeleteUnavailability: %w", repository.ErrNotFound)
+	}
+	delete(r.s.unavailability, id)
+	return nil
+}
+
+func (r *unavailabilityRepo) GetStartedUnavailability(ctx context.Context, at time.Time, limit int) ([]entity.Unavailability, error) {
+	defer r.s.rlock(ctx)()
+
+	periods := []entity.Unavailability{}
+	for _, period := range r.s.unavailability {
+		if period.ReassignedAt == nil && period.Covers(at) {
+			periods = append(periods, period)
+		}
+	}
+	sortUnavailability(periods)
+	if len(periods) > limit {
+		periods = periods[:limit]
+	}
+	return periods, nil
+}
+
+// sortUnavailability упорядочивает периоды по началу, как ORDER BY starts_at, id
+func sortUnavailability(periods []entity.Unavailability) {
+	sort.Slice(periods, func(i, j int) bool {
+		if !periods[i].From.Equal(periods[j].From) {
+			return periods[i].From.Before(periods[j].From)
+		}
+		return periods[i].ID < periods[j].ID
+	})
+}
@@ -0,0 +1,291 @@
+// user.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type userRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewUserRepository(s *Storage, l logger.Interface) repository.UserRepository {
+	return &userRepo{s: s, logger: l}
+}
+
+func (r *userRepo) CreateOrUpdateUser(ctx context.Context, user *entity.User) error {
+	r.logger.Debug("Creating or updating user: %s", user.ID)
+
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.teams[user.TeamName]; !ok {
+		return fmt.Errorf("userRepo - CreateOrUpdateUser: team %s: %w", user.TeamName, errForeignKey)
+	}
+	// Как и ON CONFLICT DO UPDATE, не трогаем личный лимит открытых ревью
+	saved := *user
+	saved.MaxOpenReviews = r.s.users[user.ID].MaxOpenReviews
+	r.s.users[user.ID] = saved
+
+	r.logger.Debug("User created or updated successfully: %s", user.ID)
+	return nil
+}
+
+func (r *userRepo) GetUser(ctx context.Context, id string) (*entity.User, error) {
+	r.logger.Debug("Getting user: %s", id)
+
+	defer r.s.rlock(ctx)()
+
+	user, ok := r.s.users[id]
+	if !ok || user.DeletedAt != nil {
+		r.logger.Warn("User not found: %s", id)
+		return nil, fmt.Errorf("userRepo - GetUser: %w", repository.ErrNotFound)
+	}
+	return &user, nil
+}
+
+func (r *userRepo) UpdateUser(ctx context.Context, user *entity.User) error {
+	r.logger.Debug("Updating user: %s", user.ID)
+
+	defer r.s.lock(ctx)()
+
+	// Как и UPDATE без совпавших строк - отсутствие пользователя не ошибка
+	if existing, ok := r.s.users[user.ID]; !ok || existing.DeletedAt != nil {
+		return nil
+	}
+	if _, ok := r.s.teams[user.TeamName]; !ok {
+		return fmt.Errorf("userRepo - UpdateUser: team %s: %w", user.TeamName, errForeignKey)
+	}
+	r.s.users[user.ID] = *user
+
+	r.logger.Debug("User updated successfully: %s", user.ID)
+	return nil
+}
+
+func (r *userRepo) GetActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string) ([]entity.User, error) {
+	r.logger.Debug("Getting active users by team: %s, exclude: %s", teamName, excludeUserID)
+
+	defer r.s.rlock(ctx)()
+
+	return r.activeUsersByTeam(teamName, excludeUserID), nil
+}
+
+func (r *userRepo) GetAvailableUsersByTeam(ctx context.Context, teamName string, at time.Time) ([]entity.User, error) {
+	r.logger.Debug("Getting users of team %s available at %s", teamName, at)
+
+	defer r.s.rlock(ctx)()
+
+	return r.availableUsersByTeam(teamName, "", at), nil
+}
+
+// activeUsersByTeam возвращает активных и доступных сейчас участников команды, вызывается под блокировкой хранилища
+func (r *userRepo) activeUsersByTeam(teamName string, excludeUserID string) []entity.User {
+	return r.availableUsersByTeam(teamName, excludeUserID, time.Now())
+}
+
+// availableUsersByTeam возвращает активных участников команды, доступных в момент at, вызывается под блокировкой хранилища
+func (r *userRepo) availableUsersByTeam(teamName string, excludeUserID string, at time.Time) []entity.User {
+	var users []entity.User
+	for _, user := range r.s.users {
+		if user.TeamName == teamName && user.IsActive && user.DeletedAt == nil && user.ID != excludeUserID &&
+			!r.s.unavailableAt(user.ID, at) {
+			users = append(users, user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users
+}
+
+func (r *userRepo) DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string, choose repository.ReplacementFunc) (*entity.TeamDeactivation, error) {
+	r.logger.Debug("Deactivating %d users in team %s", len(userIDs), teamName)
+
+	defer r.s.lock(ctx)()
+
+	result := &entity.TeamDeactivation{
+		TeamName:         teamName,
+		DeactivatedUsers: []string{},
+		Reassignments:    []entity.ReviewerReassignment{},
+	}
+
+	// Изменения применяем к копиям и сохраняем только при успехе, как при откате транзакции
+	users := make(map[string]entity.User)
+	deactivated := make(map[string]bool)
+	for _, id := range userIDs {
+		user, ok := r.s.users[id]
+		if !ok || user.TeamName != teamName || user.DeletedAt != nil || deactivated[id] {
+			continue
+		}
+		user.IsActive = false
+		users[id] = user
+		deactivated[id] = true
+		result.DeactivatedUsers = append(result.DeactivatedUsers, id)
+	}
+
+	var members []entity.User
+	for _, user := range r.activeUsersByTeam(teamName, "") {
+		if !deactivated[user.ID] {
+			members = append(members, user)
+		}
+	}
+
+	var prIDs []string
+	for id, pr := range r.s.prs {
+		if pr.Status != entity.StatusOpen {
+			continue
+		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			if deactivated[reviewerID] {
+				prIDs = append(prIDs, id)
+				break
+			}
+		}
+	}
+	sort.Strings(prIDs)
+
+	prs := make(map[string]*entity.PullRequest, len(prIDs))
+	for _, id := range prIDs {
+		pr := copyPR(r.s.prs[id])
+		for _, oldUserID := range append([]string(nil), pr.AssignedReviewers...) {
+			if !deactivated[oldUserID] {
+				continue
+			}
+
+			var candidates []entity.User
+			for _, member := range members {
+				if member.ID != pr.AuthorID && !containsString(pr.AssignedReviewers, member.ID) {
+					candidates = append(candidates, member)
+				}
+			}
+
+			newUserID, err := choose(ctx, &pr, oldUserID, candidates)
+			if err != nil {
+				return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - choose: %w", err)
+			}
+			pr.AssignedReviewers = replaceString(pr.AssignedReviewers, oldUserID, newUserID)
+
+			result.Reassignments = append(result.Reassignments, entity.ReviewerReassignment{
+				PullRequestID: pr.ID,
+				OldUserID:     oldUserID,
+				NewUserID:     newUserID,
+			})
+		}
+		prs[id] = &pr
+	}
+
+	for id, user := range users {
+		r.s.users[id] = user
+	}
+	for id, pr := range prs {
+		r.s.prs[id] = pr
+	}
+	for _, reassignment := range result.Reassignments {
+		r.s.dropReview(reassignment.PullRequestID, reassignment.OldUserID)
+	}
+
+	r.logger.Info("Deactivated %d users in team %s, %d reviewer slots changed",
+		len(result.DeactivatedUsers), teamName, len(result.Reassignments))
+	return result, nil
+}
+
+func (r *userRepo) ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, error) {
+	r.logger.Debug("Listing users: %+v", filter)
+
+	defer r.s.rlock(ctx)()
+
+	users := []entity.User{}
+	for _, user := range r.s.users {
+		switch {
+		case user.DeletedAt != nil:
+		case filter.TeamName != "" && user.TeamName != filter.TeamName:
+		case filter.IsActive != nil && user.IsActive != *filter.IsActive:
+		default:
+			users = append(users, user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
+}
+
+func (r *userRepo) DeleteUser(ctx context.Context, id string) (*entity.User, error) {
+	r.logger.Debug("Deleting user: %s", id)
+
+	defer r.s.lock(ctx)()
+
+	user, ok := r.s.users[id]
+	if !ok || user.DeletedAt != nil {
+		r.logger.Warn("User not found: %s", id)
+		return nil, fmt.Errorf("userRepo - DeleteUser: %w", repository.ErrNotFound)
+	}
+
+	deletedAt := time.Now()
+	user.IsActive = false
+	user.DeletedAt = &deletedAt
+	r.s.users[id] = user
+
+	r.logger.Debug("User deleted: %s", id)
+	return &user, nil
+}
+
+func (r *userRepo) ReassignUserReviews(ctx context.Context, userID, teamName string, choose repository.ReplacementFunc) ([]entity.ReviewerReassignment, error) {
+	r.logger.Debug("Reassigning open reviews of user %s within team %s", userID, teamName)
+
+	defer r.s.lock(ctx)()
+
+	reassignments := []entity.ReviewerReassignment{}
+	members := r.activeUsersByTeam(teamName, userID)
+
+	var prIDs []string
+	for id, pr := range r.s.prs {
+		if pr.Status == entity.StatusOpen && containsString(pr.AssignedReviewers, userID) {
+			prIDs = append(prIDs, id)
+		}
+	}
+	sort.Strings(prIDs)
+
+	// Изменения применяем к копиям и сохраняем только при успехе, как при откате транзакции
+	prs := make(map[string]*entity.PullRequest, len(prIDs))
+	for _, id := range prIDs {
+		pr := copyPR(r.s.prs[id])
+
+		var candidates []entity.User
+		for _, member := range members {
+			if member.ID != pr.AuthorID && !containsString(pr.AssignedReviewers, member.ID) {
+				candidates = append(candidates, member)
+			}
+		}
+
+		newUserID, err := choose(ctx, &pr, userID, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("userRepo - ReassignUserReviews - choose: %w", err)
+		}
+		pr.AssignedReviewers = replaceString(pr.AssignedReviewers, userID, newUserID)
+		prs[id] = &pr
+
+		reassignments = append(reassignments, entity.ReviewerReassignment{
+			PullRequestID: pr.ID,
+			OldUserID:     userID,
+			NewUserID:     newUserID,
+		})
+	}
+
+	for id, pr := range prs {
+		r.s.prs[id] = pr
+		r.s.dropReview(id, userID)
+	}
+
+	r.logger.Debug("Reassigned %d open reviews of user %s", len(reassignments), userID)
+	return reassignments, nil
+}
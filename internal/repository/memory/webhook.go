@@ -0,0 +1,137 @@
+// webhook.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type webhookRepo struct {
+	s      *Storage
+	logger logger.Interface
+}
+
+func NewWebhookRepository(s *Storage, l logger.Interface) repository.WebhookRepository {
+	return &webhookRepo{s: s, logger: l}
+}
+
+// copyWebhook возвращает копию подписки, не разделяющую список событий с хранилищем
+func copyWebhook(webhook entity.Webhook) entity.Webhook {
+	webhook.EventTypes = append([]entity.WebhookEventType(nil), webhook.EventTypes...)
+	return webhook
+}
+
+func (r *webhookRepo) CreateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	r.logger.Debug("Creating webhook for %s", webhook.URL)
+
+	defer r.s.lock(ctx)()
+
+	webhook.ID = int64(len(r.s.webhooks) + 1)
+	webhook.CreatedAt = time.Now()
+	r.s.webhooks = append(r.s.webhooks, copyWebhook(*webhook))
+
+	r.logger.Debug("Webhook created: %d", webhook.ID)
+	return nil
+}
+
+func (r *webhookRepo) GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	r.logger.Debug("Getting webhook: %d", id)
+
+	defer r.s.rlock(ctx)()
+
+	if id < 1 || id > int64(len(r.s.webhooks)) {
+		return nil, fmt.Errorf("webhookRepo - GetWebhook: %w", repository.ErrNotFound)
+	}
+	webhook := copyWebhook(r.s.webhooks[id-1])
+	return &webhook, nil
+}
+
+func (r *webhookRepo) ListWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	r.logger.Debug("Listing webhooks")
+
+	defer r.s.rlock(ctx)()
+
+	webhooks := make([]entity.Webhook, 0, len(r.s.webhooks))
+	for _, webhook := range r.s.webhooks {
+		webhooks = append(webhooks, copyWebhook(webhook))
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepo) GetWebhooksByEvent(ctx context.Context, eventType entity.WebhookEventType) ([]entity.Webhook, error) {
+	r.logger.Debug("Getting webhooks subscribed to %s", eventType)
+
+	defer r.s.rlock(ctx)()
+
+	var webhooks []entity.Webhook
+	for _, webhook := range r.s.webhooks {
+		if webhook.Subscribed(eventType) {
+			webhooks = append(webhooks, copyWebhook(webhook))
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepo) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.logger.Debug("Creating delivery of %s to webhook %d", delivery.EventType, delivery.WebhookID)
+
+	defer r.s.lock(ctx)()
+
+	if delivery.WebhookID < 1 || delivery.WebhookID > int64(len(r.s.webhooks)) {
+		return fmt.Errorf("webhookRepo - CreateDelivery: webhook %d: %w", delivery.WebhookID, errForeignKey)
+	}
+
+	now := time.Now()
+	delivery.ID = int64(len(r.s.deliveries) + 1)
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+	r.s.deliveries = append(r.s.deliveries, *delivery)
+	return nil
+}
+
+func (r *webhookRepo) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.logger.Debug("Updating delivery %d: %s after %d attempts", delivery.ID, delivery.Status, delivery.Attempts)
+
+	defer r.s.lock(ctx)()
+
+	if delivery.ID < 1 || delivery.ID > int64(len(r.s.deliveries)) {
+		return fmt.Errorf("webhookRepo - UpdateDelivery: %w", repository.ErrNotFound)
+	}
+
+	delivery.UpdatedAt = time.Now()
+	r.s.deliveries[delivery.ID-1] = *delivery
+	return nil
+}
+
+func (r *webhookRepo) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]entity.WebhookDelivery, error) {
+	r.logger.Debug("Listing deliveries of webhook %d", webhookID)
+
+	defer r.s.rlock(ctx)()
+
+	deliveries := []entity.WebhookDelivery{}
+	for i := len(r.s.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if r.s.deliveries[i].WebhookID == webhookID {
+			deliveries = append(deliveries, r.s.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepo) GetPendingDeliveries(ctx context.Context, afterID int64, limit int) ([]entity.WebhookDelivery, error) {
+	r.logger.Debug("Getting pending webhook deliveries after %d", afterID)
+
+	defer r.s.rlock(ctx)()
+
+	deliveries := []entity.WebhookDelivery{}
+	for i := int(max(afterID, 0)); i < len(r.s.deliveries) && len(deliveries) < limit; i++ {
+		if r.s.deliveries[i].Status == entity.DeliveryPending {
+			deliveries = append(deliveries, r.s.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,104 @@
+// apikey.go
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type apiKeyRepo struct {
+	db     *pgxpool.Pool
+	logger logger.Interface
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool, l logger.Interface) repository.APIKeyRepository {
+	return &apiKeyRepo{db: db, logger: l}
+}
+
+const _apiKeyColumns = `id, name, role, prefix, key_hash, created_at, revoked_at`
+
+func scanAPIKey(row pgx.Row) (entity.APIKey, error) {
+	var key entity.APIKey
+	err := row.Scan(&key.ID, &key.Name, &key.Role, &key.Prefix, &key.KeyHash, &key.CreatedAt, &key.RevokedAt)
+	return key, err
+}
+
+func (r *apiKeyRepo) CreateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	r.logger.Debug("Creating API key %s with role %s", key.Name, key.Role)
+
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		INSERT INTO api_keys (name, role, prefix, key_hash)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, key.Name, key.Role, key.Prefix, key.KeyHash).Scan(&key.ID, &key.CreatedAt)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("apiKeyRepo - CreateAPIKey - Insert: %w", repository.ErrAlreadyExists)
+	}
+	if err != nil {
+		r.logger.Error("Failed to insert API key: %v", err)
+		return fmt.Errorf("apiKeyRepo - CreateAPIKey - Insert: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepo) GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	key, err := scanAPIKey(conn(ctx, r.db).QueryRow(ctx,
+		`SELECT `+_apiKeyColumns+` FROM api_keys WHERE key_hash = $1`, keyHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("apiKeyRepo - GetAPIKeyByHash: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("Failed to get API key: %v", err)
+		return nil, fmt.Errorf("apiKeyRepo - GetAPIKeyByHash - Scan: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepo) ListAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	r.logger.Debug("Listing API keys")
+
+	rows, err := conn(ctx, r.db).Query(ctx, `SELECT `+_apiKeyColumns+` FROM api_keys ORDER BY id`)
+	if err != nil {
+		r.logger.Error("Failed to query API keys: %v", err)
+		return nil, fmt.Errorf("apiKeyRepo - ListAPIKeys - Query: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []entity.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan API key: %v", err)
+			return nil, fmt.Errorf("apiKeyRepo - ListAPIKeys - Scan: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("apiKeyRepo - ListAPIKeys - Rows: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepo) RevokeAPIKey(ctx context.Context, id int64) (*entity.APIKey, error) {
+	r.logger.Debug("Revoking API key: %d", id)
+
+	key, err := scanAPIKey(conn(ctx, r.db).QueryRow(ctx, `
+		UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING `+_apiKeyColumns, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("apiKeyRepo - RevokeAPIKey: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("Failed to revoke API key: %v", err)
+		return nil, fmt.Errorf("apiKeyRepo - RevokeAPIKey - Update: %w", err)
+	}
+	return &key, nil
+}
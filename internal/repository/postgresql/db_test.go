@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/PaulLocust/Avito-review/migrations"
+	"github.com/PaulLocust/Avito-review/pkg/migrate"
+	"github.com/PaulLocust/Avito-review/pkg/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// _testPrefix отмечает тестовые данные, они удаляются до и после теста
+const _testPrefix = "test-"
+
+// testPool подключается к базе из TEST_PG_URL и применяет миграции.
+// Без TEST_PG_URL тест пропускается: go test ./... не требует PostgreSQL
+func testPool(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+
+	url := os.Getenv("TEST_PG_URL")
+	if url == "" {
+		tb.Skip("TEST_PG_URL is not set")
+	}
+
+	// N+1-вариант в бенчмарке держит открытым основной запрос, пока выполняет вложенный, - нужно минимум 2 соединения
+	pg, err := postgres.New(url, postgres.MaxPoolSize(4))
+	if err != nil {
+		tb.Fatalf("postgres.New: %v", err)
+	}
+	tb.Cleanup(pg.Close)
+
+	ctx := context.Background()
+	migrator, err := migrate.New(pg.Pool, migrations.FS)
+	if err != nil {
+		tb.Fatalf("migrate.New: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		tb.Fatalf("migrator.Up: %v", err)
+	}
+
+	cleanupTestData(tb, pg.Pool)
+	tb.Cleanup(func() { cleanupTestData(tb, pg.Pool) })
+	return pg.Pool
+}
+
+// cleanupTestData удаляет данные с префиксом _testPrefix
+func cleanupTestData(tb testing.TB, pool *pgxpool.Pool) {
+	tb.Helper()
+
+	like := _testPrefix + "%"
+	for _, query := range []string{
+		`DELETE FROM pr_events WHERE pr_id LIKE $1`,
+		`DELETE FROM pr_reviewers WHERE pr_id LIKE $1`,
+		`DELETE FROM pull_requests WHERE id LIKE $1`,
+		`DELETE FROM user_unavailability WHERE user_id LIKE $1`,
+		`DELETE FROM users WHERE id LIKE $1`,
+		`DELETE FROM team_settings WHERE team_name LIKE $1`,
+		`DELETE FROM teams WHERE name LIKE $1`,
+	} {
+		if _, err := pool.Exec(context.Background(), query, like); err != nil {
+			tb.Fatalf("cleanup: %v", err)
+		}
+	}
+}
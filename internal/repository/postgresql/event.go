@@ -0,0 +1,80 @@
+// event.go
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type eventRepo struct {
+	db     *pgxpool.Pool
+	logger logger.Interface
+}
+
+func NewEventRepository(db *pgxpool.Pool, l logger.Interface) repository.EventRepository {
+	return &eventRepo{db: db, logger: l}
+}
+
+func (r *eventRepo) AddEvents(ctx context.Context, events []entity.PREvent) error {
+	r.logger.Debug("Adding %d PR events", len(events))
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, event := range events {
+		batch.Queue(`
+			INSERT INTO pr_events (pr_id, type, actor, old_user_id, new_user_id, reason, created_at)
+			VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+		`, event.PullRequestID, event.Type, event.Actor, event.OldUserID, event.NewUserID, event.Reason, event.CreatedAt)
+	}
+
+	if err := conn(ctx, r.db).SendBatch(ctx, batch).Close(); err != nil {
+		r.logger.Error("Failed to insert PR events: %v", err)
+		return fmt.Errorf("eventRepo - AddEvents - SendBatch: %w", err)
+	}
+
+	r.logger.Debug("Added %d PR events", len(events))
+	return nil
+}
+
+func (r *eventRepo) GetPREvents(ctx context.Context, prID string) ([]entity.PREvent, error) {
+	r.logger.Debug("Getting events of PR: %s", prID)
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT id, pr_id, type, actor, COALESCE(old_user_id, ''), COALESCE(new_user_id, ''), reason, created_at
+		FROM pr_events
+		WHERE pr_id = $1
+		ORDER BY id
+	`, prID)
+	if err != nil {
+		r.logger.Error("Failed to query PR events: %v", err)
+		return nil, fmt.Errorf("eventRepo - GetPREvents - Query: %w", err)
+	}
+	defer rows.Close()
+
+	events := []entity.PREvent{}
+	for rows.Next() {
+		var event entity.PREvent
+		err := rows.Scan(&event.ID, &event.PullRequestID, &event.Type, &event.Actor,
+			&event.OldUserID, &event.NewUserID, &event.Reason, &event.CreatedAt)
+		if err != nil {
+			r.logger.Error("Failed to scan PR event: %v", err)
+			return nil, fmt.Errorf("eventRepo - GetPREvents - Scan: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("eventRepo - GetPREvents - Rows: %w", err)
+	}
+
+	r.logger.Debug("Found %d events for PR %s", len(events), prID)
+	return events, nil
+}
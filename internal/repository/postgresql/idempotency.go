@@ -0,0 +1,105 @@
+// idempotency.go
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// _reserveAttempts - резерв повторяется, если существующая запись истекла и удалена между INSERT и SELECT
+const _reserveAttempts = 3
+
+type idempotencyRepo struct {
+	db     *pgxpool.Pool
+	logger logger.Interface
+}
+
+func NewIdempotencyRepository(db *pgxpool.Pool, l logger.Interface) repository.IdempotencyRepository {
+	return &idempotencyRepo{db: db, logger: l}
+}
+
+func (r *idempotencyRepo) ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, bool, error) {
+	for attempt := 0; attempt < _reserveAttempts; attempt++ {
+		// Просроченная запись перезаписывается, действующая остается как есть
+		err := conn(ctx, r.db).QueryRow(ctx, `
+			INSERT INTO idempotency_keys (scope, key, fingerprint, expires_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (scope, key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, content_type = '', body = NULL,
+				created_at = NOW(), expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= NOW()
+			RETURNING created_at
+		`, record.Scope, record.Key, record.Fingerprint, record.ExpiresAt).Scan(&record.CreatedAt)
+		if err == nil {
+			record.StatusCode = 0
+			record.ContentType = ""
+			record.Body = nil
+			return record, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			r.logger.Error("Failed to reserve idempotency key: %v", err)
+			return nil, false, fmt.Errorf("idempotencyRepo - ReserveIdempotencyKey - Insert: %w", err)
+		}
+
+		var existing entity.IdempotencyRecord
+		var statusCode *int
+		err = conn(ctx, r.db).QueryRow(ctx, `
+			SELECT scope, key, fingerprint, status_code, content_type, body, created_at, expires_at
+			FROM idempotency_keys
+			WHERE scope = $1 AND key = $2
+		`, record.Scope, record.Key).Scan(&existing.Scope, &existing.Key, &existing.Fingerprint, &statusCode,
+			&existing.ContentType, &existing.Body, &existing.CreatedAt, &existing.ExpiresAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			r.logger.Error("Failed to get idempotency key: %v", err)
+			return nil, false, fmt.Errorf("idempotencyRepo - ReserveIdempotencyKey - Select: %w", err)
+		}
+		if statusCode != nil {
+			existing.StatusCode = *statusCode
+		}
+		return &existing, false, nil
+	}
+	return nil, false, fmt.Errorf("idempotencyRepo - ReserveIdempotencyKey: key %s is contended", record.Key)
+}
+
+func (r *idempotencyRepo) CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE idempotency_keys SET status_code = $3, content_type = $4, body = $5, expires_at = $6
+		WHERE scope = $1 AND key = $2
+	`, record.Scope, record.Key, record.StatusCode, record.ContentType, record.Body, record.ExpiresAt)
+	if err != nil {
+		r.logger.Error("Failed to complete idempotency key: %v", err)
+		return fmt.Errorf("idempotencyRepo - CompleteIdempotencyKey - Update: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("idempotencyRepo - CompleteIdempotencyKey: %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) DeleteIdempotencyKey(ctx context.Context, scope, key string) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2`, scope, key)
+	if err != nil {
+		r.logger.Error("Failed to delete idempotency key: %v", err)
+		return fmt.Errorf("idempotencyRepo - DeleteIdempotencyKey - Delete: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	tag, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		r.logger.Error("Failed to delete expired idempotency keys: %v", err)
+		return 0, fmt.Errorf("idempotencyRepo - DeleteExpiredIdempotencyKeys - Delete: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
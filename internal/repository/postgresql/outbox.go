@@ -0,0 +1,87 @@
+// outbox.go
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type outboxRepo struct {
+	db     *pgxpool.Pool
+	logger logger.Interface
+}
+
+func NewOutboxRepository(db *pgxpool.Pool, l logger.Interface) repository.OutboxRepository {
+	return &outboxRepo{db: db, logger: l}
+}
+
+func (r *outboxRepo) AddOutboxMessage(ctx context.Context, msg *entity.OutboxMessage) error {
+	r.logger.Debug("Adding %s to outbox", msg.Type)
+
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		INSERT INTO outbox (type, payload)
+		VALUES ($1, $2)
+		RETURNING id, created_at
+	`, msg.Type, msg.Payload).Scan(&msg.ID, &msg.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to insert outbox message: %v", err)
+		return fmt.Errorf("outboxRepo - AddOutboxMessage - Insert: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) GetPendingOutbox(ctx context.Context, limit int) ([]entity.OutboxMessage, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT id, type, payload, attempts, last_error, created_at
+		FROM outbox
+		WHERE sent_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		r.logger.Error("Failed to query outbox: %v", err)
+		return nil, fmt.Errorf("outboxRepo - GetPendingOutbox - Query: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []entity.OutboxMessage
+	for rows.Next() {
+		var msg entity.OutboxMessage
+		if err := rows.Scan(&msg.ID, &msg.Type, &msg.Payload, &msg.Attempts, &msg.LastError, &msg.CreatedAt); err != nil {
+			r.logger.Error("Failed to scan outbox message: %v", err)
+			return nil, fmt.Errorf("outboxRepo - GetPendingOutbox - Scan: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("outboxRepo - GetPendingOutbox - Rows: %w", err)
+	}
+	return messages, nil
+}
+
+func (r *outboxRepo) MarkOutboxSent(ctx context.Context, id int64) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE outbox SET sent_at = NOW(), attempts = attempts + 1, last_error = '' WHERE id = $1
+	`, id)
+	if err != nil {
+		r.logger.Error("Failed to mark outbox message %d sent: %v", id, err)
+		return fmt.Errorf("outboxRepo - MarkOutboxSent - Update: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) MarkOutboxFailed(ctx context.Context, id int64, lastError string) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1
+	`, id, lastError)
+	if err != nil {
+		r.logger.Error("Failed to mark outbox message %d failed: %v", id, err)
+		return fmt.Errorf("outboxRepo - MarkOutboxFailed - Update: %w", err)
+	}
+	return nil
+}
@@ -3,12 +3,16 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/PaulLocust/Avito-review/internal/entity"
 	"github.com/PaulLocust/Avito-review/internal/repository"
 	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -24,7 +28,7 @@ func NewPRRepository(db *pgxpool.Pool, l logger.Interface) repository.PRReposito
 func (r *prRepo) CreatePR(ctx context.Context, pr *entity.PullRequest) error {
 	r.logger.Debug("Creating PR: %s with %d reviewers", pr.ID, len(pr.AssignedReviewers))
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		r.logger.Error("Failed to begin transaction for PR creation: %v", err)
 		return fmt.Errorf("prRepo - CreatePR - Begin: %w", err)
@@ -36,6 +40,10 @@ func (r *prRepo) CreatePR(ctx context.Context, pr *entity.PullRequest) error {
 		INSERT INTO pull_requests (id, name, author_id, status, created_at) 
 		VALUES ($1, $2, $3, $4, $5)
 	`, pr.ID, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt)
+	if isUniqueViolation(err) {
+		r.logger.Warn("PR already exists: %s", pr.ID)
+		return fmt.Errorf("prRepo - CreatePR - Insert PR: %w", repository.ErrAlreadyExists)
+	}
 	if err != nil {
 		r.logger.Error("Failed to insert PR: %v", err)
 		return fmt.Errorf("prRepo - CreatePR - Insert PR: %w", err)
@@ -64,19 +72,37 @@ func (r *prRepo) CreatePR(ctx context.Context, pr *entity.PullRequest) error {
 
 func (r *prRepo) GetPR(ctx context.Context, id string) (*entity.PullRequest, error) {
 	r.logger.Debug("Getting PR: %s", id)
+	return r.getPR(ctx, id, false)
+}
 
+func (r *prRepo) GetPRForUpdate(ctx context.Context, id string) (*entity.PullRequest, error) {
+	r.logger.Debug("Getting PR for update: %s", id)
+	return r.getPR(ctx, id, true)
+}
+
+func (r *prRepo) getPR(ctx context.Context, id string, forUpdate bool) (*entity.PullRequest, error) {
 	var pr entity.PullRequest
 	var mergedAt *time.Time
 
-	// Получаем основную информацию о PR
-	err := r.db.QueryRow(ctx, `
+	query := `
 		SELECT id, name, author_id, status, created_at, merged_at
 		FROM pull_requests 
 		WHERE id = $1
-	`, id).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
+	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
 
-	if err != nil {
+	// Получаем основную информацию о PR
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).
+		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
 		r.logger.Warn("PR not found: %s", id)
+		return nil, fmt.Errorf("prRepo - GetPR - Query PR: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("Failed to query PR %s: %v", id, err)
 		return nil, fmt.Errorf("prRepo - GetPR - Query PR: %w", err)
 	}
 
@@ -85,39 +111,21 @@ func (r *prRepo) GetPR(ctx context.Context, id string) (*entity.PullRequest, err
 		pr.MergedAt = mergedAt
 	}
 
-	// Получаем список ревьюверов
-	rows, err := r.db.Query(ctx, `
-		SELECT user_id 
-		FROM pr_reviewers 
-		WHERE pr_id = $1
-		ORDER BY user_id
-	`, id)
-	if err != nil {
-		r.logger.Error("Failed to query PR reviewers: %v", err)
-		return nil, fmt.Errorf("prRepo - GetPR - Query reviewers: %w", err)
+	// Получаем список ревьюверов и их решения
+	prs := []entity.PullRequest{pr}
+	if err := r.loadReviews(ctx, prs); err != nil {
+		return nil, fmt.Errorf("prRepo - GetPR - %w", err)
 	}
-	defer rows.Close()
+	pr = prs[0]
 
-	reviewerCount := 0
-	for rows.Next() {
-		var reviewerID string
-		err := rows.Scan(&reviewerID)
-		if err != nil {
-			r.logger.Error("Failed to scan reviewer: %v", err)
-			return nil, fmt.Errorf("prRepo - GetPR - Scan reviewer: %w", err)
-		}
-		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
-		reviewerCount++
-	}
-
-	r.logger.Debug("Found PR %s with %d reviewers", id, reviewerCount)
+	r.logger.Debug("Found PR %s with %d reviewers", id, len(pr.AssignedReviewers))
 	return &pr, nil
 }
 
 func (r *prRepo) UpdatePR(ctx context.Context, pr *entity.PullRequest) error {
 	r.logger.Debug("Updating PR: %s, status: %s", pr.ID, pr.Status)
 
-	_, err := r.db.Exec(ctx, `
+	_, err := conn(ctx, r.db).Exec(ctx, `
 		UPDATE pull_requests 
 		SET name = $1, status = $2, merged_at = $3
 		WHERE id = $4
@@ -137,7 +145,7 @@ func (r *prRepo) GetPRsByReviewer(ctx context.Context, userID string) ([]entity.
 
 	var prs []entity.PullRequest
 
-	rows, err := r.db.Query(ctx, `
+	rows, err := conn(ctx, r.db).Query(ctx, `
 		SELECT p.id, p.name, p.author_id, p.status, p.created_at, p.merged_at
 		FROM pull_requests p
 		JOIN pr_reviewers pr ON p.id = pr.pr_id
@@ -148,15 +156,14 @@ func (r *prRepo) GetPRsByReviewer(ctx context.Context, userID string) ([]entity.
 		r.logger.Error("Failed to query PRs by reviewer: %v", err)
 		return nil, fmt.Errorf("prRepo - GetPRsByReviewer - Query: %w", err)
 	}
-	defer rows.Close()
 
-	prCount := 0
 	for rows.Next() {
 		var pr entity.PullRequest
 		var mergedAt *time.Time
 
 		err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
 		if err != nil {
+			rows.Close()
 			r.logger.Error("Failed to scan PR: %v", err)
 			return nil, fmt.Errorf("prRepo - GetPRsByReviewer - Scan: %w", err)
 		}
@@ -165,41 +172,26 @@ func (r *prRepo) GetPRsByReviewer(ctx context.Context, userID string) ([]entity.
 			pr.MergedAt = mergedAt
 		}
 
-		// Получаем ревьюверов для этого PR
-		reviewerRows, err := r.db.Query(ctx, `
-			SELECT user_id FROM pr_reviewers WHERE pr_id = $1
-		`, pr.ID)
-		if err != nil {
-			r.logger.Error("Failed to query reviewers for PR %s: %v", pr.ID, err)
-			return nil, fmt.Errorf("prRepo - GetPRsByReviewer - Query reviewers: %w", err)
-		}
-
-		reviewerCount := 0
-		for reviewerRows.Next() {
-			var reviewerID string
-			err := reviewerRows.Scan(&reviewerID)
-			if err != nil {
-				reviewerRows.Close()
-				r.logger.Error("Failed to scan reviewer for PR %s: %v", pr.ID, err)
-				return nil, fmt.Errorf("prRepo - GetPRsByReviewer - Scan reviewer: %w", err)
-			}
-			pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
-			reviewerCount++
-		}
-		reviewerRows.Close()
-
 		prs = append(prs, pr)
-		prCount++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("prRepo - GetPRsByReviewer - Rows: %w", err)
+	}
+
+	// Ревьюверы всех найденных PR одним запросом (закрытые rows освобождают соединение транзакции)
+	if err := r.loadReviews(ctx, prs); err != nil {
+		return nil, fmt.Errorf("prRepo - GetPRsByReviewer - %w", err)
 	}
 
-	r.logger.Debug("Found %d PRs for reviewer %s", prCount, userID)
+	r.logger.Debug("Found %d PRs for reviewer %s", len(prs), userID)
 	return prs, nil
 }
 
 func (r *prRepo) AddReviewer(ctx context.Context, prID, userID string) error {
 	r.logger.Debug("Adding reviewer %s to PR %s", userID, prID)
 
-	_, err := r.db.Exec(ctx, `
+	_, err := conn(ctx, r.db).Exec(ctx, `
 		INSERT INTO pr_reviewers (pr_id, user_id) 
 		VALUES ($1, $2)
 		ON CONFLICT (pr_id, user_id) DO NOTHING
@@ -217,7 +209,7 @@ func (r *prRepo) AddReviewer(ctx context.Context, prID, userID string) error {
 func (r *prRepo) RemoveReviewer(ctx context.Context, prID, userID string) error {
 	r.logger.Debug("Removing reviewer %s from PR %s", userID, prID)
 
-	_, err := r.db.Exec(ctx, `
+	_, err := conn(ctx, r.db).Exec(ctx, `
 		DELETE FROM pr_reviewers 
 		WHERE pr_id = $1 AND user_id = $2
 	`, prID, userID)
@@ -234,7 +226,7 @@ func (r *prRepo) RemoveReviewer(ctx context.Context, prID, userID string) error
 func (r *prRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
 	r.logger.Debug("Replacing reviewer %s with %s in PR %s", oldUserID, newUserID, prID)
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		r.logger.Error("Failed to begin transaction for reviewer replacement: %v", err)
 		return fmt.Errorf("prRepo - ReplaceReviewer - Begin: %w", err)
@@ -256,6 +248,10 @@ func (r *prRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID
 		INSERT INTO pr_reviewers (pr_id, user_id) 
 		VALUES ($1, $2)
 	`, prID, newUserID)
+	if isUniqueViolation(err) {
+		r.logger.Warn("Reviewer %s already assigned to PR %s", newUserID, prID)
+		return fmt.Errorf("prRepo - ReplaceReviewer - Add new: %w", repository.ErrAlreadyExists)
+	}
 	if err != nil {
 		r.logger.Error("Failed to add new reviewer %s: %v", newUserID, err)
 		return fmt.Errorf("prRepo - ReplaceReviewer - Add new: %w", err)
@@ -269,3 +265,251 @@ func (r *prRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID
 	r.logger.Info("Reviewer replaced successfully: %s -> %s in PR %s", oldUserID, newUserID, prID)
 	return nil
 }
+
+func (r *prRepo) SetReviewState(ctx context.Context, prID, userID string, state entity.ReviewState) error {
+	r.logger.Debug("Setting review state of %s in PR %s to %s", userID, prID, state)
+
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE pr_reviewers
+		SET state = $1, updated_at = NOW()
+		WHERE pr_id = $2 AND user_id = $3
+	`, state, prID, userID)
+	if err != nil {
+		r.logger.Error("Failed to set review state of %s in PR %s: %v", userID, prID, err)
+		return fmt.Errorf("prRepo - SetReviewState: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		r.logger.Warn("Reviewer %s not assigned to PR %s", userID, prID)
+		return fmt.Errorf("prRepo - SetReviewState: %w", repository.ErrNotFound)
+	}
+
+	r.logger.Debug("Review state of %s in PR %s set to %s", userID, prID, state)
+	return nil
+}
+
+func (r *prRepo) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	r.logger.Debug("Counting open reviews for %d users", len(userIDs))
+
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT rv.user_id, COUNT(*)
+		FROM pr_reviewers rv
+		JOIN pull_requests p ON p.id = rv.pr_id
+		WHERE p.status = $1 AND rv.user_id = ANY($2)
+		GROUP BY rv.user_id
+	`, entity.StatusOpen, userIDs)
+	if err != nil {
+		r.logger.Error("Failed to count open reviews: %v", err)
+		return nil, fmt.Errorf("prRepo - CountOpenReviews - Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			r.logger.Error("Failed to scan open reviews count: %v", err)
+			return nil, fmt.Errorf("prRepo - CountOpenReviews - Scan: %w", err)
+		}
+		counts[userID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("prRepo - CountOpenReviews - Rows: %w", err)
+	}
+
+	r.logger.Debug("Counted open reviews for %d users", len(counts))
+	return counts, nil
+}
+
+func (r *prRepo) CountOpenReviewsForUpdate(ctx context.Context, userIDs []string) (map[string]int, error) {
+	r.logger.Debug("Locking %d users before counting open reviews", len(userIDs))
+
+	if len(userIDs) > 0 {
+		// Блокируем в порядке id, чтобы параллельные транзакции не взаимоблокировались
+		_, err := conn(ctx, r.db).Exec(ctx, `
+			SELECT id FROM users WHERE id = ANY($1) ORDER BY id FOR UPDATE
+		`, userIDs)
+		if err != nil {
+			r.logger.Error("Failed to lock users: %v", err)
+			return nil, fmt.Errorf("prRepo - CountOpenReviewsForUpdate - Lock: %w", err)
+		}
+	}
+
+	counts, err := r.CountOpenReviews(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("prRepo - CountOpenReviewsForUpdate - CountOpenReviews: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *prRepo) GetUnfinishedPRIDsByTeam(ctx context.Context, teamName string) ([]string, error) {
+	r.logger.Debug("Getting unfinished PRs of team: %s", teamName)
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT p.id
+		FROM pull_requests p
+		JOIN users u ON u.id = p.author_id
+		WHERE u.team_name = $1 AND p.status IN ($2, $3)
+		ORDER BY p.id
+	`, teamName, entity.StatusOpen, entity.StatusDraft)
+	if err != nil {
+		r.logger.Error("Failed to query unfinished PRs: %v", err)
+		return nil, fmt.Errorf("prRepo - GetUnfinishedPRIDsByTeam - Query: %w", err)
+	}
+	defer rows.Close()
+
+	prIDs := []string{}
+	for rows.Next() {
+		var prID string
+		if err := rows.Scan(&prID); err != nil {
+			r.logger.Error("Failed to scan PR id: %v", err)
+			return nil, fmt.Errorf("prRepo - GetUnfinishedPRIDsByTeam - Scan: %w", err)
+		}
+		prIDs = append(prIDs, prID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("prRepo - GetUnfinishedPRIDsByTeam - Rows: %w", err)
+	}
+
+	r.logger.Debug("Found %d unfinished PRs of team %s", len(prIDs), teamName)
+	return prIDs, nil
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE в пользовательской подстроке
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *prRepo) ListPRs(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error) {
+	r.logger.Debug("Listing PRs: %+v", filter)
+
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Select("p.id", "p.name", "p.author_id", "p.status", "p.created_at", "p.merged_at").
+		From("pull_requests p")
+
+	if filter.Status != "" {
+		builder = builder.Where(squirrel.Eq{"p.status": filter.Status})
+	}
+	if filter.AuthorID != "" {
+		builder = builder.Where(squirrel.Eq{"p.author_id": filter.AuthorID})
+	}
+	if filter.ReviewerID != "" {
+		builder = builder.Where("EXISTS (SELECT 1 FROM pr_reviewers rv WHERE rv.pr_id = p.id AND rv.user_id = ?)", filter.ReviewerID)
+	}
+	if filter.TeamName != "" {
+		builder = builder.Join("users a ON a.id = p.author_id").Where(squirrel.Eq{"a.team_name": filter.TeamName})
+	}
+	if filter.NameContains != "" {
+		builder = builder.Where("p.name ILIKE ?", "%"+likeEscaper.Replace(filter.NameContains)+"%")
+	}
+	if filter.CreatedFrom != nil {
+		builder = builder.Where(squirrel.GtOrEq{"p.created_at": *filter.CreatedFrom})
+	}
+	if filter.CreatedTo != nil {
+		builder = builder.Where(squirrel.Lt{"p.created_at": *filter.CreatedTo})
+	}
+	if filter.MergedFrom != nil {
+		builder = builder.Where(squirrel.GtOrEq{"p.merged_at": *filter.MergedFrom})
+	}
+	if filter.MergedTo != nil {
+		builder = builder.Where(squirrel.Lt{"p.merged_at": *filter.MergedTo})
+	}
+
+	// Keyset-пагинация: сравнение кортежей использует индекс и не зависит от OFFSET
+	if filter.Order == entity.SortAsc {
+		if filter.After != nil {
+			builder = builder.Where("(p.created_at, p.id) > (?, ?)", filter.After.CreatedAt, filter.After.ID)
+		}
+		builder = builder.OrderBy("p.created_at ASC", "p.id ASC")
+	} else {
+		if filter.After != nil {
+			builder = builder.Where("(p.created_at, p.id) < (?, ?)", filter.After.CreatedAt, filter.After.ID)
+		}
+		builder = builder.OrderBy("p.created_at DESC", "p.id DESC")
+	}
+	if filter.Limit > 0 {
+		builder = builder.Limit(uint64(filter.Limit))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("prRepo - ListPRs - ToSql: %w", err)
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to list PRs: %v", err)
+		return nil, fmt.Errorf("prRepo - ListPRs - Query: %w", err)
+	}
+
+	var prs []entity.PullRequest
+	for rows.Next() {
+		var pr entity.PullRequest
+		var mergedAt *time.Time
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan PR: %v", err)
+			return nil, fmt.Errorf("prRepo - ListPRs - Scan: %w", err)
+		}
+		pr.MergedAt = mergedAt
+		prs = append(prs, pr)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("prRepo - ListPRs - Rows: %w", err)
+	}
+
+	// Ревьюверы всех PR страницы одним запросом
+	if err := r.loadReviews(ctx, prs); err != nil {
+		return nil, fmt.Errorf("prRepo - ListPRs - %w", err)
+	}
+
+	r.logger.Debug("Listed %d PRs", len(prs))
+	return prs, nil
+}
+
+// loadReviews заполняет ревьюверов и их решения для списка PR одним запросом по ANY($1)
+// вместо отдельного запроса на каждый PR. Вызывать после закрытия rows основного запроса:
+// в транзакции pgx не допускает параллельных запросов на одном соединении
+func (r *prRepo) loadReviews(ctx context.Context, prs []entity.PullRequest) error {
+	if len(prs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(prs))
+	index := make(map[string]int, len(prs))
+	for i := range prs {
+		ids[i] = prs[i].ID
+		index[prs[i].ID] = i
+	}
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT pr_id, user_id, state
+		FROM pr_reviewers
+		WHERE pr_id = ANY($1)
+		ORDER BY pr_id, user_id
+	`, ids)
+	if err != nil {
+		r.logger.Error("Failed to query reviewers: %v", err)
+		return fmt.Errorf("loadReviews - Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID string
+		var review entity.Review
+		if err := rows.Scan(&prID, &review.ReviewerID, &review.State); err != nil {
+			r.logger.Error("Failed to scan reviewer: %v", err)
+			return fmt.Errorf("loadReviews - Scan: %w", err)
+		}
+		pr := &prs[index[prID]]
+		pr.AssignedReviewers = append(pr.AssignedReviewers, review.ReviewerID)
+		pr.Reviews = append(pr.Reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("loadReviews - Rows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	_benchTeam     = _testPrefix + "team"
+	_benchReviewer = _testPrefix + "reviewer"
+	_benchPRs      = 500
+	_benchTeamSize = 20
+)
+
+// BenchmarkGetPRsByReviewer сравнивает загрузку PR ревьювера запросом на каждый PR (N+1)
+// и пакетной загрузкой ревьюверов через ANY($1). Запуск: TEST_PG_URL=... make bench-reviews
+func BenchmarkGetPRsByReviewer(b *testing.B) {
+	pool := testPool(b)
+	ctx := context.Background()
+
+	seedReviewerPRs(b, pool, _benchPRs, _benchTeamSize)
+	repo := NewPRRepository(pool, logger.New("error"))
+
+	run := func(name string, load func() ([]entity.PullRequest, error)) {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				prs, err := load()
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(prs) != _benchPRs {
+					b.Fatalf("got %d PRs, want %d", len(prs), _benchPRs)
+				}
+			}
+		})
+	}
+
+	run("N+1", func() ([]entity.PullRequest, error) {
+		return getPRsByReviewerNPlusOne(ctx, pool, _benchReviewer)
+	})
+	run("batched", func() ([]entity.PullRequest, error) {
+		return repo.GetPRsByReviewer(ctx, _benchReviewer)
+	})
+}
+
+// seedReviewerPRs создает команду, пользователей и prCount открытых PR, в каждом из которых
+// назначены _benchReviewer и ещё один участник команды
+func seedReviewerPRs(tb testing.TB, pool *pgxpool.Pool, prCount, teamSize int) {
+	tb.Helper()
+	ctx := context.Background()
+
+	if _, err := pool.Exec(ctx, `INSERT INTO teams (name) VALUES ($1)`, _benchTeam); err != nil {
+		tb.Fatalf("insert team: %v", err)
+	}
+
+	users := [][]any{{_benchReviewer, "Bench Reviewer", _benchTeam, true}}
+	for i := 1; i < teamSize; i++ {
+		users = append(users, []any{fmt.Sprintf("%su%d", _testPrefix, i), fmt.Sprintf("Bench User %d", i), _benchTeam, true})
+	}
+	if _, err := pool.CopyFrom(ctx, pgx.Identifier{"users"},
+		[]string{"id", "username", "team_name", "is_active"}, pgx.CopyFromRows(users)); err != nil {
+		tb.Fatalf("copy users: %v", err)
+	}
+
+	now := time.Now()
+	prs := make([][]any, 0, prCount)
+	reviewers := make([][]any, 0, prCount*2)
+	for i := 0; i < prCount; i++ {
+		id := fmt.Sprintf("%spr-%d", _testPrefix, i)
+		author := users[1+i%(teamSize-1)][0]
+		other := users[1+(i+1)%(teamSize-1)][0]
+		prs = append(prs, []any{id, fmt.Sprintf("Bench PR %d", i), author, string(entity.StatusOpen), now.Add(time.Duration(i) * time.Second)})
+		reviewers = append(reviewers, []any{id, _benchReviewer})
+		if other != author {
+			reviewers = append(reviewers, []any{id, other})
+		}
+	}
+	if _, err := pool.CopyFrom(ctx, pgx.Identifier{"pull_requests"},
+		[]string{"id", "name", "author_id", "status", "created_at"}, pgx.CopyFromRows(prs)); err != nil {
+		tb.Fatalf("copy pull requests: %v", err)
+	}
+	if _, err := pool.CopyFrom(ctx, pgx.Identifier{"pr_reviewers"},
+		[]string{"pr_id", "user_id"}, pgx.CopyFromRows(reviewers)); err != nil {
+		tb.Fatalf("copy reviewers: %v", err)
+	}
+
+	if _, err := pool.Exec(ctx, `ANALYZE pull_requests; ANALYZE pr_reviewers`); err != nil {
+		tb.Fatalf("analyze: %v", err)
+	}
+}
+
+// getPRsByReviewerNPlusOne воспроизводит прежнюю реализацию: отдельный запрос ревьюверов на каждый PR
+func getPRsByReviewerNPlusOne(ctx context.Context, pool *pgxpool.Pool, userID string) ([]entity.PullRequest, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT p.id, p.name, p.author_id, p.status, p.created_at, p.merged_at
+		FROM pull_requests p
+		JOIN pr_reviewers pr ON p.id = pr.pr_id
+		WHERE pr.user_id = $1
+		ORDER BY p.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prs []entity.PullRequest
+	for rows.Next() {
+		var pr entity.PullRequest
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt); err != nil {
+			return nil, err
+		}
+
+		reviewerRows, err := pool.Query(ctx, `
+			SELECT user_id, state FROM pr_reviewers WHERE pr_id = $1
+		`, pr.ID)
+		if err != nil {
+			return nil, err
+		}
+		for reviewerRows.Next() {
+			var review entity.Review
+			if err := reviewerRows.Scan(&review.ReviewerID, &review.State); err != nil {
+				reviewerRows.Close()
+				return nil, err
+			}
+			pr.AssignedReviewers = append(pr.AssignedReviewers, review.ReviewerID)
+			pr.Reviews = append(pr.Reviews, review)
+		}
+		reviewerRows.Close()
+
+		prs = append(prs, pr)
+	}
+	return prs, rows.Err()
+}
@@ -0,0 +1,52 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+func TestCountOpenReviewsForUpdateLocksUsers(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	l := logger.New("error")
+	repo := NewPRRepository(pool, l)
+	tx := NewTransactor(pool, l)
+
+	team, user := _testPrefix+"capacity", _testPrefix+"capacity-user"
+	if _, err := pool.Exec(ctx, `INSERT INTO teams (name) VALUES ($1)`, team); err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO users (id, username, team_name, is_active) VALUES ($1, $1, $2, true)
+	`, user, team); err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := repo.CountOpenReviewsForUpdate(ctx, []string{user}); err != nil {
+			return err
+		}
+
+		// Параллельная транзакция должна ждать, пока первая не завершится
+		waitCtx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		err := tx.WithinTx(waitCtx, func(ctx context.Context) error {
+			_, err := repo.CountOpenReviewsForUpdate(ctx, []string{user})
+			return err
+		})
+		if err == nil {
+			t.Error("concurrent transaction counted open reviews of a locked user")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithinTx: %v", err)
+	}
+
+	if _, err := repo.CountOpenReviewsForUpdate(ctx, []string{user}); err != nil {
+		t.Errorf("CountOpenReviewsForUpdate after commit: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// stats.go
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type statsRepo struct {
+	db     *pgxpool.Pool
+	logger logger.Interface
+}
+
+func NewStatsRepository(db *pgxpool.Pool, l logger.Interface) repository.StatsRepository {
+	return &statsRepo{db: db, logger: l}
+}
+
+// Параметры всех запросов: $1 - команда, $2 - начало периода, $3 - конец периода.
+// Период применяется к дате создания PR.
+func (r *statsRepo) GetAssignmentStats(ctx context.Context, filter entity.StatsFilter) (*entity.AssignmentStats, error) {
+	r.logger.Debug("Getting assignment stats: %+v", filter)
+
+	stats := &entity.AssignmentStats{
+		Users:        []entity.UserAssignmentStats{},
+		Teams:        []entity.TeamAssignmentStats{},
+		PullRequests: []entity.PRReviewerStats{},
+	}
+	args := []interface{}{filter.TeamName, filter.From, filter.To}
+
+	// Статистика по пользователям (включая пользователей без назначений)
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT u.id, u.username, u.team_name,
+			COUNT(p.id),
+			COUNT(p.id) FILTER (WHERE p.status = 'OPEN'),
+			COUNT(p.id) FILTER (WHERE p.status = 'MERGED')
+		FROM users u
+		LEFT JOIN pr_reviewers rv ON rv.user_id = u.id
+		LEFT JOIN pull_requests p ON p.id = rv.pr_id
+			AND ($2::timestamp IS NULL OR p.created_at >= $2)
+			AND ($3::timestamp IS NULL OR p.created_at < $3)
+		WHERE ($1::text = '' OR u.team_name = $1)
+		GROUP BY u.id, u.username, u.team_name
+		ORDER BY COUNT(p.id) DESC, u.id
+	`, args...)
+	if err != nil {
+		r.logger.Error("Failed to query user stats: %v", err)
+		return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Query users: %w", err)
+	}
+	for rows.Next() {
+		var s entity.UserAssignmentStats
+		if err := rows.Scan(&s.UserID, &s.Username, &s.TeamName, &s.Total, &s.Open, &s.Merged); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan user stats: %v", err)
+			return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Scan users: %w", err)
+		}
+		stats.Users = append(stats.Users, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Rows users: %w", err)
+	}
+
+	// Статистика по командам ревьюверов
+	rows, err = conn(ctx, r.db).Query(ctx, `
+		SELECT u.team_name,
+			COUNT(p.id),
+			COUNT(p.id) FILTER (WHERE p.status = 'OPEN'),
+			COUNT(p.id) FILTER (WHERE p.status = 'MERGED')
+		FROM users u
+		LEFT JOIN pr_reviewers rv ON rv.user_id = u.id
+		LEFT JOIN pull_requests p ON p.id = rv.pr_id
+			AND ($2::timestamp IS NULL OR p.created_at >= $2)
+			AND ($3::timestamp IS NULL OR p.created_at < $3)
+		WHERE ($1::text = '' OR u.team_name = $1)
+		GROUP BY u.team_name
+		ORDER BY u.team_name
+	`, args...)
+	if err != nil {
+		r.logger.Error("Failed to query team stats: %v", err)
+		return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Query teams: %w", err)
+	}
+	for rows.Next() {
+		var s entity.TeamAssignmentStats
+		if err := rows.Scan(&s.TeamName, &s.Total, &s.Open, &s.Merged); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan team stats: %v", err)
+			return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Scan teams: %w", err)
+		}
+		stats.Teams = append(stats.Teams, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Rows teams: %w", err)
+	}
+
+	// Количество ревьюверов по PR (команда - команда автора)
+	rows, err = conn(ctx, r.db).Query(ctx, `
+		SELECT p.id, p.author_id, p.status, COUNT(rv.user_id)
+		FROM pull_requests p
+		JOIN users a ON a.id = p.author_id
+		LEFT JOIN pr_reviewers rv ON rv.pr_id = p.id
+		WHERE ($1::text = '' OR a.team_name = $1)
+			AND ($2::timestamp IS NULL OR p.created_at >= $2)
+			AND ($3::timestamp IS NULL OR p.created_at < $3)
+		GROUP BY p.id, p.author_id, p.status
+		ORDER BY p.id
+	`, args...)
+	if err != nil {
+		r.logger.Error("Failed to query PR stats: %v", err)
+		return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Query PRs: %w", err)
+	}
+	for rows.Next() {
+		var s entity.PRReviewerStats
+		if err := rows.Scan(&s.PullRequestID, &s.AuthorID, &s.Status, &s.ReviewerCount); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan PR stats: %v", err)
+			return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Scan PRs: %w", err)
+		}
+		stats.PullRequests = append(stats.PullRequests, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("statsRepo - GetAssignmentStats - Rows PRs: %w", err)
+	}
+
+	r.logger.Debug("Assignment stats: %d users, %d teams, %d PRs",
+		len(stats.Users), len(stats.Teams), len(stats.PullRequests))
+	return stats, nil
+}
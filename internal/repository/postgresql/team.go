@@ -3,11 +3,13 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/PaulLocust/Avito-review/internal/entity"
 	"github.com/PaulLocust/Avito-review/internal/repository"
 	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,15 +25,15 @@ func NewTeamRepository(db *pgxpool.Pool, l logger.Interface) repository.TeamRepo
 func (r *teamRepo) CreateTeam(ctx context.Context, team *entity.Team) error {
 	r.logger.Debug("Creating team: %s with %d members", team.Name, len(team.Members))
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		r.logger.Error("Failed to begin transaction for team creation: %v", err)
 		return fmt.Errorf("teamRepo - CreateTeam - Begin: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	// Создаем команду
-	_, err = tx.Exec(ctx, "INSERT INTO teams (name) VALUES ($1) ON CONFLICT (name) DO NOTHING", team.Name)
+	// Создаем команду или восстанавливаем удаленную
+	_, err = tx.Exec(ctx, "INSERT INTO teams (name) VALUES ($1) ON CONFLICT (name) DO UPDATE SET deleted_at = NULL", team.Name)
 	if err != nil {
 		r.logger.Error("Failed to insert team: %v", err)
 		return fmt.Errorf("teamRepo - CreateTeam - Insert team: %w", err)
@@ -45,7 +47,8 @@ func (r *teamRepo) CreateTeam(ctx context.Context, team *entity.Team) error {
 			ON CONFLICT (id) DO UPDATE SET 
 				username = EXCLUDED.username,
 				team_name = EXCLUDED.team_name,
-				is_active = EXCLUDED.is_active
+				is_active = EXCLUDED.is_active,
+				deleted_at = NULL
 		`, member.UserID, member.Username, team.Name, member.IsActive)
 		if err != nil {
 			r.logger.Error("Failed to insert user %s: %v", member.UserID, err)
@@ -68,11 +71,12 @@ func (r *teamRepo) GetTeam(ctx context.Context, name string) (*entity.Team, erro
 	var team entity.Team
 	team.Name = name
 
-	rows, err := r.db.Query(ctx, `
-		SELECT id, username, is_active 
-		FROM users 
-		WHERE team_name = $1
-		ORDER BY username
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT u.id, u.username, u.is_active 
+		FROM users u
+		JOIN teams t ON t.name = u.team_name
+		WHERE u.team_name = $1 AND u.deleted_at IS NULL AND t.deleted_at IS NULL
+		ORDER BY u.username
 	`, name)
 	if err != nil {
 		r.logger.Error("Failed to query team members: %v", err)
@@ -93,8 +97,16 @@ func (r *teamRepo) GetTeam(ctx context.Context, name string) (*entity.Team, erro
 	}
 
 	if memberCount == 0 {
-		r.logger.Warn("Team not found: %s", name)
-		return nil, fmt.Errorf("team not found")
+		// Команда может существовать и без участников
+		exists, err := r.TeamExists(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("teamRepo - GetTeam - %w", err)
+		}
+		if !exists {
+			r.logger.Warn("Team not found: %s", name)
+			return nil, fmt.Errorf("team not found")
+		}
+		team.Members = []entity.TeamMember{}
 	}
 
 	r.logger.Debug("Found team %s with %d members", name, memberCount)
@@ -105,7 +117,7 @@ func (r *teamRepo) TeamExists(ctx context.Context, name string) (bool, error) {
 	r.logger.Debug("Checking if team exists: %s", name)
 
 	var exists bool
-	err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)", name).Scan(&exists)
+	err := conn(ctx, r.db).QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND deleted_at IS NULL)", name).Scan(&exists)
 	if err != nil {
 		r.logger.Error("Failed to check team existence: %v", err)
 		return false, fmt.Errorf("teamRepo - TeamExists: %w", err)
@@ -114,3 +126,133 @@ func (r *teamRepo) TeamExists(ctx context.Context, name string) (bool, error) {
 	r.logger.Debug("Team %s exists: %v", name, exists)
 	return exists, nil
 }
+
+func (r *teamRepo) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	r.logger.Debug("Getting team settings: %s", teamName)
+
+	var settings entity.TeamSettings
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		SELECT team_name, reviewer_count, min_required, strategy, required_approvals, max_open_reviews
+		FROM team_settings
+		WHERE team_name = $1
+	`, teamName).Scan(&settings.TeamName, &settings.ReviewerCount, &settings.MinRequired, &settings.Strategy,
+		&settings.RequiredApprovals, &settings.MaxOpenReviews)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		r.logger.Debug("No settings for team %s", teamName)
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to get team settings: %v", err)
+		return nil, fmt.Errorf("teamRepo - GetTeamSettings: %w", err)
+	}
+
+	r.logger.Debug("Found settings for team %s: %+v", teamName, settings)
+	return &settings, nil
+}
+
+func (r *teamRepo) UpsertTeamSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	r.logger.Debug("Upserting team settings: %+v", *settings)
+
+	_, err := conn(ctx, r.db).Exec(ctx, `
+		INSERT INTO team_settings (team_name, reviewer_count, min_required, strategy, required_approvals, max_open_reviews)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (team_name) DO UPDATE SET
+			reviewer_count = EXCLUDED.reviewer_count,
+			min_required = EXCLUDED.min_required,
+			strategy = EXCLUDED.strategy,
+			required_approvals = EXCLUDED.required_approvals,
+			max_open_reviews = EXCLUDED.max_open_reviews
+	`, settings.TeamName, settings.ReviewerCount, settings.MinRequired, settings.Strategy, settings.RequiredApprovals,
+		settings.MaxOpenReviews)
+
+	if err != nil {
+		r.logger.Error("Failed to upsert team settings %s: %v", settings.TeamName, err)
+		return fmt.Errorf("teamRepo - UpsertTeamSettings: %w", err)
+	}
+
+	r.logger.Info("Team settings saved: %s", settings.TeamName)
+	return nil
+}
+
+func (r *teamRepo) ListTeams(ctx context.Context) ([]entity.TeamSummary, error) {
+	r.logger.Debug("Listing teams")
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT t.name, COUNT(u.id), COUNT(u.id) FILTER (WHERE u.is_active)
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.name AND u.deleted_at IS NULL
+		WHERE t.deleted_at IS NULL
+		GROUP BY t.name
+		ORDER BY t.name
+	`)
+	if err != nil {
+		r.logger.Error("Failed to query teams: %v", err)
+		return nil, fmt.Errorf("teamRepo - ListTeams - Query: %w", err)
+	}
+	defer rows.Close()
+
+	teams := []entity.TeamSummary{}
+	for rows.Next() {
+		var team entity.TeamSummary
+		if err := rows.Scan(&team.Name, &team.MembersCount, &team.ActiveCount); err != nil {
+			r.logger.Error("Failed to scan team: %v", err)
+			return nil, fmt.Errorf("teamRepo - ListTeams - Scan: %w", err)
+		}
+		teams = append(teams, team)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("teamRepo - ListTeams - Rows: %w", err)
+	}
+
+	r.logger.Debug("Found %d teams", len(teams))
+	return teams, nil
+}
+
+func (r *teamRepo) RenameTeam(ctx context.Context, oldName, newName string) error {
+	r.logger.Debug("Renaming team %s to %s", oldName, newName)
+
+	// users.team_name и team_settings.team_name обновляются внешними ключами ON UPDATE CASCADE
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE teams SET name = $2
+		WHERE name = $1 AND deleted_at IS NULL
+	`, oldName, newName)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("teamRepo - RenameTeam: %w", repository.ErrAlreadyExists)
+	}
+	if err != nil {
+		r.logger.Error("Failed to rename team %s: %v", oldName, err)
+		return fmt.Errorf("teamRepo - RenameTeam: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("teamRepo - RenameTeam: %w", repository.ErrNotFound)
+	}
+
+	r.logger.Info("Team %s renamed to %s", oldName, newName)
+	return nil
+}
+
+func (r *teamRepo) DeleteTeam(ctx context.Context, name string) error {
+	r.logger.Debug("Deleting team: %s", name)
+
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE teams SET deleted_at = NOW()
+		WHERE name = $1 AND deleted_at IS NULL
+	`, name)
+	if err != nil {
+		r.logger.Error("Failed to delete team %s: %v", name, err)
+		return fmt.Errorf("teamRepo - DeleteTeam - Update: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("teamRepo - DeleteTeam: %w", repository.ErrNotFound)
+	}
+
+	// Восстановленная через /team/add команда начинает с настроек по умолчанию
+	if _, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM team_settings WHERE team_name = $1`, name); err != nil {
+		r.logger.Error("Failed to delete team settings %s: %v", name, err)
+		return fmt.Errorf("teamRepo - DeleteTeam - Delete settings: %w", err)
+	}
+
+	r.logger.Info("Team deleted: %s", name)
+	return nil
+}
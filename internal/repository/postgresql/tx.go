@@ -0,0 +1,76 @@
+// tx.go
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// _uniqueViolation - SQLSTATE нарушения уникальности
+const _uniqueViolation = "23505"
+
+type txKey struct{}
+
+// querier - общие методы pgxpool.Pool и pgx.Tx
+type querier interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+// conn возвращает транзакцию из контекста, если она есть, иначе пул.
+// Begin на транзакции создает savepoint, поэтому методы репозиториев со своей транзакцией
+// корректно вкладываются во внешнюю
+func conn(ctx context.Context, db *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == _uniqueViolation
+}
+
+type transactor struct {
+	db     *pgxpool.Pool
+	logger logger.Interface
+}
+
+func NewTransactor(db *pgxpool.Pool, l logger.Interface) repository.Transactor {
+	return &transactor{db: db, logger: l}
+}
+
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Уже внутри транзакции - просто продолжаем её
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.logger.Error("Failed to begin transaction: %v", err)
+		return fmt.Errorf("transactor - WithinTx - Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.logger.Error("Failed to commit transaction: %v", err)
+		return fmt.Errorf("transactor - WithinTx - Commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// unavailability.go
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type unavailabilityRepo struct {
+	db     *pgxpool.Pool
+	logger logger.Interface
+}
+
+func NewUnavailabilityRepository(db *pgxpool.Pool, l logger.Interface) repository.UnavailabilityRepository {
+	return &unavailabilityRepo{db: db, logger: l}
+}
+
+const _unavailabilityColumns = `id, user_id, starts_at, ends_at, reason, created_at, reassigned_at`
+
+func scanUnavailability(row pgx.Row) (entity.Unavailability, error) {
+	var period entity.Unavailability
+	err := row.Scan(&period.ID, &period.UserID, &period.From, &period.To, &period.Reason, &period.CreatedAt,
+		&period.ReassignedAt)
+	return period, err
+}
+
+func (r *unavailabilityRepo) CreateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	r.logger.Debug("Creating unavailability for user %s: %s - %s", period.UserID, period.From, period.To)
+
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, period.UserID, period.From.UTC(), period.To.UTC(), period.Reason).Scan(&period.ID, &period.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to insert unavailability: %v", err)
+		return fmt.Errorf("unavailabilityRepo - CreateUnavailability - Insert: %w", err)
+	}
+	return nil
+}
+
+func (r *unavailabilityRepo) GetUnavailability(ctx context.Context, id int64) (*entity.Unavailability, error) {
+	period, err := scanUnavailability(conn(ctx, r.db).QueryRow(ctx,
+		`SELECT `+_unavailabilityColumns+` FROM user_unavailability WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("unavailabilityRepo - GetUnavailability: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("Failed to get unavailability: %v", err)
+		return nil, fmt.Errorf("unavailabilityRepo - GetUnavailability - Scan: %w", err)
+	}
+	return &period, nil
+}
+
+func (r *unavailabilityRepo) ListUnavailability(ctx context.Context, userID string) ([]entity.Unavailability, error) {
+	r.logger.Debug("Listing unavailability for user %s", userID)
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT `+_unavailabilityColumns+` FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at, id
+	`, userID)
+	if err != nil {
+		r.logger.Error("Failed to query unavailability: %v", err)
+		return nil, fmt.Errorf("unavailabilityRepo - ListUnavailability - Query: %w", err)
+	}
+	return r.collect(rows, "ListUnavailability")
+}
+
+func (r *unavailabilityRepo) UpdateUnavailability(ctx context.Context, period *entity.Unavailability) error {
+	r.logger.Debug("Updating unavailability %d", period.ID)
+
+	tag, err := conn(ctx, r.db).Exec(ctx, `
+		UPDATE user_unavailability
+		SET starts_at = $2, ends_at = $3, reason = $4, reassigned_at = $5
+		WHERE id = $1
+	`, period.ID, period.From.UTC(), period.To.UTC(), period.Reason, period.ReassignedAt)
+	if err != nil {
+		r.logger.Error("Failed to update unavailability %d: %v", period.ID, err)
+		return fmt.Errorf("unavailabilityRepo - UpdateUnavailability: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("unavailabilityRepo - UpdateUnavailability: %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *unavailabilityRepo) DeleteUnavailability(ctx context.Context, id int64) error {
+	r.logger.Debug("Deleting unavailability %d", id)
+
+	tag, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM user_unavailability WHERE id = $1`, id)
+	if err != nil {
+		r.logger.Error("Failed to delete unavailability %d: %v", id, err)
+		return fmt.Errorf("unavailabilityRepo - DeleteUnavailability: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("unavailabilityRepo - DeleteUnavailability: %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *unavailabilityRepo) GetStartedUnavailability(ctx context.Context, at time.Time, limit int) ([]entity.Unavailability, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT `+_unavailabilityColumns+` FROM user_unavailability
+		WHERE reassigned_at IS NULL AND starts_at <= $1 AND ends_at > $1
+		ORDER BY starts_at, id
+		LIMIT $2
+	`, at.UTC(), limit)
+	if err != nil {
+		r.logger.Error("Failed to query started unavailability: %v", err)
+		return nil, fmt.Errorf("unavailabilityRepo - GetStartedUnavailability - Query: %w", err)
+	}
+	return r.collect(rows, "GetStartedUnavailability")
+}
+
+// collect читает строки периодов и закрывает rows
+func (r *unavailabilityRepo) collect(rows pgx.Rows, method string) ([]entity.Unavailability, error) {
+	defer rows.Close()
+
+	periods := []entity.Unavailability{}
+	for rows.Next() {
+		period, err := scanUnavailability(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan unavailability: %v", err)
+			return nil, fmt.Errorf("unavailabilityRepo - %s - Scan: %w", method, err)
+		}
+		periods = append(periods, period)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unavailabilityRepo - %s - Rows: %w", method, err)
+	}
+	return periods, nil
+}
@@ -3,11 +3,14 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/PaulLocust/Avito-review/internal/entity"
 	"github.com/PaulLocust/Avito-review/internal/repository"
 	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -23,13 +26,14 @@ func NewUserRepository(db *pgxpool.Pool, l logger.Interface) repository.UserRepo
 func (r *userRepo) CreateOrUpdateUser(ctx context.Context, user *entity.User) error {
 	r.logger.Debug("Creating or updating user: %s", user.ID)
 
-	_, err := r.db.Exec(ctx, `
+	_, err := conn(ctx, r.db).Exec(ctx, `
 		INSERT INTO users (id, username, team_name, is_active) 
 		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (id) DO UPDATE SET 
 			username = EXCLUDED.username,
 			team_name = EXCLUDED.team_name,
-			is_active = EXCLUDED.is_active
+			is_active = EXCLUDED.is_active,
+			deleted_at = NULL
 	`, user.ID, user.Username, user.TeamName, user.IsActive)
 
 	if err != nil {
@@ -45,14 +49,18 @@ func (r *userRepo) GetUser(ctx context.Context, id string) (*entity.User, error)
 	r.logger.Debug("Getting user: %s", id)
 
 	var user entity.User
-	err := r.db.QueryRow(ctx, `
-		SELECT id, username, team_name, is_active 
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		SELECT id, username, team_name, is_active, max_open_reviews
 		FROM users 
-		WHERE id = $1
-	`, id).Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive)
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id).Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews)
 
-	if err != nil {
+	if errors.Is(err, pgx.ErrNoRows) {
 		r.logger.Warn("User not found: %s", id)
+		return nil, fmt.Errorf("userRepo - GetUser: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("Failed to query user %s: %v", id, err)
 		return nil, fmt.Errorf("userRepo - GetUser: %w", err)
 	}
 
@@ -63,11 +71,11 @@ func (r *userRepo) GetUser(ctx context.Context, id string) (*entity.User, error)
 func (r *userRepo) UpdateUser(ctx context.Context, user *entity.User) error {
 	r.logger.Debug("Updating user: %s", user.ID)
 
-	_, err := r.db.Exec(ctx, `
+	_, err := conn(ctx, r.db).Exec(ctx, `
 		UPDATE users 
-		SET username = $1, team_name = $2, is_active = $3 
-		WHERE id = $4
-	`, user.Username, user.TeamName, user.IsActive, user.ID)
+		SET username = $1, team_name = $2, is_active = $3, max_open_reviews = $5
+		WHERE id = $4 AND deleted_at IS NULL
+	`, user.Username, user.TeamName, user.IsActive, user.ID, user.MaxOpenReviews)
 
 	if err != nil {
 		r.logger.Error("Failed to update user %s: %v", user.ID, err)
@@ -78,16 +86,23 @@ func (r *userRepo) UpdateUser(ctx context.Context, user *entity.User) error {
 	return nil
 }
 
+// _availableNow отсекает пользователей, у которых сейчас идет период недоступности
+const _availableNow = `
+		AND NOT EXISTS (
+			SELECT 1 FROM user_unavailability ua
+			WHERE ua.user_id = users.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+		)`
+
 func (r *userRepo) GetActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string) ([]entity.User, error) {
 	r.logger.Debug("Getting active users by team: %s, exclude: %s", teamName, excludeUserID)
 
 	var users []entity.User
 
 	query := `
-		SELECT id, username, team_name, is_active 
+		SELECT id, username, team_name, is_active, max_open_reviews
 		FROM users 
-		WHERE team_name = $1 AND is_active = true
-	`
+		WHERE team_name = $1 AND is_active = true AND deleted_at IS NULL
+	` + _availableNow
 	args := []interface{}{teamName}
 
 	if excludeUserID != "" {
@@ -97,7 +112,7 @@ func (r *userRepo) GetActiveUsersByTeam(ctx context.Context, teamName string, ex
 
 	query += " ORDER BY id"
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("Failed to query active users by team: %v", err)
 		return nil, fmt.Errorf("userRepo - GetActiveUsersByTeam - Query: %w", err)
@@ -107,7 +122,7 @@ func (r *userRepo) GetActiveUsersByTeam(ctx context.Context, teamName string, ex
 	userCount := 0
 	for rows.Next() {
 		var user entity.User
-		err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive)
+		err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews)
 		if err != nil {
 			r.logger.Error("Failed to scan user: %v", err)
 			return nil, fmt.Errorf("userRepo - GetActiveUsersByTeam - Scan: %w", err)
@@ -115,7 +130,372 @@ func (r *userRepo) GetActiveUsersByTeam(ctx context.Context, teamName string, ex
 		users = append(users, user)
 		userCount++
 	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Failed to iterate active users: %v", err)
+		return nil, fmt.Errorf("userRepo - GetActiveUsersByTeam - Rows: %w", err)
+	}
 
 	r.logger.Debug("Found %d active users in team %s", userCount, teamName)
 	return users, nil
 }
+
+// _availableAt - то же, что _availableNow, для момента из параметра $2
+const _availableAt = `
+		AND NOT EXISTS (
+			SELECT 1 FROM user_unavailability ua
+			WHERE ua.user_id = users.id AND ua.starts_at <= $2 AND ua.ends_at > $2
+		)`
+
+func (r *userRepo) GetAvailableUsersByTeam(ctx context.Context, teamName string, at time.Time) ([]entity.User, error) {
+	r.logger.Debug("Getting users of team %s available at %s", teamName, at)
+
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT id, username, team_name, is_active, max_open_reviews
+		FROM users
+		WHERE team_name = $1 AND is_active = true AND deleted_at IS NULL`+_availableAt+`
+		ORDER BY id
+	`, teamName, at)
+	if err != nil {
+		r.logger.Error("Failed to query available users by team: %v", err)
+		return nil, fmt.Errorf("userRepo - GetAvailableUsersByTeam - Query: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entity.User
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews); err != nil {
+			r.logger.Error("Failed to scan user: %v", err)
+			return nil, fmt.Errorf("userRepo - GetAvailableUsersByTeam - Scan: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("userRepo - GetAvailableUsersByTeam - Rows: %w", err)
+	}
+
+	r.logger.Debug("Found %d available users in team %s", len(users), teamName)
+	return users, nil
+}
+
+func (r *userRepo) DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string, choose repository.ReplacementFunc) (*entity.TeamDeactivation, error) {
+	r.logger.Debug("Deactivating %d users in team %s", len(userIDs), teamName)
+
+	result := &entity.TeamDeactivation{
+		TeamName:         teamName,
+		DeactivatedUsers: []string{},
+		Reassignments:    []entity.ReviewerReassignment{},
+	}
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		r.logger.Error("Failed to begin transaction for team deactivation: %v", err)
+		return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Деактивируем пользователей
+	rows, err := tx.Query(ctx, `
+		UPDATE users SET is_active = false
+		WHERE team_name = $1 AND id = ANY($2) AND deleted_at IS NULL
+		RETURNING id
+	`, teamName, userIDs)
+	if err != nil {
+		r.logger.Error("Failed to deactivate users: %v", err)
+		return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Update users: %w", err)
+	}
+	result.DeactivatedUsers, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		r.logger.Error("Failed to scan deactivated users: %v", err)
+		return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Scan users: %w", err)
+	}
+
+	deactivated := make(map[string]bool, len(result.DeactivatedUsers))
+	for _, id := range result.DeactivatedUsers {
+		deactivated[id] = true
+	}
+
+	// Блокируем открытые PR, где деактивированные пользователи назначены ревьюверами,
+	// и получаем полный состав их ревьюверов одним запросом
+	rows, err = tx.Query(ctx, `
+		SELECT p.id, p.name, p.author_id, p.status, p.created_at, rv.user_id
+		FROM pull_requests p
+		JOIN pr_reviewers rv ON rv.pr_id = p.id
+		WHERE p.status = $1
+		  AND p.id IN (SELECT pr_id FROM pr_reviewers WHERE user_id = ANY($2))
+		ORDER BY p.id, rv.user_id
+		FOR UPDATE OF p
+	`, entity.StatusOpen, result.DeactivatedUsers)
+	if err != nil {
+		r.logger.Error("Failed to query affected PRs: %v", err)
+		return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Query PRs: %w", err)
+	}
+
+	var prs []*entity.PullRequest
+	for rows.Next() {
+		var pr entity.PullRequest
+		var reviewerID string
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &reviewerID); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan affected PR: %v", err)
+			return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Scan PR: %w", err)
+		}
+		if len(prs) == 0 || prs[len(prs)-1].ID != pr.ID {
+			prs = append(prs, &pr)
+		}
+		last := prs[len(prs)-1]
+		last.AssignedReviewers = append(last.AssignedReviewers, reviewerID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Rows PRs: %w", err)
+	}
+
+	// Активные и доступные участники команды после деактивации
+	rows, err = tx.Query(ctx, `
+		SELECT id, username, team_name, is_active, max_open_reviews
+		FROM users
+		WHERE team_name = $1 AND is_active = true AND deleted_at IS NULL`+_availableNow+`
+		ORDER BY id
+	`, teamName)
+	if err != nil {
+		r.logger.Error("Failed to query active team members: %v", err)
+		return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Query members: %w", err)
+	}
+	var members []entity.User
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan active team member: %v", err)
+			return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Scan members: %w", err)
+		}
+		members = append(members, user)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Rows members: %w", err)
+	}
+
+	// Планируем замены и собираем изменения в один batch
+	batch := &pgx.Batch{}
+	for _, pr := range prs {
+		for _, oldUserID := range append([]string(nil), pr.AssignedReviewers...) {
+			if !deactivated[oldUserID] {
+				continue
+			}
+
+			var candidates []entity.User
+			for _, member := range members {
+				if member.ID != pr.AuthorID && !containsString(pr.AssignedReviewers, member.ID) {
+					candidates = append(candidates, member)
+				}
+			}
+
+			newUserID, err := choose(ctx, pr, oldUserID, candidates)
+			if err != nil {
+				return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - choose: %w", err)
+			}
+
+			batch.Queue(`DELETE FROM pr_reviewers WHERE pr_id = $1 AND user_id = $2`, pr.ID, oldUserID)
+			if newUserID != "" {
+				batch.Queue(`INSERT INTO pr_reviewers (pr_id, user_id) VALUES ($1, $2)`, pr.ID, newUserID)
+			}
+			pr.AssignedReviewers = replaceString(pr.AssignedReviewers, oldUserID, newUserID)
+
+			result.Reassignments = append(result.Reassignments, entity.ReviewerReassignment{
+				PullRequestID: pr.ID,
+				OldUserID:     oldUserID,
+				NewUserID:     newUserID,
+			})
+		}
+	}
+
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			r.logger.Error("Failed to apply reviewer reassignments: %v", err)
+			return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - SendBatch: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("Failed to commit transaction for team deactivation: %v", err)
+		return nil, fmt.Errorf("userRepo - DeactivateTeamUsers - Commit: %w", err)
+	}
+
+	r.logger.Info("Deactivated %d users in team %s, %d reviewer slots changed",
+		len(result.DeactivatedUsers), teamName, len(result.Reassignments))
+	return result, nil
+}
+
+func (r *userRepo) ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, error) {
+	r.logger.Debug("Listing users: %+v", filter)
+
+	query := `
+		SELECT id, username, team_name, is_active, max_open_reviews
+		FROM users
+		WHERE deleted_at IS NULL
+	`
+	var args []interface{}
+	if filter.TeamName != "" {
+		args = append(args, filter.TeamName)
+		query += fmt.Sprintf(" AND team_name = $%d", len(args))
+	}
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		query += fmt.Sprintf(" AND is_active = $%d", len(args))
+	}
+	query += " ORDER BY id"
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query users: %v", err)
+		return nil, fmt.Errorf("userRepo - ListUsers - Query: %w", err)
+	}
+	defer rows.Close()
+
+	users := []entity.User{}
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews); err != nil {
+			r.logger.Error("Failed to scan user: %v", err)
+			return nil, fmt.Errorf("userRepo - ListUsers - Scan: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("userRepo - ListUsers - Rows: %w", err)
+	}
+
+	r.logger.Debug("Found %d users", len(users))
+	return users, nil
+}
+
+func (r *userRepo) DeleteUser(ctx context.Context, id string) (*entity.User, error) {
+	r.logger.Debug("Deleting user: %s", id)
+
+	var user entity.User
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		UPDATE users SET is_active = false, deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, username, team_name, is_active, deleted_at, max_open_reviews
+	`, id).Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.DeletedAt, &user.MaxOpenReviews)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		r.logger.Warn("User not found: %s", id)
+		return nil, fmt.Errorf("userRepo - DeleteUser: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("Failed to delete user %s: %v", id, err)
+		return nil, fmt.Errorf("userRepo - DeleteUser: %w", err)
+	}
+
+	r.logger.Debug("User deleted: %s", id)
+	return &user, nil
+}
+
+func (r *userRepo) ReassignUserReviews(ctx context.Context, userID, teamName string, choose repository.ReplacementFunc) ([]entity.ReviewerReassignment, error) {
+	r.logger.Debug("Reassigning open reviews of user %s within team %s", userID, teamName)
+
+	reassignments := []entity.ReviewerReassignment{}
+
+	// Блокируем открытые PR пользователя и получаем полный состав их ревьюверов одним запросом
+	rows, err := conn(ctx, r.db).Query(ctx, `
+		SELECT p.id, p.name, p.author_id, p.status, p.created_at, rv.user_id
+		FROM pull_requests p
+		JOIN pr_reviewers rv ON rv.pr_id = p.id
+		WHERE p.status = $1
+		  AND p.id IN (SELECT pr_id FROM pr_reviewers WHERE user_id = $2)
+		ORDER BY p.id, rv.user_id
+		FOR UPDATE OF p
+	`, entity.StatusOpen, userID)
+	if err != nil {
+		r.logger.Error("Failed to query user reviews: %v", err)
+		return nil, fmt.Errorf("userRepo - ReassignUserReviews - Query PRs: %w", err)
+	}
+
+	var prs []*entity.PullRequest
+	for rows.Next() {
+		var pr entity.PullRequest
+		var reviewerID string
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &reviewerID); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan user review: %v", err)
+			return nil, fmt.Errorf("userRepo - ReassignUserReviews - Scan PR: %w", err)
+		}
+		if len(prs) == 0 || prs[len(prs)-1].ID != pr.ID {
+			prs = append(prs, &pr)
+		}
+		last := prs[len(prs)-1]
+		last.AssignedReviewers = append(last.AssignedReviewers, reviewerID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("userRepo - ReassignUserReviews - Rows PRs: %w", err)
+	}
+	if len(prs) == 0 {
+		return reassignments, nil
+	}
+
+	members, err := r.GetActiveUsersByTeam(ctx, teamName, userID)
+	if err != nil {
+		return nil, fmt.Errorf("userRepo - ReassignUserReviews - %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, pr := range prs {
+		var candidates []entity.User
+		for _, member := range members {
+			if member.ID != pr.AuthorID && !containsString(pr.AssignedReviewers, member.ID) {
+				candidates = append(candidates, member)
+			}
+		}
+
+		newUserID, err := choose(ctx, pr, userID, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("userRepo - ReassignUserReviews - choose: %w", err)
+		}
+
+		batch.Queue(`DELETE FROM pr_reviewers WHERE pr_id = $1 AND user_id = $2`, pr.ID, userID)
+		if newUserID != "" {
+			batch.Queue(`INSERT INTO pr_reviewers (pr_id, user_id) VALUES ($1, $2)`, pr.ID, newUserID)
+		}
+
+		reassignments = append(reassignments, entity.ReviewerReassignment{
+			PullRequestID: pr.ID,
+			OldUserID:     userID,
+			NewUserID:     newUserID,
+		})
+	}
+
+	if err := conn(ctx, r.db).SendBatch(ctx, batch).Close(); err != nil {
+		r.logger.Error("Failed to apply reviewer reassignments: %v", err)
+		return nil, fmt.Errorf("userRepo - ReassignUserReviews - SendBatch: %w", err)
+	}
+
+	r.logger.Debug("Reassigned %d open reviews of user %s", len(reassignments), userID)
+	return reassignments, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceString заменяет old на new, а при пустом new удаляет old из списка
+func replaceString(values []string, old, new string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		switch {
+		case v != old:
+			result = append(result, v)
+		case new != "":
+			result = append(result, new)
+		}
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	_deactivateTeam    = _testPrefix + "deactivate"
+	_deactivateLeaving = 200
+	_deactivateStaying = 10
+	// _deletedMember - удаленный, но формально активный участник команды: не должен становиться ревьювером
+	_deletedMember = _testPrefix + "deleted"
+)
+
+// seedDeactivation создает команду из leaving уходящих и staying остающихся пользователей
+// и по открытому PR на каждого уходящего, где ревьюверами назначены два уходящих пользователя.
+// Возвращает id уходящих пользователей
+func seedDeactivation(tb testing.TB, pool *pgxpool.Pool, leaving, staying int) []string {
+	tb.Helper()
+	ctx := context.Background()
+
+	if _, err := pool.Exec(ctx, `INSERT INTO teams (name) VALUES ($1)`, _deactivateTeam); err != nil {
+		tb.Fatalf("insert team: %v", err)
+	}
+
+	var leavingIDs []string
+	var users [][]any
+	for i := 0; i < leaving; i++ {
+		id := fmt.Sprintf("%sleaving-%03d", _testPrefix, i)
+		leavingIDs = append(leavingIDs, id)
+		users = append(users, []any{id, id, _deactivateTeam, true})
+	}
+	for i := 0; i < staying; i++ {
+		id := fmt.Sprintf("%sstaying-%02d", _testPrefix, i)
+		users = append(users, []any{id, id, _deactivateTeam, true})
+	}
+	if _, err := pool.CopyFrom(ctx, pgx.Identifier{"users"},
+		[]string{"id", "username", "team_name", "is_active"}, pgx.CopyFromRows(users)); err != nil {
+		tb.Fatalf("copy users: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO users (id, username, team_name, is_active, deleted_at) VALUES ($1, $1, $2, true, now())
+	`, _deletedMember, _deactivateTeam); err != nil {
+		tb.Fatalf("insert deleted user: %v", err)
+	}
+
+	var prs, reviewers [][]any
+	for i := 0; i < leaving; i++ {
+		id := fmt.Sprintf("%sdeactivate-pr-%03d", _testPrefix, i)
+		author := fmt.Sprintf("%sstaying-%02d", _testPrefix, i%staying)
+		prs = append(prs, []any{id, id, author, string(entity.StatusOpen)})
+		reviewers = append(reviewers, []any{id, leavingIDs[i]}, []any{id, leavingIDs[(i+1)%leaving]})
+	}
+	if _, err := pool.CopyFrom(ctx, pgx.Identifier{"pull_requests"},
+		[]string{"id", "name", "author_id", "status"}, pgx.CopyFromRows(prs)); err != nil {
+		tb.Fatalf("copy pull requests: %v", err)
+	}
+	if _, err := pool.CopyFrom(ctx, pgx.Identifier{"pr_reviewers"},
+		[]string{"pr_id", "user_id"}, pgx.CopyFromRows(reviewers)); err != nil {
+		tb.Fatalf("copy reviewers: %v", err)
+	}
+
+	if _, err := pool.Exec(ctx, `ANALYZE users; ANALYZE pull_requests; ANALYZE pr_reviewers`); err != nil {
+		tb.Fatalf("analyze: %v", err)
+	}
+	return leavingIDs
+}
+
+// firstCandidate выбирает первого кандидата и запоминает всех предложенных кандидатов
+func firstCandidate(offered map[string]bool) repository.ReplacementFunc {
+	return func(_ context.Context, _ *entity.PullRequest, _ string, candidates []entity.User) (string, error) {
+		for _, candidate := range candidates {
+			offered[candidate.ID] = true
+		}
+		if len(candidates) == 0 {
+			return "", nil
+		}
+		return candidates[0].ID, nil
+	}
+}
+
+func TestDeactivateTeamUsers(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	repo := NewUserRepository(pool, logger.New("error"))
+
+	leavingIDs := seedDeactivation(t, pool, _deactivateLeaving, _deactivateStaying)
+	offered := map[string]bool{}
+
+	result, err := repo.DeactivateTeamUsers(ctx, _deactivateTeam, append(leavingIDs, _deletedMember), firstCandidate(offered))
+	if err != nil {
+		t.Fatalf("DeactivateTeamUsers: %v", err)
+	}
+
+	if len(result.DeactivatedUsers) != _deactivateLeaving {
+		t.Errorf("deactivated %d users, want %d: deleted users must not be reported", len(result.DeactivatedUsers), _deactivateLeaving)
+	}
+	if len(result.Reassignments) != 2*_deactivateLeaving {
+		t.Errorf("got %d reassignments, want %d", len(result.Reassignments), 2*_deactivateLeaving)
+	}
+	if offered[_deletedMember] {
+		t.Error("deleted user was offered as a replacement reviewer")
+	}
+
+	leaving := make(map[string]bool, len(leavingIDs))
+	for _, id := range leavingIDs {
+		leaving[id] = true
+	}
+	for _, reassignment := range result.Reassignments {
+		switch {
+		case reassignment.NewUserID == "":
+			t.Errorf("%s: %s removed without replacement", reassignment.PullRequestID, reassignment.OldUserID)
+		case leaving[reassignment.NewUserID] || reassignment.NewUserID == _deletedMember:
+			t.Errorf("%s: %s replaced by deactivated user %s",
+				reassignment.PullRequestID, reassignment.OldUserID, reassignment.NewUserID)
+		}
+	}
+}
+
+// BenchmarkDeactivateTeamUsers измеряет деактивацию ~200 пользователей с открытыми PR, цель - до 100ms на операцию.
+// Запуск: TEST_PG_URL=... go test -run '^$' -bench DeactivateTeamUsers ./internal/repository/postgresql
+func BenchmarkDeactivateTeamUsers(b *testing.B) {
+	pool := testPool(b)
+	ctx := context.Background()
+	repo := NewUserRepository(pool, logger.New("error"))
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		cleanupTestData(b, pool)
+		leavingIDs := seedDeactivation(b, pool, _deactivateLeaving, _deactivateStaying)
+		b.StartTimer()
+
+		if _, err := repo.DeactivateTeamUsers(ctx, _deactivateTeam, leavingIDs, firstCandidate(map[string]bool{})); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// webhook.go
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type webhookRepo struct {
+	db     *pgxpool.Pool
+	logger logger.Interface
+}
+
+func NewWebhookRepository(db *pgxpool.Pool, l logger.Interface) repository.WebhookRepository {
+	return &webhookRepo{db: db, logger: l}
+}
+
+const _webhookColumns = `id, url, event_types, secret, created_at`
+
+const _deliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts,
+			COALESCE(response_code, 0), last_error, created_at, updated_at, delivered_at`
+
+func scanWebhook(row pgx.Row) (entity.Webhook, error) {
+	var webhook entity.Webhook
+	var eventTypes []string
+	if err := row.Scan(&webhook.ID, &webhook.URL, &eventTypes, &webhook.Secret, &webhook.CreatedAt); err != nil {
+		return webhook, err
+	}
+	for _, eventType := range eventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, entity.WebhookEventType(eventType))
+	}
+	return webhook, nil
+}
+
+func (r *webhookRepo) CreateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	r.logger.Debug("Creating webhook for %s", webhook.URL)
+
+	eventTypes := make([]string, len(webhook.EventTypes))
+	for i, eventType := range webhook.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		INSERT INTO webhooks (url, event_types, secret)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`, webhook.URL, eventTypes, webhook.Secret).Scan(&webhook.ID, &webhook.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to insert webhook: %v", err)
+		return fmt.Errorf("webhookRepo - CreateWebhook - Insert: %w", err)
+	}
+
+	r.logger.Debug("Webhook created: %d", webhook.ID)
+	return nil
+}
+
+func (r *webhookRepo) GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	r.logger.Debug("Getting webhook: %d", id)
+
+	webhook, err := scanWebhook(conn(ctx, r.db).QueryRow(ctx,
+		`SELECT `+_webhookColumns+` FROM webhooks WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("webhookRepo - GetWebhook: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("Failed to get webhook: %v", err)
+		return nil, fmt.Errorf("webhookRepo - GetWebhook - Scan: %w", err)
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepo) ListWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	r.logger.Debug("Listing webhooks")
+	return r.queryWebhooks(ctx, `SELECT `+_webhookColumns+` FROM webhooks ORDER BY id`)
+}
+
+func (r *webhookRepo) GetWebhooksByEvent(ctx context.Context, eventType entity.WebhookEventType) ([]entity.Webhook, error) {
+	r.logger.Debug("Getting webhooks subscribed to %s", eventType)
+	return r.queryWebhooks(ctx, `SELECT `+_webhookColumns+` FROM webhooks WHERE $1 = ANY(event_types) ORDER BY id`, string(eventType))
+}
+
+func (r *webhookRepo) queryWebhooks(ctx context.Context, sql string, args ...any) ([]entity.Webhook, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, sql, args...)
+	if err != nil {
+		r.logger.Error("Failed to query webhooks: %v", err)
+		return nil, fmt.Errorf("webhookRepo - queryWebhooks - Query: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []entity.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan webhook: %v", err)
+			return nil, fmt.Errorf("webhookRepo - queryWebhooks - Scan: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhookRepo - queryWebhooks - Rows: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepo) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.logger.Debug("Creating delivery of %s to webhook %d", delivery.EventType, delivery.WebhookID)
+
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Payload, delivery.Status).
+		Scan(&delivery.ID, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		r.logger.Error("Failed to insert webhook delivery: %v", err)
+		return fmt.Errorf("webhookRepo - CreateDelivery - Insert: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepo) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.logger.Debug("Updating delivery %d: %s after %d attempts", delivery.ID, delivery.Status, delivery.Attempts)
+
+	err := conn(ctx, r.db).QueryRow(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_code = NULLIF($4, 0), last_error = $5,
+			delivered_at = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, delivery.ID, delivery.Status, delivery.Attempts, delivery.ResponseCode, delivery.LastError, delivery.DeliveredAt).
+		Scan(&delivery.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("webhookRepo - UpdateDelivery: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("Failed to update webhook delivery: %v", err)
+		return fmt.Errorf("webhookRepo - UpdateDelivery - Update: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepo) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]entity.WebhookDelivery, error) {
+	r.logger.Debug("Listing deliveries of webhook %d", webhookID)
+
+	return r.queryDeliveries(ctx, "ListDeliveries", `
+		SELECT `+_deliveryColumns+`
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`, webhookID, limit)
+}
+
+func (r *webhookRepo) GetPendingDeliveries(ctx context.Context, afterID int64, limit int) ([]entity.WebhookDelivery, error) {
+	r.logger.Debug("Getting pending webhook deliveries after %d", afterID)
+
+	return r.queryDeliveries(ctx, "GetPendingDeliveries", `
+		SELECT `+_deliveryColumns+`
+		FROM webhook_deliveries
+		WHERE status = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`, entity.DeliveryPending, afterID, limit)
+}
+
+func (r *webhookRepo) queryDeliveries(ctx context.Context, method, sql string, args ...any) ([]entity.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, sql, args...)
+	if err != nil {
+		r.logger.Error("Failed to query webhook deliveries: %v", err)
+		return nil, fmt.Errorf("webhookRepo - %s - Query: %w", method, err)
+	}
+	defer rows.Close()
+
+	deliveries := []entity.WebhookDelivery{}
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType,
+			&delivery.Payload, &delivery.Status, &delivery.Attempts, &delivery.ResponseCode,
+			&delivery.LastError, &delivery.CreatedAt, &delivery.UpdatedAt, &delivery.DeliveredAt)
+		if err != nil {
+			r.logger.Error("Failed to scan webhook delivery: %v", err)
+			return nil, fmt.Errorf("webhookRepo - %s - Scan: %w", method, err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("webhookRepo - %s - Rows: %w", method, err)
+	}
+	return deliveries, nil
+}
@@ -2,32 +2,166 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/PaulLocust/Avito-review/internal/entity"
 )
 
+// Ошибки, которые репозитории возвращают независимо от хранилища
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+)
+
+// Transactor - выполнение нескольких операций репозиториев в одной транзакции.
+// Репозитории, вызванные с ctx из fn, работают внутри этой транзакции.
+// Если fn возвращает ошибку, транзакция откатывается
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // TeamRepository - интерфейс для работы с командами
 type TeamRepository interface {
 	CreateTeam(ctx context.Context, team *entity.Team) error
 	GetTeam(ctx context.Context, name string) (*entity.Team, error)
 	TeamExists(ctx context.Context, name string) (bool, error)
+	// GetTeamSettings возвращает nil, nil если настройки для команды не заданы
+	GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error)
+	UpsertTeamSettings(ctx context.Context, settings *entity.TeamSettings) error
+	// ListTeams возвращает неудаленные команды, отсортированные по имени
+	ListTeams(ctx context.Context) ([]entity.TeamSummary, error)
+	// RenameTeam переименовывает команду вместе с ее пользователями и настройками.
+	// Возвращает ErrNotFound, если команды нет, и ErrAlreadyExists, если новое имя занято (в том числе удаленной командой)
+	RenameTeam(ctx context.Context, oldName, newName string) error
+	// DeleteTeam помечает команду удаленной, возвращает ErrNotFound, если ее нет
+	DeleteTeam(ctx context.Context, name string) error
 }
 
+// ReplacementFunc выбирает замену выбывшему ревьюверу PR из списка кандидатов.
+// Пустая строка означает, что ревьювер снимается без замены.
+// Вызывается внутри транзакции репозитория и не должна обращаться к репозиториям
+type ReplacementFunc func(ctx context.Context, pr *entity.PullRequest, oldUserID string, candidates []entity.User) (string, error)
+
 // UserRepository - интерфейс для работы с пользователями
 type UserRepository interface {
 	CreateOrUpdateUser(ctx context.Context, user *entity.User) error
 	GetUser(ctx context.Context, id string) (*entity.User, error)
 	UpdateUser(ctx context.Context, user *entity.User) error
+	// GetActiveUsersByTeam возвращает активных участников команды, кроме недоступных в текущий момент
 	GetActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string) ([]entity.User, error)
+	// GetAvailableUsersByTeam возвращает активных участников команды, кроме недоступных в момент at
+	GetAvailableUsersByTeam(ctx context.Context, teamName string, at time.Time) ([]entity.User, error)
+	// DeactivateTeamUsers в одной транзакции деактивирует пользователей команды
+	// и переназначает их слоты в открытых PR с помощью choose
+	DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string, choose ReplacementFunc) (*entity.TeamDeactivation, error)
+	// ListUsers возвращает неудаленных пользователей, отсортированных по id
+	ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, error)
+	// DeleteUser помечает пользователя удаленным и неактивным, возвращает ErrNotFound, если его нет
+	DeleteUser(ctx context.Context, id string) (*entity.User, error)
+	// ReassignUserReviews заменяет пользователя в открытых PR активными участниками teamName с помощью choose.
+	// Вызывается в транзакции
+	ReassignUserReviews(ctx context.Context, userID, teamName string, choose ReplacementFunc) ([]entity.ReviewerReassignment, error)
 }
 
 // PRRepository - интерфейс для работы с pull requests
 type PRRepository interface {
 	CreatePR(ctx context.Context, pr *entity.PullRequest) error
 	GetPR(ctx context.Context, id string) (*entity.PullRequest, error)
+	// GetPRForUpdate блокирует PR до конца транзакции (SELECT ... FOR UPDATE)
+	GetPRForUpdate(ctx context.Context, id string) (*entity.PullRequest, error)
 	UpdatePR(ctx context.Context, pr *entity.PullRequest) error
 	GetPRsByReviewer(ctx context.Context, userID string) ([]entity.PullRequest, error)
+	// ListPRs возвращает не более filter.Limit PR, отсортированных по (created_at, id) в порядке filter.Order
+	ListPRs(ctx context.Context, filter entity.PRFilter) ([]entity.PullRequest, error)
 	AddReviewer(ctx context.Context, prID, userID string) error
 	RemoveReviewer(ctx context.Context, prID, userID string) error
 	ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error
+	// SetReviewState возвращает ErrNotFound, если пользователь не назначен ревьювером PR
+	SetReviewState(ctx context.Context, prID, userID string, state entity.ReviewState) error
+	CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
+	// CountOpenReviewsForUpdate блокирует строки пользователей до конца транзакции (SELECT ... FOR UPDATE)
+	// и считает их открытые ревью, чтобы параллельные назначения не превысили лимит
+	CountOpenReviewsForUpdate(ctx context.Context, userIDs []string) (map[string]int, error)
+	// GetUnfinishedPRIDsByTeam возвращает id PR в статусах OPEN и DRAFT, авторы которых состоят в команде
+	GetUnfinishedPRIDsByTeam(ctx context.Context, teamName string) ([]string, error)
+}
+
+// EventRepository - журнал изменений PR, записи только добавляются
+type EventRepository interface {
+	AddEvents(ctx context.Context, events []entity.PREvent) error
+	GetPREvents(ctx context.Context, prID string) ([]entity.PREvent, error)
+}
+
+// WebhookRepository - подписки на события и журнал их доставки
+type WebhookRepository interface {
+	// CreateWebhook заполняет ID и CreatedAt
+	CreateWebhook(ctx context.Context, webhook *entity.Webhook) error
+	// GetWebhook возвращает ErrNotFound, если подписки нет
+	GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]entity.Webhook, error)
+	GetWebhooksByEvent(ctx context.Context, eventType entity.WebhookEventType) ([]entity.Webhook, error)
+	// CreateDelivery заполняет ID, CreatedAt и UpdatedAt
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	// ListDeliveries возвращает не более limit последних доставок вебхука, новые первыми
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]entity.WebhookDelivery, error)
+	// GetPendingDeliveries возвращает не более limit доставок в статусе pending с id больше afterID, по возрастанию id
+	GetPendingDeliveries(ctx context.Context, afterID int64, limit int) ([]entity.WebhookDelivery, error)
+}
+
+// OutboxRepository - события, ожидающие публикации. AddOutboxMessage вызывается
+// в транзакции изменения, поэтому событие записывается тогда и только тогда, когда изменение зафиксировано
+type OutboxRepository interface {
+	// AddOutboxMessage заполняет ID и CreatedAt
+	AddOutboxMessage(ctx context.Context, msg *entity.OutboxMessage) error
+	// GetPendingOutbox возвращает не более limit неотправленных событий в порядке записи
+	GetPendingOutbox(ctx context.Context, limit int) ([]entity.OutboxMessage, error)
+	MarkOutboxSent(ctx context.Context, id int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, lastError string) error
+}
+
+// APIKeyRepository - выданные API-ключи
+type APIKeyRepository interface {
+	// CreateAPIKey заполняет ID и CreatedAt
+	CreateAPIKey(ctx context.Context, key *entity.APIKey) error
+	// GetAPIKeyByHash возвращает ErrNotFound, если ключа с таким хэшем нет
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	// RevokeAPIKey возвращает ErrNotFound, если ключа нет; повторный отзыв не меняет время отзыва
+	RevokeAPIKey(ctx context.Context, id int64) (*entity.APIKey, error)
+}
+
+// UnavailabilityRepository - периоды недоступности пользователей
+type UnavailabilityRepository interface {
+	// CreateUnavailability заполняет ID и CreatedAt
+	CreateUnavailability(ctx context.Context, period *entity.Unavailability) error
+	// GetUnavailability возвращает ErrNotFound, если периода нет
+	GetUnavailability(ctx context.Context, id int64) (*entity.Unavailability, error)
+	// ListUnavailability возвращает периоды пользователя, отсортированные по началу
+	ListUnavailability(ctx context.Context, userID string) ([]entity.Unavailability, error)
+	// UpdateUnavailability меняет границы, причину и отметку о переназначении, возвращает ErrNotFound, если периода нет
+	UpdateUnavailability(ctx context.Context, period *entity.Unavailability) error
+	// DeleteUnavailability возвращает ErrNotFound, если периода нет
+	DeleteUnavailability(ctx context.Context, id int64) error
+	// GetStartedUnavailability возвращает не более limit периодов, идущих в момент at,
+	// открытые ревью по которым еще не переназначены
+	GetStartedUnavailability(ctx context.Context, at time.Time, limit int) ([]entity.Unavailability, error)
+}
+
+// IdempotencyRepository - ответы на запросы с ключом идемпотентности
+type IdempotencyRepository interface {
+	// ReserveIdempotencyKey атомарно создает незавершенную запись, если для scope и key нет действующей.
+	// Иначе возвращает существующую запись и false
+	ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, bool, error)
+	// CompleteIdempotencyKey сохраняет ответ зарезервированного запроса и продлевает запись до record.ExpiresAt
+	CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error
+	DeleteIdempotencyKey(ctx context.Context, scope, key string) error
+	// DeleteExpiredIdempotencyKeys удаляет просроченные записи и возвращает их количество
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// StatsRepository - интерфейс для получения статистики назначений
+type StatsRepository interface {
+	GetAssignmentStats(ctx context.Context, filter entity.StatsFilter) (*entity.AssignmentStats, error)
 }
\ No newline at end of file
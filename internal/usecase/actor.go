@@ -0,0 +1,25 @@
+// actor.go
+package usecase
+
+import "context"
+
+// systemActor - инициатор изменений, если он не передан в контексте
+const systemActor = "system"
+
+type actorKey struct{}
+
+// WithActor сохраняет в контексте инициатора операции для журнала изменений PR
+func WithActor(ctx context.Context, actor string) context.Context {
+	if actor == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// actorFrom возвращает инициатора операции из контекста или systemActor
+func actorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok {
+		return actor
+	}
+	return systemActor
+}
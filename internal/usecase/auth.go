@@ -0,0 +1,203 @@
+// auth.go
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/jwt"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+// AuthUseCase интерфейс для выдачи API-ключей и проверки ключей запросов
+type AuthUseCase interface {
+	// IssueAPIKey выдает ключ и возвращает его вместе с открытым значением, которое больше нигде не хранится
+	IssueAPIKey(ctx context.Context, name string, role entity.Role) (*entity.APIKey, string, error)
+	ListAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) (*entity.APIKey, error)
+	Authenticate(ctx context.Context, rawKey string) (*entity.APIKey, error)
+	// AuthenticateToken проверяет bearer-токен и сопоставляет его claims пользователю и роли
+	AuthenticateToken(ctx context.Context, token string) (*entity.Principal, error)
+}
+
+// TokenVerifier проверяет подпись и срок действия bearer-токена
+type TokenVerifier interface {
+	Verify(token string) (jwt.Claims, error)
+}
+
+// AuthOptions - параметры аутентификации
+type AuthOptions struct {
+	// BootstrapKey, если задан, принимается как ключ администратора, чтобы выдать первые ключи в пустой базе
+	BootstrapKey string
+	// Verifier проверяет bearer-токены; nil - токены не принимаются
+	Verifier TokenVerifier
+	// UserClaim - claim с идентификатором пользователя, RoleClaim - claim с ролью
+	UserClaim string
+	RoleClaim string
+	// DefaultRole - роль токена без RoleClaim
+	DefaultRole entity.Role
+}
+
+const (
+	apiKeyPrefix     = "ark_"
+	apiKeyBytes      = 24
+	apiKeyShownSize  = len(apiKeyPrefix) + 8
+	bootstrapKeyName = "bootstrap"
+)
+
+type authUseCase struct {
+	apiKeyRepo    repository.APIKeyRepository
+	bootstrapHash string
+	opts          AuthOptions
+	logger        logger.Interface
+}
+
+func NewAuthUseCase(apiKeyRepo repository.APIKeyRepository, opts AuthOptions, l logger.Interface) AuthUseCase {
+	uc := &authUseCase{
+		apiKeyRepo: apiKeyRepo,
+		opts:       opts,
+		logger:     l,
+	}
+	if opts.BootstrapKey != "" {
+		uc.bootstrapHash = hashAPIKey(opts.BootstrapKey)
+	}
+	return uc
+}
+
+func (uc *authUseCase) IssueAPIKey(ctx context.Context, name string, role entity.Role) (*entity.APIKey, string, error) {
+	uc.logger.Info("Issuing API key %s with role %s", name, role)
+
+	if name == "" {
+		return nil, "", entity.NewAppError(entity.ErrorInvalidInput, "name must not be empty")
+	}
+	if !role.IsKnown() {
+		return nil, "", entity.NewAppError(entity.ErrorInvalidInput, fmt.Sprintf("unknown role %s", role))
+	}
+
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", fmt.Errorf("authUseCase - IssueAPIKey - rand.Read: %w", err)
+	}
+	rawKey := apiKeyPrefix + hex.EncodeToString(buf)
+
+	key := &entity.APIKey{
+		Name:    name,
+		Role:    role,
+		Prefix:  rawKey[:apiKeyShownSize],
+		KeyHash: hashAPIKey(rawKey),
+	}
+	if err := uc.apiKeyRepo.CreateAPIKey(ctx, key); err != nil {
+		uc.logger.Error("Failed to create API key: %v", err)
+		return nil, "", fmt.Errorf("authUseCase - IssueAPIKey - CreateAPIKey: %w", err)
+	}
+
+	uc.logger.Info("API key issued: %d", key.ID)
+	return key, rawKey, nil
+}
+
+func (uc *authUseCase) ListAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	uc.logger.Debug("Listing API keys")
+
+	keys, err := uc.apiKeyRepo.ListAPIKeys(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to list API keys: %v", err)
+		return nil, fmt.Errorf("authUseCase - ListAPIKeys - ListAPIKeys: %w", err)
+	}
+	return keys, nil
+}
+
+func (uc *authUseCase) RevokeAPIKey(ctx context.Context, id int64) (*entity.APIKey, error) {
+	uc.logger.Info("Revoking API key: %d", id)
+
+	key, err := uc.apiKeyRepo.RevokeAPIKey(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("API key not found: %d", id)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "API key not found")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to revoke API key: %v", err)
+		return nil, fmt.Errorf("authUseCase - RevokeAPIKey - RevokeAPIKey: %w", err)
+	}
+	return key, nil
+}
+
+// Authenticate возвращает действующий ключ по его открытому значению
+func (uc *authUseCase) Authenticate(ctx context.Context, rawKey string) (*entity.APIKey, error) {
+	keyHash := hashAPIKey(rawKey)
+
+	if uc.bootstrapHash != "" && subtle.ConstantTimeCompare([]byte(keyHash), []byte(uc.bootstrapHash)) == 1 {
+		return &entity.APIKey{Name: bootstrapKeyName, Role: entity.RoleAdmin}, nil
+	}
+
+	key, err := uc.apiKeyRepo.GetAPIKeyByHash(ctx, keyHash)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("Unknown API key")
+		return nil, entity.NewAppError(entity.ErrorUnauthorized, "invalid API key")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to get API key: %v", err)
+		return nil, fmt.Errorf("authUseCase - Authenticate - GetAPIKeyByHash: %w", err)
+	}
+	if key.IsRevoked() {
+		uc.logger.Warn("Revoked API key used: %d", key.ID)
+		return nil, entity.NewAppError(entity.ErrorUnauthorized, "API key is revoked")
+	}
+	return key, nil
+}
+
+func (uc *authUseCase) AuthenticateToken(ctx context.Context, token string) (*entity.Principal, error) {
+	if uc.opts.Verifier == nil {
+		return nil, entity.NewAppError(entity.ErrorUnauthorized, "bearer tokens are not accepted")
+	}
+
+	claims, err := uc.opts.Verifier.Verify(token)
+	if errors.Is(err, jwt.ErrExpired) {
+		return nil, entity.NewAppError(entity.ErrorUnauthorized, "token is expired")
+	}
+	if err != nil {
+		uc.logger.Warn("Invalid bearer token: %v", err)
+		return nil, entity.NewAppError(entity.ErrorUnauthorized, "invalid token")
+	}
+
+	userID := claims.String(uc.opts.UserClaim)
+	if userID == "" {
+		return nil, entity.NewAppError(entity.ErrorUnauthorized, fmt.Sprintf("token has no %s claim", uc.opts.UserClaim))
+	}
+
+	role := entity.Role(claims.String(uc.opts.RoleClaim))
+	if role == "" {
+		role = uc.opts.DefaultRole
+	}
+	if !role.IsKnown() {
+		uc.logger.Warn("Token of user %s has unknown role %s", userID, role)
+		return nil, entity.NewAppError(entity.ErrorUnauthorized, fmt.Sprintf("unknown role %s", role))
+	}
+
+	return &entity.Principal{Name: userID, Role: role, UserID: userID}, nil
+}
+
+// hashAPIKey - ключи случайные и длинные, поэтому достаточно SHA-256 без соли
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+type principalKey struct{}
+
+// WithPrincipal сохраняет в контексте аутентифицированного инициатора запроса
+func WithPrincipal(ctx context.Context, principal *entity.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom возвращает инициатора запроса из контекста
+func PrincipalFrom(ctx context.Context) (*entity.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*entity.Principal)
+	return principal, ok
+}
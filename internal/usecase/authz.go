@@ -0,0 +1,70 @@
+// authz.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+)
+
+// scopedPrincipal возвращает инициатора, действия которого ограничены его командой и его PR:
+// пользователя bearer-токена без роли admin. Для остальных возвращает nil
+func scopedPrincipal(ctx context.Context) *entity.Principal {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.UserID == "" || principal.Role == entity.RoleAdmin {
+		return nil
+	}
+	return principal
+}
+
+// authorizeTeam разрешает изменять команду teamName только ее участникам
+func authorizeTeam(ctx context.Context, userRepo repository.UserRepository, teamName string) error {
+	principal := scopedPrincipal(ctx)
+	if principal == nil {
+		return nil
+	}
+
+	user, err := userRepo.GetUser(ctx, principal.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return entity.NewAppError(entity.ErrorForbidden, fmt.Sprintf("user %s is not registered", principal.UserID))
+	}
+	if err != nil {
+		return fmt.Errorf("authorizeTeam - GetUser: %w", err)
+	}
+
+	if user.TeamName != teamName {
+		return entity.NewAppError(entity.ErrorForbidden, fmt.Sprintf("user %s can modify only team %s", principal.UserID, user.TeamName))
+	}
+	return nil
+}
+
+// authorizeMerge разрешает мердж только автору PR
+func authorizeMerge(ctx context.Context, pr *entity.PullRequest) error {
+	principal := scopedPrincipal(ctx)
+	if principal == nil || principal.UserID == pr.AuthorID {
+		return nil
+	}
+	return entity.NewAppError(entity.ErrorForbidden, "only the PR author or an admin can merge")
+}
+
+// authorizeReview разрешает фиксировать решение только самому ревьюверу.
+// От имени другого ревьювера действуют администраторы и API-ключи ботов
+func authorizeReview(ctx context.Context, reviewerID string) error {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.Role == entity.RoleAdmin {
+		return nil
+	}
+	if principal.UserID == "" {
+		if principal.Role == entity.RoleBot {
+			return nil
+		}
+		return entity.NewAppError(entity.ErrorForbidden, "only admins and bot keys can review on behalf of a reviewer")
+	}
+	if principal.UserID != reviewerID {
+		return entity.NewAppError(entity.ErrorForbidden, fmt.Sprintf("user %s can review only as themselves", principal.UserID))
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository/memory"
+	"github.com/PaulLocust/Avito-review/pkg/jwt"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+func TestAuthorizeReview(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *entity.Principal
+		reviewer  string
+		wantErr   bool
+	}{
+		{name: "no principal", reviewer: "u2"},
+		{name: "admin key", principal: &entity.Principal{Name: "root", Role: entity.RoleAdmin}, reviewer: "u2"},
+		{name: "bot key", principal: &entity.Principal{Name: "ci-bot", Role: entity.RoleBot}, reviewer: "u2"},
+		{name: "team-lead key", principal: &entity.Principal{Name: "lead", Role: entity.RoleTeamLead}, reviewer: "u2", wantErr: true},
+		{name: "token as self", principal: &entity.Principal{Name: "u2", Role: entity.RoleBot, UserID: "u2"}, reviewer: "u2"},
+		{name: "token as another reviewer", principal: &entity.Principal{Name: "u1", Role: entity.RoleBot, UserID: "u1"}, reviewer: "u2", wantErr: true},
+		{name: "admin token as another reviewer", principal: &entity.Principal{Name: "u1", Role: entity.RoleAdmin, UserID: "u1"}, reviewer: "u2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = WithPrincipal(ctx, tt.principal)
+			}
+
+			err := authorizeReview(ctx, tt.reviewer)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var appErr entity.AppError
+			if !errors.As(err, &appErr) || appErr.Code != entity.ErrorForbidden {
+				t.Fatalf("want FORBIDDEN, got %v", err)
+			}
+		})
+	}
+}
+
+// signToken выпускает HS256-токен с claims
+func signToken(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestTokenTeamScope(t *testing.T) {
+	const secret = "jwt-secret"
+	ctx := context.Background()
+	l := logger.New("error")
+
+	verifier, err := jwt.New(jwt.HS256Secret([]byte(secret)))
+	if err != nil {
+		t.Fatalf("jwt.New: %v", err)
+	}
+	auth := NewAuthUseCase(memory.NewAPIKeyRepository(memory.NewStorage(), l), AuthOptions{
+		Verifier:    verifier,
+		UserClaim:   "sub",
+		RoleClaim:   "role",
+		DefaultRole: entity.RoleBot,
+	}, l)
+
+	uc := newTestUseCases(t, StrategyRandom)
+	createTeam(t, uc, "backend", "a", "b")
+	createTeam(t, uc, "frontend", "f")
+	if _, err := uc.PR.CreatePR(ctx, "pr-1", "scoped", "a", false); err != nil {
+		t.Fatalf("CreatePR: %v", err)
+	}
+
+	exp := float64(time.Now().Add(time.Hour).Unix())
+	updateBackend := func(ctx context.Context) error {
+		_, err := uc.Team.SetTeamSettings(ctx, entity.TeamSettingsUpdate{TeamName: "backend", ReviewerCount: 1})
+		return err
+	}
+	mergePR := func(ctx context.Context) error {
+		_, err := uc.PR.MergePR(ctx, "pr-1")
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		claims   map[string]any
+		action   func(ctx context.Context) error
+		wantCode entity.ErrorCode
+	}{
+		{name: "member updates own team", claims: map[string]any{"sub": "b", "role": "team-lead", "exp": exp}, action: updateBackend},
+		{name: "member of another team", claims: map[string]any{"sub": "f", "role": "team-lead", "exp": exp}, action: updateBackend, wantCode: entity.ErrorForbidden},
+		{name: "unregistered user", claims: map[string]any{"sub": "ghost", "role": "team-lead", "exp": exp}, action: updateBackend, wantCode: entity.ErrorForbidden},
+		{name: "admin token of another team", claims: map[string]any{"sub": "f", "role": "admin", "exp": exp}, action: updateBackend},
+		{name: "teammate merges PR", claims: map[string]any{"sub": "b", "exp": exp}, action: mergePR, wantCode: entity.ErrorForbidden},
+		{name: "author merges PR", claims: map[string]any{"sub": "a", "exp": exp}, action: mergePR},
+		{name: "unknown role", claims: map[string]any{"sub": "b", "role": "root", "exp": exp}, action: updateBackend, wantCode: entity.ErrorUnauthorized},
+		{name: "no user claim", claims: map[string]any{"role": "admin", "exp": exp}, action: updateBackend, wantCode: entity.ErrorUnauthorized},
+		{name: "expired", claims: map[string]any{"sub": "b", "exp": float64(time.Now().Add(-time.Hour).Unix())}, action: updateBackend, wantCode: entity.ErrorUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := auth.AuthenticateToken(ctx, signToken(t, secret, tt.claims))
+			if err == nil {
+				err = tt.action(WithPrincipal(ctx, principal))
+			}
+
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var appErr entity.AppError
+			if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+				t.Fatalf("want %s, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestTokenTeamMove(t *testing.T) {
+	const secret = "jwt-secret"
+	ctx := context.Background()
+	l := logger.New("error")
+
+	verifier, err := jwt.New(jwt.HS256Secret([]byte(secret)))
+	if err != nil {
+		t.Fatalf("jwt.New: %v", err)
+	}
+	auth := NewAuthUseCase(memory.NewAPIKeyRepository(memory.NewStorage(), l), AuthOptions{
+		Verifier:    verifier,
+		UserClaim:   "sub",
+		DefaultRole: entity.RoleBot,
+	}, l)
+
+	uc := newTestUseCases(t, StrategyRandom)
+	createTeam(t, uc, "backend", "a", "b", "c")
+	createTeam(t, uc, "frontend", "f")
+
+	exp := float64(time.Now().Add(time.Hour).Unix())
+	backend, frontend, missing := "backend", "frontend", "missing"
+
+	// Шаги выполняются по порядку и зависят от предыдущих переводов
+	steps := []struct {
+		name     string
+		sub      string
+		userID   string
+		teamName *string
+		wantCode entity.ErrorCode
+		wantTeam string
+	}{
+		{name: "lead of target team moves user in", sub: "f", userID: "c", teamName: &frontend, wantCode: entity.ErrorForbidden},
+		{name: "lead of source team moves user out", sub: "b", userID: "c", teamName: &frontend, wantTeam: "frontend"},
+		{name: "former lead moves user back", sub: "b", userID: "c", teamName: &backend, wantCode: entity.ErrorForbidden},
+		{name: "move to missing team", sub: "b", userID: "a", teamName: &missing, wantCode: entity.ErrorNotFound},
+	}
+
+	for _, step := range steps {
+		principal, err := auth.AuthenticateToken(ctx, signToken(t, secret, map[string]any{"sub": step.sub, "exp": exp}))
+		if err != nil {
+			t.Fatalf("%s: AuthenticateToken: %v", step.name, err)
+		}
+		change, err := uc.User.UpdateUser(WithPrincipal(ctx, principal), step.userID, nil, step.teamName, nil)
+
+		if step.wantCode != "" {
+			var appErr entity.AppError
+			if !errors.As(err, &appErr) || appErr.Code != step.wantCode {
+				t.Fatalf("%s: want %s, got %v", step.name, step.wantCode, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		if change.User.TeamName != step.wantTeam {
+			t.Fatalf("%s: user in team %s, want %s", step.name, change.User.TeamName, step.wantTeam)
+		}
+	}
+}
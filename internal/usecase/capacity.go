@@ -0,0 +1,59 @@
+// capacity.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+)
+
+// reviewCapacity отслеживает открытые ревью кандидатов относительно их лимита max_open_reviews
+type reviewCapacity struct {
+	settings *entity.TeamSettings
+	open     map[string]int
+}
+
+// newReviewCapacity считает открытые ревью кандидатов с лимитом, блокируя их строки до конца транзакции.
+// Для замен внутри DeactivateTeamUsers и ReassignUserReviews вызывается до блокировок репозиториев:
+// сами замены выбираются под блокировкой хранилища
+func newReviewCapacity(
+	ctx context.Context,
+	prRepo repository.PRRepository,
+	settings *entity.TeamSettings,
+	candidates []entity.User,
+) (*reviewCapacity, error) {
+	var limited []string
+	for _, user := range candidates {
+		if settings.ReviewLimit(user) > 0 {
+			limited = append(limited, user.ID)
+		}
+	}
+
+	open := map[string]int{}
+	if len(limited) > 0 {
+		var err error
+		if open, err = prRepo.CountOpenReviewsForUpdate(ctx, limited); err != nil {
+			return nil, fmt.Errorf("CountOpenReviewsForUpdate: %w", err)
+		}
+	}
+	return &reviewCapacity{settings: settings, open: open}, nil
+}
+
+// free возвращает кандидатов, не достигших лимита, и количество отброшенных
+func (c *reviewCapacity) free(candidates []entity.User) ([]entity.User, int) {
+	result := make([]entity.User, 0, len(candidates))
+	for _, user := range candidates {
+		if limit := c.settings.ReviewLimit(user); limit > 0 && c.open[user.ID] >= limit {
+			continue
+		}
+		result = append(result, user)
+	}
+	return result, len(candidates) - len(result)
+}
+
+// take учитывает назначение, сделанное в рамках текущей операции
+func (c *reviewCapacity) take(userID string) {
+	c.open[userID]++
+}
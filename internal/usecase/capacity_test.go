@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+func TestBulkDeactivationRespectsPersonalLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		deactivate func(ctx context.Context, uc *UseCases) error
+	}{
+		{
+			name: "deactivate users",
+			deactivate: func(ctx context.Context, uc *UseCases) error {
+				_, err := uc.Team.DeactivateUsers(ctx, "backend", []string{"b"})
+				return err
+			},
+		},
+		{
+			name: "sync team",
+			deactivate: func(ctx context.Context, uc *UseCases) error {
+				_, err := uc.Team.SyncTeam(ctx, entity.Team{Name: "backend", Members: []entity.TeamMember{
+					{UserID: "a", Username: "a", IsActive: true},
+					{UserID: "c", Username: "c", IsActive: true},
+					{UserID: "d", Username: "d", IsActive: true},
+				}}, false)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uc := newTestUseCases(t, "random")
+			createTeam(t, uc, "backend", "a", "b", "c", "d")
+			// Лимит команды не задан, у c личный лимит
+			setTeamSettings(t, uc, entity.TeamSettings{TeamName: "backend", ReviewerCount: 3})
+
+			// pr-1: ревьюверы b и d, c неактивен
+			if _, err := uc.User.SetUserActive(ctx, "c", false); err != nil {
+				t.Fatalf("SetUserActive: %v", err)
+			}
+			if _, err := uc.PR.CreatePR(ctx, "pr-1", "first", "a", false); err != nil {
+				t.Fatalf("CreatePR: %v", err)
+			}
+			if _, err := uc.User.SetUserActive(ctx, "c", true); err != nil {
+				t.Fatalf("SetUserActive: %v", err)
+			}
+			// pr-2: ревьюверы b, c и d
+			if _, err := uc.PR.CreatePR(ctx, "pr-2", "second", "a", false); err != nil {
+				t.Fatalf("CreatePR: %v", err)
+			}
+			setReviewLimit(t, uc, "c", 1)
+
+			if err := tt.deactivate(ctx, uc); err != nil {
+				t.Fatalf("deactivate: %v", err)
+			}
+
+			if got := openReviews(t, uc, "c"); got != 1 {
+				t.Errorf("c has %d open reviews, limit is 1", got)
+			}
+			pr, err := uc.PR.GetPR(ctx, "pr-1")
+			if err != nil {
+				t.Fatalf("GetPR: %v", err)
+			}
+			if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "d" {
+				t.Errorf("pr-1 reviewers = %v, want [d]", pr.AssignedReviewers)
+			}
+		})
+	}
+}
+
+func TestReviewCapacityFree(t *testing.T) {
+	tests := []struct {
+		name        string
+		teamLimit   int
+		personal    map[string]int
+		open        map[string]int
+		take        []string
+		wantFree    []string
+		wantDropped int
+	}{
+		{
+			name:     "no limits",
+			open:     map[string]int{"a": 10, "b": 3},
+			wantFree: []string{"a", "b", "c"},
+		},
+		{
+			name:        "team limit",
+			teamLimit:   2,
+			open:        map[string]int{"a": 2, "b": 1},
+			wantFree:    []string{"b", "c"},
+			wantDropped: 1,
+		},
+		{
+			name:        "personal limit overrides team limit",
+			teamLimit:   2,
+			personal:    map[string]int{"a": 3, "b": 1},
+			open:        map[string]int{"a": 2, "b": 1},
+			wantFree:    []string{"a", "c"},
+			wantDropped: 1,
+		},
+		{
+			name:        "personal limit without team limit",
+			personal:    map[string]int{"c": 1},
+			open:        map[string]int{"a": 5, "c": 1},
+			wantFree:    []string{"a", "b"},
+			wantDropped: 1,
+		},
+		{
+			name:        "assignments in the same operation count",
+			teamLimit:   1,
+			take:        []string{"a", "c"},
+			wantFree:    []string{"b"},
+			wantDropped: 2,
+		},
+		{
+			name:        "everyone at capacity",
+			teamLimit:   1,
+			open:        map[string]int{"a": 1, "b": 1, "c": 4},
+			wantFree:    []string{},
+			wantDropped: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var candidates []entity.User
+			for _, id := range []string{"a", "b", "c"} {
+				candidates = append(candidates, entity.User{ID: id, IsActive: true, MaxOpenReviews: tt.personal[id]})
+			}
+			open := map[string]int{}
+			for id, count := range tt.open {
+				open[id] = count
+			}
+			capacity := &reviewCapacity{settings: &entity.TeamSettings{MaxOpenReviews: tt.teamLimit}, open: open}
+			for _, id := range tt.take {
+				capacity.take(id)
+			}
+
+			free, dropped := capacity.free(candidates)
+			got := []string{}
+			for _, user := range free {
+				got = append(got, user.ID)
+			}
+			if !reflect.DeepEqual(got, tt.wantFree) || dropped != tt.wantDropped {
+				t.Errorf("free = %v, dropped = %d, want %v, %d", got, dropped, tt.wantFree, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestCreatePRCapacityWarning(t *testing.T) {
+	tests := []struct {
+		name          string
+		settings      entity.TeamSettings
+		personal      map[string]int
+		wantReviewers []string // nil - любые wantCount ревьюверов
+		wantCount     int
+		wantWarning   bool
+		wantCode      entity.ErrorCode
+	}{
+		{
+			name:      "no limits",
+			settings:  entity.TeamSettings{ReviewerCount: 2},
+			wantCount: 2,
+		},
+		{
+			name:      "limit not reached",
+			settings:  entity.TeamSettings{ReviewerCount: 2, MaxOpenReviews: 2},
+			wantCount: 2,
+		},
+		{
+			name:          "everyone at team limit",
+			settings:      entity.TeamSettings{ReviewerCount: 2, MaxOpenReviews: 1},
+			wantReviewers: []string{},
+			wantWarning:   true,
+		},
+		{
+			name:          "member at personal limit",
+			settings:      entity.TeamSettings{ReviewerCount: 3},
+			personal:      map[string]int{"b": 1},
+			wantReviewers: []string{"c", "d"},
+			wantWarning:   true,
+		},
+		{
+			name:          "member at limit but enough others",
+			settings:      entity.TeamSettings{ReviewerCount: 2},
+			personal:      map[string]int{"b": 1},
+			wantReviewers: []string{"c", "d"},
+		},
+		{
+			name:          "personal limit above team limit",
+			settings:      entity.TeamSettings{ReviewerCount: 3, MaxOpenReviews: 1},
+			personal:      map[string]int{"b": 2},
+			wantReviewers: []string{"b"},
+			wantWarning:   true,
+		},
+		{
+			name:          "small team is not a capacity warning",
+			settings:      entity.TeamSettings{ReviewerCount: 5},
+			wantReviewers: []string{"b", "c", "d"},
+		},
+		{
+			name:     "min required not met",
+			settings: entity.TeamSettings{ReviewerCount: 2, MinRequired: 1, MaxOpenReviews: 1},
+			wantCode: entity.ErrorNoCandidate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uc := newTestUseCases(t, StrategyRandom)
+			createTeam(t, uc, "backend", "a", "b", "c", "d")
+
+			// У каждого из b, c и d одно открытое ревью
+			setTeamSettings(t, uc, entity.TeamSettings{TeamName: "backend", ReviewerCount: 3})
+			if _, err := uc.PR.CreatePR(ctx, "pr-load", "load", "a", false); err != nil {
+				t.Fatalf("CreatePR: %v", err)
+			}
+
+			tt.settings.TeamName = "backend"
+			setTeamSettings(t, uc, tt.settings)
+			for userID, limit := range tt.personal {
+				setReviewLimit(t, uc, userID, limit)
+			}
+
+			pr, err := uc.PR.CreatePR(ctx, "pr-1", "capacity", "a", false)
+			if tt.wantCode != "" {
+				var appErr entity.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Fatalf("want %s, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreatePR: %v", err)
+			}
+
+			reviewers := append([]string{}, pr.AssignedReviewers...)
+			sort.Strings(reviewers)
+			if tt.wantReviewers != nil && !reflect.DeepEqual(reviewers, tt.wantReviewers) {
+				t.Errorf("reviewers = %v, want %v", reviewers, tt.wantReviewers)
+			}
+			if tt.wantReviewers == nil && len(reviewers) != tt.wantCount {
+				t.Errorf("reviewers = %v, want %d", reviewers, tt.wantCount)
+			}
+			if pr.CapacityWarning != tt.wantWarning {
+				t.Errorf("capacity_warning = %v, want %v", pr.CapacityWarning, tt.wantWarning)
+			}
+		})
+	}
+}
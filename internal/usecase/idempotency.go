@@ -0,0 +1,102 @@
+// idempotency.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+// IdempotencyUseCase интерфейс для повторного воспроизведения ответов на запросы с ключом идемпотентности
+type IdempotencyUseCase interface {
+	// Begin резервирует ключ. Для уже выполненного запроса возвращает сохраненный ответ,
+	// для нового - nil: запрос нужно выполнить и вызвать Complete или Release
+	Begin(ctx context.Context, scope, key, fingerprint string) (*entity.IdempotencyRecord, error)
+	// Complete сохраняет ответ на IDEMPOTENCY_TTL
+	Complete(ctx context.Context, record *entity.IdempotencyRecord) error
+	// Release снимает резерв, чтобы запрос можно было повторить с тем же ключом
+	Release(ctx context.Context, scope, key string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+const maxIdempotencyKeyLength = 255
+
+type idempotencyUseCase struct {
+	idempotencyRepo repository.IdempotencyRepository
+	ttl             time.Duration // срок хранения сохраненного ответа
+	lease           time.Duration // срок резерва ключа незавершенным запросом
+	logger          logger.Interface
+}
+
+func NewIdempotencyUseCase(idempotencyRepo repository.IdempotencyRepository, ttl, lease time.Duration, l logger.Interface) IdempotencyUseCase {
+	return &idempotencyUseCase{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+		lease:           lease,
+		logger:          l,
+	}
+}
+
+func (uc *idempotencyUseCase) Begin(ctx context.Context, scope, key, fingerprint string) (*entity.IdempotencyRecord, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput,
+			fmt.Sprintf("Idempotency-Key must be 1 to %d characters long", maxIdempotencyKeyLength))
+	}
+
+	// Резерв действует lease: если запрос не сохранит ответ и не снимет резерв (например, процесс упал),
+	// повтор с тем же ключом будет выполнен заново, а не получит 409 на весь срок хранения ответов
+	record := &entity.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Now().Add(uc.lease),
+	}
+	existing, reserved, err := uc.idempotencyRepo.ReserveIdempotencyKey(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("idempotencyUseCase - Begin - ReserveIdempotencyKey: %w", err)
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	// Ключ уже использован: повтор допустим только для того же запроса
+	if existing.Fingerprint != fingerprint {
+		uc.logger.Warn("Idempotency key %s reused by %s with a different request", key, scope)
+		return nil, entity.NewAppError(entity.ErrorIdempotencyKeyReused,
+			"Idempotency-Key was already used with a different request")
+	}
+	if !existing.IsCompleted() {
+		return nil, entity.NewAppError(entity.ErrorRequestInProgress,
+			"request with this Idempotency-Key is still in progress")
+	}
+
+	uc.logger.Info("Replaying response for idempotency key %s of %s", key, scope)
+	return existing, nil
+}
+
+func (uc *idempotencyUseCase) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	record.ExpiresAt = time.Now().Add(uc.ttl)
+	if err := uc.idempotencyRepo.CompleteIdempotencyKey(ctx, record); err != nil {
+		return fmt.Errorf("idempotencyUseCase - Complete - CompleteIdempotencyKey: %w", err)
+	}
+	return nil
+}
+
+func (uc *idempotencyUseCase) Release(ctx context.Context, scope, key string) error {
+	if err := uc.idempotencyRepo.DeleteIdempotencyKey(ctx, scope, key); err != nil {
+		return fmt.Errorf("idempotencyUseCase - Release - DeleteIdempotencyKey: %w", err)
+	}
+	return nil
+}
+
+func (uc *idempotencyUseCase) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := uc.idempotencyRepo.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("idempotencyUseCase - PurgeExpired - DeleteExpiredIdempotencyKeys: %w", err)
+	}
+	return deleted, nil
+}
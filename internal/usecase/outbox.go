@@ -0,0 +1,26 @@
+// outbox.go
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+)
+
+// enqueueEvent записывает событие в outbox. Вызывается внутри транзакции изменения:
+// событие будет опубликовано тогда и только тогда, когда изменение зафиксировано
+func enqueueEvent(ctx context.Context, outboxRepo repository.OutboxRepository, eventType entity.WebhookEventType, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("enqueueEvent - Marshal %s: %w", eventType, err)
+	}
+
+	msg := entity.OutboxMessage{Type: eventType, Payload: payload}
+	if err := outboxRepo.AddOutboxMessage(ctx, &msg); err != nil {
+		return fmt.Errorf("enqueueEvent - AddOutboxMessage %s: %w", eventType, err)
+	}
+	return nil
+}
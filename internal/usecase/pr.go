@@ -3,8 +3,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
+	"slices"
 	"time"
 
 	"github.com/PaulLocust/Avito-review/internal/entity"
@@ -13,34 +14,84 @@ import (
 )
 
 type PRUseCase interface {
-	CreatePR(ctx context.Context, prID, name, authorID string) (*entity.PullRequest, error)
+	CreatePR(ctx context.Context, prID, name, authorID string, draft bool) (*entity.PullRequest, error)
 	MergePR(ctx context.Context, prID string) (*entity.PullRequest, error)
+	ClosePR(ctx context.Context, prID string) (*entity.PullRequest, error)
+	ReopenPR(ctx context.Context, prID string) (*entity.PullRequest, error)
+	ReadyPR(ctx context.Context, prID string) (*entity.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*entity.PullRequest, string, error)
+	ReviewPR(ctx context.Context, prID, reviewerID string, state entity.ReviewState) (*entity.PullRequest, error)
+	ListPRs(ctx context.Context, filter entity.PRFilter, cursor string) (*entity.PRPage, error)
+	GetPR(ctx context.Context, prID string) (*entity.PullRequest, error)
+	GetPRHistory(ctx context.Context, prID string) ([]entity.PREvent, error)
 }
 
+const (
+	defaultPRPageSize = 20
+	maxPRPageSize     = 100
+)
+
 type prUseCase struct {
-	prRepo   repository.PRRepository
-	userRepo repository.UserRepository
-	teamRepo repository.TeamRepository
-	logger   logger.Interface
+	prRepo     repository.PRRepository
+	userRepo   repository.UserRepository
+	teamRepo   repository.TeamRepository
+	eventRepo  repository.EventRepository
+	outboxRepo repository.OutboxRepository
+	tx         repository.Transactor
+	selector   ReviewerSelector
+	metrics    Metrics
+	logger     logger.Interface
 }
 
 func NewPRUseCase(
 	prRepo repository.PRRepository,
 	userRepo repository.UserRepository,
 	teamRepo repository.TeamRepository,
+	eventRepo repository.EventRepository,
+	outboxRepo repository.OutboxRepository,
+	tx repository.Transactor,
+	selector ReviewerSelector,
+	m Metrics,
 	l logger.Interface,
 ) PRUseCase {
 	return &prUseCase{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
-		logger:   l,
+		prRepo:     prRepo,
+		userRepo:   userRepo,
+		teamRepo:   teamRepo,
+		eventRepo:  eventRepo,
+		outboxRepo: outboxRepo,
+		tx:         tx,
+		selector:   selector,
+		metrics:    m,
+		logger:     l,
+	}
+}
+
+// CreatePR выполняет проверку, выбор ревьюверов и вставку в одной транзакции.
+// Черновик создаётся без ревьюверов
+func (uc *prUseCase) CreatePR(ctx context.Context, prID, name, authorID string, draft bool) (*entity.PullRequest, error) {
+	uc.logger.Info("Creating PR: %s by author %s (draft: %t)", prID, authorID, draft)
+
+	var pr *entity.PullRequest
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = uc.createPR(ctx, prID, name, authorID, draft)
+		if err != nil {
+			return err
+		}
+		return uc.enqueueEvent(ctx, entity.WebhookPRCreated, pr)
+	})
+	if err != nil {
+		uc.observeFailure("create", err)
+		return nil, err
 	}
+	uc.metrics.PRCreated()
+
+	uc.logger.Info("PR created successfully: %s", prID)
+	return pr, nil
 }
 
-func (uc *prUseCase) CreatePR(ctx context.Context, prID, name, authorID string) (*entity.PullRequest, error) {
-	uc.logger.Info("Creating PR: %s by author %s", prID, authorID)
+func (uc *prUseCase) createPR(ctx context.Context, prID, name, authorID string, draft bool) (*entity.PullRequest, error) {
 
 	// Проверяем существование PR
 	existingPR, _ := uc.prRepo.GetPR(ctx, prID)
@@ -50,59 +101,166 @@ func (uc *prUseCase) CreatePR(ctx context.Context, prID, name, authorID string)
 	}
 
 	// Получаем автора
-	author, err := uc.userRepo.GetUser(ctx, authorID)
+	_, err := uc.userRepo.GetUser(ctx, authorID)
 	if err != nil {
 		uc.logger.Warn("Author not found: %s", authorID)
 		return nil, entity.NewAppError(entity.ErrorNotFound, "author not found")
 	}
 
-	// Получаем активных пользователей команды (исключая автора)
-	teamMembers, err := uc.userRepo.GetActiveUsersByTeam(ctx, author.TeamName, authorID)
-	if err != nil {
-		uc.logger.Error("Failed to get team members: %v", err)
-		return nil, fmt.Errorf("prUseCase - CreatePR - GetActiveUsersByTeam: %w", err)
-	}
-
-	uc.logger.Debug("Found %d active team members for PR assignment", len(teamMembers))
-
-	// Выбираем до 2 случайных ревьюверов
-	reviewers := uc.selectRandomReviewers(teamMembers, 2)
-	uc.logger.Info("Selected %d reviewers for PR %s: %v", len(reviewers), prID, reviewers)
-
 	// Создаем PR
 	pr := &entity.PullRequest{
 		ID:                prID,
 		Name:              name,
 		AuthorID:          authorID,
 		Status:            entity.StatusOpen,
-		AssignedReviewers: reviewers,
+		AssignedReviewers: []string{},
+		Reviews:           []entity.Review{},
 		CreatedAt:         time.Now(),
 	}
 
+	if draft {
+		pr.Status = entity.StatusDraft
+	} else {
+		reviewers, capacityWarning, err := uc.selectReviewers(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		pr.AssignedReviewers = reviewers
+		pr.Reviews = entity.PendingReviews(reviewers)
+		pr.CapacityWarning = capacityWarning
+	}
+
 	err = uc.prRepo.CreatePR(ctx, pr)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		// PR с таким id успели создать параллельно
+		uc.logger.Warn("PR already exists: %s", prID)
+		return nil, entity.NewAppError(entity.ErrorPRExists, "PR already exists")
+	}
 	if err != nil {
 		uc.logger.Error("Failed to create PR: %v", err)
 		return nil, fmt.Errorf("prUseCase - CreatePR - CreatePR: %w", err)
 	}
 
-	uc.logger.Info("PR created successfully: %s", prID)
+	// Записываем создание и назначения в журнал в той же транзакции
+	actor := actorFrom(ctx)
+	events := []entity.PREvent{entity.NewPREvent(prID, entity.EventCreated, actor)}
+	events = append(events, assignedEvents(pr.ID, pr.AssignedReviewers, actor, entity.ReasonCreate)...)
+	if err := uc.addEvents(ctx, events); err != nil {
+		return nil, err
+	}
+
 	return pr, nil
 }
 
+// selectReviewers выбирает ревьюверов PR из активных участников команды автора согласно настройкам команды.
+// Участники, достигшие лимита открытых ревью, пропускаются; true означает, что из-за этого
+// назначено меньше reviewer_count ревьюверов
+func (uc *prUseCase) selectReviewers(ctx context.Context, pr *entity.PullRequest) ([]string, bool, error) {
+	author, err := uc.userRepo.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		uc.logger.Warn("Author not found: %s", pr.AuthorID)
+		return nil, false, entity.NewAppError(entity.ErrorNotFound, "author not found")
+	}
+
+	// Получаем активных пользователей команды (исключая автора)
+	teamMembers, err := uc.userRepo.GetActiveUsersByTeam(ctx, author.TeamName, author.ID)
+	if err != nil {
+		uc.logger.Error("Failed to get team members: %v", err)
+		return nil, false, fmt.Errorf("prUseCase - selectReviewers - GetActiveUsersByTeam: %w", err)
+	}
+
+	uc.logger.Debug("Found %d active team members for PR assignment", len(teamMembers))
+
+	// Получаем настройки команды автора
+	settings, err := teamSettingsOrDefault(ctx, uc.teamRepo, author.TeamName)
+	if err != nil {
+		uc.logger.Error("Failed to get team settings: %v", err)
+		return nil, false, fmt.Errorf("prUseCase - selectReviewers - teamSettingsOrDefault: %w", err)
+	}
+
+	selector, err := selectorForTeam(settings, uc.selector, uc.prRepo)
+	if err != nil {
+		return nil, false, fmt.Errorf("prUseCase - selectReviewers - selectorForTeam: %w", err)
+	}
+
+	// Отбрасываем участников, у которых уже максимум открытых ревью
+	capacity, err := newReviewCapacity(ctx, uc.prRepo, settings, teamMembers)
+	if err != nil {
+		uc.logger.Error("Failed to count open reviews: %v", err)
+		return nil, false, fmt.Errorf("prUseCase - selectReviewers - %w", err)
+	}
+	candidates, atCapacity := capacity.free(teamMembers)
+
+	// Выбираем ревьюверов согласно настройкам команды
+	reviewers, err := selector.Select(ctx, candidates, settings.ReviewerCount)
+	if err != nil {
+		uc.logger.Error("Failed to select reviewers: %v", err)
+		return nil, false, fmt.Errorf("prUseCase - selectReviewers - Select: %w", err)
+	}
+
+	if len(reviewers) < settings.MinRequired {
+		uc.logger.Warn("Not enough reviewers for PR %s: %d < %d (%d at capacity)", pr.ID, len(reviewers), settings.MinRequired, atCapacity)
+		return nil, false, entity.NewAppError(entity.ErrorNoCandidate, "not enough active reviewers in team")
+	}
+
+	capacityWarning := atCapacity > 0 && len(reviewers) < settings.ReviewerCount
+	if capacityWarning {
+		uc.logger.Warn("PR %s gets %d of %d reviewers: %d team members at capacity", pr.ID, len(reviewers), settings.ReviewerCount, atCapacity)
+	}
+	uc.logger.Info("Selected %d reviewers for PR %s: %v", len(reviewers), pr.ID, reviewers)
+
+	return reviewers, capacityWarning, nil
+}
+
 func (uc *prUseCase) MergePR(ctx context.Context, prID string) (*entity.PullRequest, error) {
 	uc.logger.Info("Merging PR: %s", prID)
 
-	// Получаем PR
-	pr, err := uc.prRepo.GetPR(ctx, prID)
+	var pr *entity.PullRequest
+	var merged bool
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, merged, err = uc.mergePR(ctx, prID)
+		if err != nil || !merged {
+			return err
+		}
+		return uc.enqueueEvent(ctx, entity.WebhookPRMerged, pr)
+	})
 	if err != nil {
-		uc.logger.Warn("PR not found: %s", prID)
-		return nil, entity.NewAppError(entity.ErrorNotFound, "PR not found")
+		return nil, err
+	}
+	if merged {
+		uc.metrics.PRMerged()
+	}
+
+	return pr, nil
+}
+
+// mergePR возвращает true, если PR был смерджен этим вызовом
+func (uc *prUseCase) mergePR(ctx context.Context, prID string) (*entity.PullRequest, bool, error) {
+	// Получаем и блокируем PR
+	pr, err := uc.lockPR(ctx, prID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := authorizeMerge(ctx, pr); err != nil {
+		uc.logger.Warn("Merge of PR %s denied: %v", prID, err)
+		return nil, false, err
 	}
 
 	// Если уже мерджен - возвращаем как есть (идемпотентность)
 	if pr.Status == entity.StatusMerged {
 		uc.logger.Debug("PR already merged: %s", prID)
-		return pr, nil
+		return pr, false, nil
+	}
+	if err := checkTransition(pr.Status, entity.StatusMerged); err != nil {
+		uc.logger.Warn("Cannot merge PR %s in status %s", prID, pr.Status)
+		return nil, false, err
+	}
+
+	// Проверяем, что набрано требуемое командой автора число одобрений
+	if err := uc.checkApprovals(ctx, pr); err != nil {
+		return nil, false, err
 	}
 
 	// Обновляем статус
@@ -113,27 +271,147 @@ func (uc *prUseCase) MergePR(ctx context.Context, prID string) (*entity.PullRequ
 	err = uc.prRepo.UpdatePR(ctx, pr)
 	if err != nil {
 		uc.logger.Error("Failed to merge PR: %v", err)
-		return nil, fmt.Errorf("prUseCase - MergePR - UpdatePR: %w", err)
+		return nil, false, fmt.Errorf("prUseCase - MergePR - UpdatePR: %w", err)
+	}
+
+	if err := uc.addEvents(ctx, []entity.PREvent{entity.NewPREvent(prID, entity.EventMerged, actorFrom(ctx))}); err != nil {
+		return nil, false, err
 	}
 
 	uc.logger.Info("PR merged successfully: %s", prID)
+	return pr, true, nil
+}
+
+// ClosePR закрывает PR без мерджа; ревьюверы остаются назначенными, но PR перестаёт учитываться в их нагрузке
+func (uc *prUseCase) ClosePR(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	uc.logger.Info("Closing PR: %s", prID)
+	return uc.changeStatus(ctx, prID, "close", entity.StatusClosed)
+}
+
+// ReopenPR возвращает закрытый PR в OPEN; если ревьюверов нет (закрыт черновик) - назначает их
+func (uc *prUseCase) ReopenPR(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	uc.logger.Info("Reopening PR: %s", prID)
+	return uc.changeStatus(ctx, prID, "reopen", entity.StatusOpen, entity.StatusClosed)
+}
+
+// ReadyPR переводит черновик в OPEN и назначает ревьюверов
+func (uc *prUseCase) ReadyPR(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	uc.logger.Info("Marking PR ready for review: %s", prID)
+	return uc.changeStatus(ctx, prID, "ready", entity.StatusOpen, entity.StatusDraft)
+}
+
+// changeStatus переводит PR в status в транзакции под блокировкой строки PR.
+// from дополнительно ограничивает исходные статусы для операции (пустой - только prTransitions)
+func (uc *prUseCase) changeStatus(ctx context.Context, prID, operation string, status entity.PRStatus, from ...entity.PRStatus) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = uc.lockPR(ctx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status != status && len(from) > 0 && !slices.Contains(from, pr.Status) {
+			uc.logger.Warn("Cannot %s PR %s in status %s", operation, prID, pr.Status)
+			return entity.NewAppError(entity.ErrorInvalidTransition,
+				fmt.Sprintf("%s is not allowed for PR in status %s", operation, pr.Status))
+		}
+		pr, err = uc.transition(ctx, pr, status)
+		return err
+	})
+	if err != nil {
+		uc.observeFailure(operation, err)
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// transition переводит заблокированный PR в статус status по правилам prTransitions.
+// При переходе в OPEN PR без ревьюверов получает их так же, как при создании
+func (uc *prUseCase) transition(ctx context.Context, pr *entity.PullRequest, status entity.PRStatus) (*entity.PullRequest, error) {
+	if pr.Status == status {
+		uc.logger.Debug("PR %s already in status %s", pr.ID, status)
+		return pr, nil
+	}
+	if err := checkTransition(pr.Status, status); err != nil {
+		uc.logger.Warn("Illegal PR transition for %s: %s -> %s", pr.ID, pr.Status, status)
+		return nil, err
+	}
+
+	actor := actorFrom(ctx)
+	event, reason := transitionEvent(pr.Status, status)
+	events := []entity.PREvent{entity.NewPREvent(pr.ID, event, actor)}
+
+	if status == entity.StatusOpen && len(pr.AssignedReviewers) == 0 {
+		reviewers, capacityWarning, err := uc.selectReviewers(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		for _, reviewerID := range reviewers {
+			if err := uc.prRepo.AddReviewer(ctx, pr.ID, reviewerID); err != nil {
+				uc.logger.Error("Failed to add reviewer %s: %v", reviewerID, err)
+				return nil, fmt.Errorf("prUseCase - transition - AddReviewer: %w", err)
+			}
+		}
+		pr.AssignedReviewers = reviewers
+		pr.Reviews = entity.PendingReviews(reviewers)
+		pr.CapacityWarning = capacityWarning
+		events = append(events, assignedEvents(pr.ID, reviewers, actor, reason)...)
+	}
+
+	pr.Status = status
+	if err := uc.prRepo.UpdatePR(ctx, pr); err != nil {
+		uc.logger.Error("Failed to update PR status: %v", err)
+		return nil, fmt.Errorf("prUseCase - transition - UpdatePR: %w", err)
+	}
+
+	if err := uc.addEvents(ctx, events); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("PR %s is now %s", pr.ID, status)
 	return pr, nil
 }
 
+// ReassignReviewer выполняет чтение, выбор замены и запись под блокировкой строки PR,
+// поэтому параллельные переназначения одного PR не приводят к дублям ревьюверов
 func (uc *prUseCase) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*entity.PullRequest, string, error) {
 	uc.logger.Info("Reassigning reviewer %s from PR %s", oldUserID, prID)
 
-	// Получаем PR
-	pr, err := uc.prRepo.GetPR(ctx, prID)
+	var pr *entity.PullRequest
+	var newReviewerID string
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, newReviewerID, err = uc.reassignReviewer(ctx, prID, oldUserID)
+		if err != nil {
+			return err
+		}
+		return uc.enqueueEvent(ctx, entity.WebhookReviewerReassigned, entity.ReviewerReassignment{
+			PullRequestID: prID,
+			OldUserID:     oldUserID,
+			NewUserID:     newReviewerID,
+		})
+	})
 	if err != nil {
-		uc.logger.Warn("PR not found: %s", prID)
-		return nil, "", entity.NewAppError(entity.ErrorNotFound, "PR not found")
+		uc.observeFailure("reassign", err)
+		return nil, "", err
 	}
+	uc.metrics.ReviewerReassigned()
 
-	// Проверяем что PR не мерджен
-	if pr.Status == entity.StatusMerged {
-		uc.logger.Warn("Cannot reassign on merged PR: %s", prID)
-		return nil, "", entity.NewAppError(entity.ErrorPRMerged, "cannot reassign on merged PR")
+	return pr, newReviewerID, nil
+}
+
+func (uc *prUseCase) reassignReviewer(ctx context.Context, prID, oldUserID string) (*entity.PullRequest, string, error) {
+	// Получаем и блокируем PR
+	pr, err := uc.lockPR(ctx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Проверяем что PR не мерджен и не закрыт
+	if err := checkReviewersEditable(pr, "reassign on"); err != nil {
+		uc.logger.Warn("Cannot reassign on %s PR: %s", pr.Status, prID)
+		return nil, "", err
 	}
 
 	// Проверяем что старый ревьювер назначен на PR
@@ -169,54 +447,315 @@ func (uc *prUseCase) ReassignReviewer(ctx context.Context, prID, oldUserID strin
 		return nil, "", entity.NewAppError(entity.ErrorNoCandidate, "no active replacement candidate in team")
 	}
 
-	// Выбираем случайного нового ревьювера
-	newReviewer := teamMembers[rand.Intn(len(teamMembers))]
-	uc.logger.Info("Replacing reviewer %s with %s in PR %s", oldUserID, newReviewer.ID, prID)
+	// Выбираем нового ревьювера согласно стратегии команды
+	settings, err := teamSettingsOrDefault(ctx, uc.teamRepo, oldReviewer.TeamName)
+	if err != nil {
+		uc.logger.Error("Failed to get team settings: %v", err)
+		return nil, "", fmt.Errorf("prUseCase - ReassignReviewer - teamSettingsOrDefault: %w", err)
+	}
+
+	// Кандидаты, достигшие лимита открытых ревью, замену не получают
+	capacity, err := newReviewCapacity(ctx, uc.prRepo, settings, teamMembers)
+	if err != nil {
+		uc.logger.Error("Failed to count open reviews: %v", err)
+		return nil, "", fmt.Errorf("prUseCase - ReassignReviewer - %w", err)
+	}
+	teamMembers, _ = capacity.free(teamMembers)
+	if len(teamMembers) == 0 {
+		uc.logger.Warn("All replacement candidates for reviewer %s in PR %s are at capacity", oldUserID, prID)
+		return nil, "", entity.NewAppError(entity.ErrorNoCandidate, "all replacement candidates reached max_open_reviews")
+	}
+
+	selector, err := selectorForTeam(settings, uc.selector, uc.prRepo)
+	if err != nil {
+		return nil, "", fmt.Errorf("prUseCase - ReassignReviewer - selectorForTeam: %w", err)
+	}
+
+	selected, err := selector.Select(ctx, teamMembers, 1)
+	if err != nil {
+		uc.logger.Error("Failed to select replacement reviewer: %v", err)
+		return nil, "", fmt.Errorf("prUseCase - ReassignReviewer - Select: %w", err)
+	}
+	newReviewerID := selected[0]
+	uc.logger.Info("Replacing reviewer %s with %s in PR %s", oldUserID, newReviewerID, prID)
 
 	// Заменяем ревьювера
-	err = uc.prRepo.ReplaceReviewer(ctx, prID, oldUserID, newReviewer.ID)
+	err = uc.prRepo.ReplaceReviewer(ctx, prID, oldUserID, newReviewerID)
 	if err != nil {
 		uc.logger.Error("Failed to replace reviewer: %v", err)
 		return nil, "", fmt.Errorf("prUseCase - ReassignReviewer - ReplaceReviewer: %w", err)
 	}
 
-	// Обновляем список ревьюверов в возвращаемом объекте
+	event := entity.NewPREvent(prID, entity.EventReviewerReplaced, actorFrom(ctx))
+	event.OldUserID = oldUserID
+	event.NewUserID = newReviewerID
+	event.Reason = entity.ReasonReassign
+	if err := uc.addEvents(ctx, []entity.PREvent{event}); err != nil {
+		return nil, "", err
+	}
+
+	// Обновляем список ревьюверов в возвращаемом объекте, решение нового ревьювера - PENDING
 	for i, reviewer := range pr.AssignedReviewers {
 		if reviewer == oldUserID {
-			pr.AssignedReviewers[i] = newReviewer.ID
+			pr.AssignedReviewers[i] = newReviewerID
+			break
+		}
+	}
+	for i, review := range pr.Reviews {
+		if review.ReviewerID == oldUserID {
+			pr.Reviews[i] = entity.Review{ReviewerID: newReviewerID, State: entity.ReviewPending}
 			break
 		}
 	}
 
 	uc.logger.Info("Reviewer reassigned successfully in PR %s", prID)
-	return pr, newReviewer.ID, nil
+	return pr, newReviewerID, nil
 }
 
-// Вспомогательные методы (без изменений)
-func (uc *prUseCase) selectRandomReviewers(users []entity.User, max int) []string {
-	if len(users) == 0 {
-		return []string{}
+// ReviewPR фиксирует решение ревьювера под блокировкой строки PR,
+// чтобы решение не записалось в параллельно мерджащийся PR
+func (uc *prUseCase) ReviewPR(ctx context.Context, prID, reviewerID string, state entity.ReviewState) (*entity.PullRequest, error) {
+	uc.logger.Info("Reviewer %s submits %s for PR %s", reviewerID, state, prID)
+
+	if !state.IsDecision() {
+		uc.logger.Warn("Invalid review state: %s", state)
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "state must be one of APPROVED, CHANGES_REQUESTED, COMMENTED")
+	}
+
+	// Решение за другого ревьювера (например, за автора PR) обходило бы required_approvals
+	if err := authorizeReview(ctx, reviewerID); err != nil {
+		uc.logger.Warn("Review of PR %s as %s denied: %v", prID, reviewerID, err)
+		return nil, err
 	}
 
-	// Перемешиваем пользователей
-	shuffled := make([]entity.User, len(users))
-	copy(shuffled, users)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	var pr *entity.PullRequest
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = uc.reviewPR(ctx, prID, reviewerID, state)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (uc *prUseCase) reviewPR(ctx context.Context, prID, reviewerID string, state entity.ReviewState) (*entity.PullRequest, error) {
+	// Получаем и блокируем PR
+	pr, err := uc.lockPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверяем что PR не мерджен и не закрыт
+	if err := checkReviewersEditable(pr, "review"); err != nil {
+		uc.logger.Warn("Cannot review %s PR: %s", pr.Status, prID)
+		return nil, err
+	}
+
+	err = uc.prRepo.SetReviewState(ctx, prID, reviewerID, state)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("Reviewer %s not assigned to PR %s", reviewerID, prID)
+		return nil, entity.NewAppError(entity.ErrorNotAssigned, "reviewer is not assigned to this PR")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to set review state: %v", err)
+		return nil, fmt.Errorf("prUseCase - ReviewPR - SetReviewState: %w", err)
+	}
+
+	// Обновляем решение в возвращаемом объекте
+	for i, review := range pr.Reviews {
+		if review.ReviewerID == reviewerID {
+			pr.Reviews[i].State = state
+			break
+		}
+	}
+
+	uc.logger.Info("Review of %s recorded for PR %s: %s", reviewerID, prID, state)
+	return pr, nil
+}
+
+// GetPR возвращает PR с ревьюверами и командой автора
+func (uc *prUseCase) GetPR(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	uc.logger.Debug("Getting PR: %s", prID)
+
+	pr, err := uc.prRepo.GetPR(ctx, prID)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("PR not found: %s", prID)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "PR not found")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to get PR %s: %v", prID, err)
+		return nil, fmt.Errorf("prUseCase - GetPR - GetPR: %w", err)
+	}
+
+	// У удаленного автора команды нет, PR возвращается без author_team
+	author, err := uc.userRepo.GetUser(ctx, pr.AuthorID)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+	case err != nil:
+		uc.logger.Error("Failed to get PR author %s: %v", pr.AuthorID, err)
+		return nil, fmt.Errorf("prUseCase - GetPR - GetUser: %w", err)
+	default:
+		pr.AuthorTeam = author.TeamName
+	}
 
-	// Берем до max пользователей
-	count := len(shuffled)
-	if count > max {
-		count = max
+	return pr, nil
+}
+
+// GetPRHistory возвращает журнал изменений PR в порядке записи
+func (uc *prUseCase) GetPRHistory(ctx context.Context, prID string) ([]entity.PREvent, error) {
+	uc.logger.Debug("Getting history of PR: %s", prID)
+
+	_, err := uc.prRepo.GetPR(ctx, prID)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("PR not found: %s", prID)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "PR not found")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to get PR %s: %v", prID, err)
+		return nil, fmt.Errorf("prUseCase - GetPRHistory - GetPR: %w", err)
+	}
+
+	events, err := uc.eventRepo.GetPREvents(ctx, prID)
+	if err != nil {
+		uc.logger.Error("Failed to get PR events: %v", err)
+		return nil, fmt.Errorf("prUseCase - GetPRHistory - GetPREvents: %w", err)
+	}
+
+	return events, nil
+}
+
+// ListPRs возвращает страницу PR по фильтру; cursor - значение next_cursor предыдущей страницы
+func (uc *prUseCase) ListPRs(ctx context.Context, filter entity.PRFilter, cursor string) (*entity.PRPage, error) {
+	uc.logger.Debug("Listing PRs: %+v, cursor %q", filter, cursor)
+
+	// Валидируем фильтр
+	switch filter.Status {
+	case "", entity.StatusDraft, entity.StatusOpen, entity.StatusMerged, entity.StatusClosed:
+	default:
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "unknown status")
+	}
+	switch filter.Order {
+	case "":
+		filter.Order = entity.SortDesc
+	case entity.SortAsc, entity.SortDesc:
+	default:
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "order must be asc or desc")
+	}
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultPRPageSize
+	case filter.Limit < 0 || filter.Limit > maxPRPageSize:
+		return nil, entity.NewAppError(entity.ErrorInvalidInput,
+			fmt.Sprintf("limit must be between 1 and %d", maxPRPageSize))
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "created_from must not be after created_to")
+	}
+	if filter.MergedFrom != nil && filter.MergedTo != nil && filter.MergedFrom.After(*filter.MergedTo) {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "merged_from must not be after merged_to")
+	}
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			uc.logger.Warn("Invalid cursor %q: %v", cursor, err)
+			return nil, entity.NewAppError(entity.ErrorInvalidInput, "invalid cursor")
+		}
+		filter.After = after
+	}
+
+	// Запрашиваем на один PR больше, чтобы понять, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := uc.prRepo.ListPRs(ctx, filter)
+	if err != nil {
+		uc.logger.Error("Failed to list PRs: %v", err)
+		return nil, fmt.Errorf("prUseCase - ListPRs - ListPRs: %w", err)
+	}
+
+	page := &entity.PRPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		page.NextCursor = encodeCursor(prs[limit-1])
+	}
+
+	uc.logger.Debug("Listed %d PRs", len(page.PullRequests))
+	return page, nil
+}
+
+// Вспомогательные методы
+
+// checkApprovals сравнивает число одобрений с required_approvals команды автора PR
+func (uc *prUseCase) checkApprovals(ctx context.Context, pr *entity.PullRequest) error {
+	author, err := uc.userRepo.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		uc.logger.Error("Failed to get PR author %s: %v", pr.AuthorID, err)
+		return fmt.Errorf("prUseCase - MergePR - GetUser: %w", err)
+	}
+
+	settings, err := teamSettingsOrDefault(ctx, uc.teamRepo, author.TeamName)
+	if err != nil {
+		uc.logger.Error("Failed to get team settings: %v", err)
+		return fmt.Errorf("prUseCase - MergePR - teamSettingsOrDefault: %w", err)
+	}
+
+	if approvals := pr.Approvals(); approvals < settings.RequiredApprovals {
+		uc.logger.Warn("PR %s has %d of %d required approvals", pr.ID, approvals, settings.RequiredApprovals)
+		return entity.NewAppError(entity.ErrorNotApproved,
+			fmt.Sprintf("PR has %d of %d required approvals", approvals, settings.RequiredApprovals))
+	}
+	return nil
+}
+
+// assignedEvents возвращает события назначения ревьюверов PR
+func assignedEvents(prID string, reviewerIDs []string, actor, reason string) []entity.PREvent {
+	events := make([]entity.PREvent, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		event := entity.NewPREvent(prID, entity.EventReviewerAssigned, actor)
+		event.NewUserID = reviewerID
+		event.Reason = reason
+		events = append(events, event)
+	}
+	return events
+}
+
+func (uc *prUseCase) addEvents(ctx context.Context, events []entity.PREvent) error {
+	if err := uc.eventRepo.AddEvents(ctx, events); err != nil {
+		uc.logger.Error("Failed to record PR events: %v", err)
+		return fmt.Errorf("prUseCase - addEvents - AddEvents: %w", err)
 	}
+	return nil
+}
 
-	var reviewers []string
-	for i := 0; i < count; i++ {
-		reviewers = append(reviewers, shuffled[i].ID)
+// enqueueEvent записывает событие для подписчиков в outbox в текущей транзакции
+func (uc *prUseCase) enqueueEvent(ctx context.Context, eventType entity.WebhookEventType, data any) error {
+	if err := enqueueEvent(ctx, uc.outboxRepo, eventType, data); err != nil {
+		uc.logger.Error("Failed to enqueue event: %v", err)
+		return fmt.Errorf("prUseCase - %w", err)
 	}
+	return nil
+}
+
+func (uc *prUseCase) observeFailure(operation string, err error) {
+	var appErr entity.AppError
+	if errors.As(err, &appErr) && appErr.Code == entity.ErrorNoCandidate {
+		uc.metrics.NoCandidate(operation)
+	}
+}
 
-	return reviewers
+func (uc *prUseCase) lockPR(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	pr, err := uc.prRepo.GetPRForUpdate(ctx, prID)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("PR not found: %s", prID)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "PR not found")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to get PR %s: %v", prID, err)
+		return nil, fmt.Errorf("prUseCase - lockPR - GetPRForUpdate: %w", err)
+	}
+	return pr, nil
 }
 
 func (uc *prUseCase) containsReviewer(reviewers []string, userID string) bool {
@@ -246,4 +785,4 @@ func (uc *prUseCase) filterOutReviewers(users []entity.User, reviewers []string)
 		}
 	}
 	return result
-}
\ No newline at end of file
+}
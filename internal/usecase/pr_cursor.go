@@ -0,0 +1,28 @@
+// pr_cursor.go
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+// encodeCursor упаковывает позицию PR в непрозрачную для клиента строку
+func encodeCursor(pr entity.PullRequest) string {
+	data, _ := json.Marshal(entity.PRCursor{CreatedAt: pr.CreatedAt, ID: pr.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor разбирает курсор, полученный из encodeCursor
+func decodeCursor(cursor string) (*entity.PRCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var result entity.PRCursor
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
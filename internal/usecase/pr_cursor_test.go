@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		id        string
+	}{
+		{name: "utc", createdAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), id: "pr-1"},
+		{name: "nanoseconds", createdAt: time.Date(2025, 3, 1, 12, 0, 0, 123456789, time.UTC), id: "pr-2"},
+		{name: "offset", createdAt: time.Date(2025, 3, 1, 15, 0, 0, 0, time.FixedZone("MSK", 3*60*60)), id: "pr-3"},
+		{name: "url unsafe id", createdAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), id: "feature/search?x=1&y=+"},
+		{name: "unicode id", createdAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), id: "поиск-пр"},
+		{name: "zero", createdAt: time.Time{}, id: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := encodeCursor(entity.PullRequest{ID: tt.id, CreatedAt: tt.createdAt})
+			// Курсор передается в query-параметре без экранирования
+			if strings.ContainsAny(cursor, "+/=?&") {
+				t.Errorf("cursor %q is not URL safe", cursor)
+			}
+
+			got, err := decodeCursor(cursor)
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if got.ID != tt.id || !got.CreatedAt.Equal(tt.createdAt) {
+				t.Errorf("decoded %+v, want id=%q created_at=%s", got, tt.id, tt.createdAt)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "padded", cursor: base64.URLEncoding.EncodeToString([]byte(`{"id":"pr-1"}`))},
+		{name: "not json", cursor: base64.RawURLEncoding.EncodeToString([]byte("pr-1"))},
+		{name: "bad time", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"created_at":"yesterday","id":"pr-1"}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor); err == nil {
+				t.Fatal("want error")
+			}
+
+			uc := newTestUseCases(t, StrategyRandom)
+			_, err := uc.PR.ListPRs(context.Background(), entity.PRFilter{}, tt.cursor)
+			var appErr entity.AppError
+			if !errors.As(err, &appErr) || appErr.Code != entity.ErrorInvalidInput {
+				t.Errorf("ListPRs: want INVALID_INPUT, got %v", err)
+			}
+		})
+	}
+}
+
+func TestListPRsPagination(t *testing.T) {
+	ctx := context.Background()
+	uc := newTestUseCases(t, StrategyRandom)
+	createTeam(t, uc, "backend", "a", "b")
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("pr-%d", i)
+		if _, err := uc.PR.CreatePR(ctx, id, id, "a", false); err != nil {
+			t.Fatalf("CreatePR: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	tests := []struct {
+		order entity.SortOrder
+		limit int
+		want  string
+	}{
+		{order: entity.SortAsc, limit: 2, want: "pr-0,pr-1,pr-2,pr-3,pr-4"},
+		{order: entity.SortDesc, limit: 2, want: "pr-4,pr-3,pr-2,pr-1,pr-0"},
+		{order: entity.SortAsc, limit: 5, want: "pr-0,pr-1,pr-2,pr-3,pr-4"},
+		{order: entity.SortDesc, limit: 100, want: "pr-4,pr-3,pr-2,pr-1,pr-0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s by %d", tt.order, tt.limit), func(t *testing.T) {
+			var got []string
+			cursor := ""
+			for pages := 0; ; pages++ {
+				if pages > len(ids) {
+					t.Fatalf("pagination does not terminate: %v", got)
+				}
+				page, err := uc.PR.ListPRs(ctx, entity.PRFilter{Order: tt.order, Limit: tt.limit}, cursor)
+				if err != nil {
+					t.Fatalf("ListPRs: %v", err)
+				}
+				if len(page.PullRequests) > tt.limit {
+					t.Fatalf("page has %d PRs, limit %d", len(page.PullRequests), tt.limit)
+				}
+				for _, pr := range page.PullRequests {
+					got = append(got, pr.ID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				cursor = page.NextCursor
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("got %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// pr_status.go
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+// prTransitions - допустимые переходы между статусами PR.
+// MERGED - конечное состояние; повтор перехода в текущий статус обрабатывается как no-op
+var prTransitions = map[entity.PRStatus][]entity.PRStatus{
+	entity.StatusDraft:  {entity.StatusOpen, entity.StatusClosed},
+	entity.StatusOpen:   {entity.StatusMerged, entity.StatusClosed},
+	entity.StatusClosed: {entity.StatusOpen},
+	entity.StatusMerged: {},
+}
+
+// checkTransition возвращает INVALID_TRANSITION, если переход из from в to запрещён
+func checkTransition(from, to entity.PRStatus) error {
+	for _, allowed := range prTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return entity.NewAppError(entity.ErrorInvalidTransition,
+		fmt.Sprintf("cannot change PR status from %s to %s", from, to))
+}
+
+// checkReviewersEditable запрещает менять ревьюверов и их решения у смердженных и закрытых PR,
+// action подставляется в сообщение: "cannot <action> merged PR"
+func checkReviewersEditable(pr *entity.PullRequest, action string) error {
+	switch pr.Status {
+	case entity.StatusMerged:
+		return entity.NewAppError(entity.ErrorPRMerged, fmt.Sprintf("cannot %s merged PR", action))
+	case entity.StatusClosed:
+		return entity.NewAppError(entity.ErrorPRClosed, fmt.Sprintf("cannot %s closed PR", action))
+	}
+	return nil
+}
+
+// transitionEvent возвращает тип события журнала для перехода from -> to
+// и причину назначения ревьюверов, если переход их назначает
+func transitionEvent(from, to entity.PRStatus) (entity.PREventType, string) {
+	switch {
+	case to == entity.StatusClosed:
+		return entity.EventClosed, ""
+	case from == entity.StatusDraft:
+		return entity.EventReadyForReview, entity.ReasonReady
+	default:
+		return entity.EventReopened, entity.ReasonReopen
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+func TestCheckTransition(t *testing.T) {
+	statuses := []entity.PRStatus{entity.StatusDraft, entity.StatusOpen, entity.StatusMerged, entity.StatusClosed}
+	allowed := map[[2]entity.PRStatus]bool{
+		{entity.StatusDraft, entity.StatusOpen}:   true,
+		{entity.StatusDraft, entity.StatusClosed}: true,
+		{entity.StatusOpen, entity.StatusMerged}:  true,
+		{entity.StatusOpen, entity.StatusClosed}:  true,
+		{entity.StatusClosed, entity.StatusOpen}:  true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			err := checkTransition(from, to)
+			if allowed[[2]entity.PRStatus{from, to}] {
+				if err != nil {
+					t.Errorf("%s -> %s: unexpected error %v", from, to, err)
+				}
+				continue
+			}
+			var appErr entity.AppError
+			if !errors.As(err, &appErr) || appErr.Code != entity.ErrorInvalidTransition {
+				t.Errorf("%s -> %s: want INVALID_TRANSITION, got %v", from, to, err)
+			}
+		}
+	}
+}
+
+func TestPRLifecycle(t *testing.T) {
+	tests := []struct {
+		name  string
+		draft bool
+		// steps выполняются по порядку, ошибку может вернуть только последний
+		steps         []string
+		wantStatus    entity.PRStatus
+		wantReviewers int
+		wantCode      entity.ErrorCode
+	}{
+		{name: "draft has no reviewers", draft: true, wantStatus: entity.StatusDraft},
+		{name: "draft ready", draft: true, steps: []string{"ready"}, wantStatus: entity.StatusOpen, wantReviewers: 2},
+		{name: "draft merge", draft: true, steps: []string{"merge"}, wantCode: entity.ErrorInvalidTransition},
+		{name: "draft reopen", draft: true, steps: []string{"reopen"}, wantCode: entity.ErrorInvalidTransition},
+		{name: "closed draft reopened with reviewers", draft: true, steps: []string{"close", "reopen"}, wantStatus: entity.StatusOpen, wantReviewers: 2},
+		{name: "open close", steps: []string{"close"}, wantStatus: entity.StatusClosed, wantReviewers: 2},
+		{name: "open close twice", steps: []string{"close", "close"}, wantStatus: entity.StatusClosed, wantReviewers: 2},
+		{name: "open close reopen", steps: []string{"close", "reopen"}, wantStatus: entity.StatusOpen, wantReviewers: 2},
+		{name: "open ready is no-op", steps: []string{"ready"}, wantStatus: entity.StatusOpen, wantReviewers: 2},
+		{name: "open merge", steps: []string{"merge"}, wantStatus: entity.StatusMerged, wantReviewers: 2},
+		{name: "merge is idempotent", steps: []string{"merge", "merge"}, wantStatus: entity.StatusMerged, wantReviewers: 2},
+		{name: "merged close", steps: []string{"merge", "close"}, wantCode: entity.ErrorInvalidTransition},
+		{name: "merged reopen", steps: []string{"merge", "reopen"}, wantCode: entity.ErrorInvalidTransition},
+		{name: "closed merge", steps: []string{"close", "merge"}, wantCode: entity.ErrorInvalidTransition},
+		{name: "closed ready", steps: []string{"close", "ready"}, wantCode: entity.ErrorInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uc := newTestUseCases(t, StrategyRandom)
+			createTeam(t, uc, "backend", "a", "b", "c")
+
+			pr, err := uc.PR.CreatePR(ctx, "pr-1", "lifecycle", "a", tt.draft)
+			if err != nil {
+				t.Fatalf("CreatePR: %v", err)
+			}
+
+			ops := map[string]func(context.Context, string) (*entity.PullRequest, error){
+				"ready":  uc.PR.ReadyPR,
+				"close":  uc.PR.ClosePR,
+				"reopen": uc.PR.ReopenPR,
+				"merge":  uc.PR.MergePR,
+			}
+			for i, step := range tt.steps {
+				pr, err = ops[step](ctx, "pr-1")
+				if err != nil && i < len(tt.steps)-1 {
+					t.Fatalf("%s: %v", step, err)
+				}
+			}
+
+			if tt.wantCode != "" {
+				var appErr entity.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Fatalf("want %s, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pr.Status != tt.wantStatus || len(pr.AssignedReviewers) != tt.wantReviewers {
+				t.Errorf("status=%s reviewers=%v, want %s with %d reviewers",
+					pr.Status, pr.AssignedReviewers, tt.wantStatus, tt.wantReviewers)
+			}
+
+			// Сохраненное состояние совпадает с возвращенным
+			stored, err := uc.PR.GetPR(ctx, "pr-1")
+			if err != nil {
+				t.Fatalf("GetPR: %v", err)
+			}
+			if stored.Status != tt.wantStatus || len(stored.AssignedReviewers) != tt.wantReviewers {
+				t.Errorf("stored status=%s reviewers=%v", stored.Status, stored.AssignedReviewers)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// reviewer_selector.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+)
+
+// Названия стратегий выбора ревьюверов (значения REVIEWER_STRATEGY)
+const (
+	StrategyRandom      = "random"
+	StrategyLeastLoaded = "least_loaded"
+)
+
+// ReviewerSelector - стратегия выбора ревьюверов из списка кандидатов
+type ReviewerSelector interface {
+	Select(ctx context.Context, candidates []entity.User, max int) ([]string, error)
+}
+
+// NewReviewerSelector возвращает стратегию по её названию
+func NewReviewerSelector(name string, prRepo repository.PRRepository) (ReviewerSelector, error) {
+	switch name {
+	case "", StrategyRandom:
+		return &randomSelector{}, nil
+	case StrategyLeastLoaded:
+		return &leastLoadedSelector{prRepo: prRepo}, nil
+	default:
+		return nil, fmt.Errorf("unknown reviewer strategy: %s", name)
+	}
+}
+
+// IsKnownStrategy проверяет, что стратегия с таким названием существует
+func IsKnownStrategy(name string) bool {
+	switch name {
+	case "", StrategyRandom, StrategyLeastLoaded:
+		return true
+	default:
+		return false
+	}
+}
+
+// teamSettingsOrDefault возвращает настройки команды или настройки по умолчанию
+func teamSettingsOrDefault(ctx context.Context, teamRepo repository.TeamRepository, teamName string) (*entity.TeamSettings, error) {
+	settings, err := teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = entity.DefaultTeamSettings(teamName)
+	}
+	return settings, nil
+}
+
+// selectorForTeam возвращает стратегию команды или стратегию сервиса по умолчанию
+func selectorForTeam(settings *entity.TeamSettings, fallback ReviewerSelector, prRepo repository.PRRepository) (ReviewerSelector, error) {
+	if settings.Strategy == "" {
+		return fallback, nil
+	}
+	return NewReviewerSelector(settings.Strategy, prRepo)
+}
+
+// candidateLoad возвращает текущую загрузку кандидатов, если стратегия её учитывает
+func candidateLoad(ctx context.Context, selector ReviewerSelector, candidates []entity.User) (map[string]int, error) {
+	s, ok := selector.(*leastLoadedSelector)
+	if !ok {
+		return map[string]int{}, nil
+	}
+
+	ids := make([]string, len(candidates))
+	for i, user := range candidates {
+		ids[i] = user.ID
+	}
+	return s.prRepo.CountOpenReviews(ctx, ids)
+}
+
+// replacementChooser возвращает функцию выбора замены выбывшему ревьюверу по стратегии команды.
+// Загрузку кандидатов считает один раз до транзакции, далее учитывает назначения внутри этой же операции,
+// чтобы распределить слоты равномерно и не превысить лимиты открытых ревью
+func replacementChooser(
+	ctx context.Context,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PRRepository,
+	fallback ReviewerSelector,
+	teamName string,
+	candidates []entity.User,
+) (repository.ReplacementFunc, error) {
+	settings, err := teamSettingsOrDefault(ctx, teamRepo, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("teamSettingsOrDefault: %w", err)
+	}
+
+	selector, err := selectorForTeam(settings, fallback, prRepo)
+	if err != nil {
+		return nil, fmt.Errorf("selectorForTeam: %w", err)
+	}
+
+	load, err := candidateLoad(ctx, selector, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("candidateLoad: %w", err)
+	}
+
+	// Кандидаты, достигшие лимита открытых ревью, замену не получают
+	capacity, err := newReviewCapacity(ctx, prRepo, settings, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("newReviewCapacity: %w", err)
+	}
+
+	// Загрузка и лимиты известны только для кандидатов, посчитанных заранее:
+	// остальных, появившихся в выборке репозитория, не назначаем
+	known := make(map[string]bool, len(candidates))
+	for _, user := range candidates {
+		known[user.ID] = true
+	}
+
+	return func(ctx context.Context, pr *entity.PullRequest, oldUserID string, candidates []entity.User) (string, error) {
+		counted := make([]entity.User, 0, len(candidates))
+		for _, user := range candidates {
+			if known[user.ID] {
+				counted = append(counted, user)
+			}
+		}
+
+		free, _ := capacity.free(counted)
+		newUserID := pickLeastLoaded(free, load)
+		if newUserID != "" {
+			load[newUserID]++
+			capacity.take(newUserID)
+		}
+		return newUserID, nil
+	}, nil
+}
+
+// pickLeastLoaded выбирает кандидата с минимальной загрузкой, ничьи разрешаются случайно
+func pickLeastLoaded(candidates []entity.User, load map[string]int) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	var best []string
+	for _, user := range candidates {
+		switch {
+		case len(best) == 0 || load[user.ID] < load[best[0]]:
+			best = []string{user.ID}
+		case load[user.ID] == load[best[0]]:
+			best = append(best, user.ID)
+		}
+	}
+	return best[rand.Intn(len(best))]
+}
+
+// randomSelector выбирает ревьюверов случайно
+type randomSelector struct{}
+
+func (s *randomSelector) Select(ctx context.Context, candidates []entity.User, max int) ([]string, error) {
+	if len(candidates) == 0 || max <= 0 {
+		return []string{}, nil
+	}
+
+	// Перемешиваем пользователей
+	shuffled := make([]entity.User, len(candidates))
+	copy(shuffled, candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	// Берем до max пользователей
+	count := len(shuffled)
+	if count > max {
+		count = max
+	}
+
+	reviewers := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		reviewers = append(reviewers, shuffled[i].ID)
+	}
+
+	return reviewers, nil
+}
+
+// leastLoadedSelector выбирает наименее загруженных ревьюверов
+// (по количеству OPEN PR, на которые они назначены), ничьи разрешаются случайно
+type leastLoadedSelector struct {
+	prRepo repository.PRRepository
+}
+
+func (s *leastLoadedSelector) Select(ctx context.Context, candidates []entity.User, max int) ([]string, error) {
+	if len(candidates) == 0 || max <= 0 {
+		return []string{}, nil
+	}
+
+	ids := make([]string, len(candidates))
+	for i, user := range candidates {
+		ids[i] = user.ID
+	}
+
+	load, err := s.prRepo.CountOpenReviews(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("leastLoadedSelector - Select - CountOpenReviews: %w", err)
+	}
+
+	// Перемешиваем до стабильной сортировки, чтобы ничьи разрешались случайно
+	rand.Shuffle(len(ids), func(i, j int) {
+		ids[i], ids[j] = ids[j], ids[i]
+	})
+	sort.SliceStable(ids, func(i, j int) bool {
+		return load[ids[i]] < load[ids[j]]
+	})
+
+	if len(ids) > max {
+		ids = ids[:max]
+	}
+
+	return ids, nil
+}
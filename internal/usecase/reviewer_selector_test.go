@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+)
+
+// loadRepo возвращает заданную загрузку ревьюверов
+type loadRepo struct {
+	repository.PRRepository
+	load map[string]int
+}
+
+func (r *loadRepo) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		counts[id] = r.load[id]
+	}
+	return counts, nil
+}
+
+func (r *loadRepo) CountOpenReviewsForUpdate(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return r.CountOpenReviews(ctx, userIDs)
+}
+
+// settingsRepo возвращает заданные настройки команды
+type settingsRepo struct {
+	repository.TeamRepository
+	settings *entity.TeamSettings
+}
+
+func (r *settingsRepo) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	return r.settings, nil
+}
+
+func testUsers(ids ...string) []entity.User {
+	result := make([]entity.User, len(ids))
+	for i, id := range ids {
+		result[i] = entity.User{ID: id, IsActive: true}
+	}
+	return result
+}
+
+func TestLeastLoadedSelectorTieBreaking(t *testing.T) {
+	const runs = 200
+
+	tests := []struct {
+		name       string
+		load       map[string]int
+		candidates []string
+		max        int
+		// want - все возможные результаты (отсортированные id через запятую), каждый должен встретиться
+		want []string
+	}{
+		{
+			name:       "least loaded wins",
+			load:       map[string]int{"a": 3, "b": 1, "c": 2},
+			candidates: []string{"a", "b", "c"},
+			max:        1,
+			want:       []string{"b"},
+		},
+		{
+			name:       "tie broken randomly",
+			load:       map[string]int{"a": 1, "b": 1, "c": 5},
+			candidates: []string{"a", "b", "c"},
+			max:        1,
+			want:       []string{"a", "b"},
+		},
+		{
+			name:       "least loaded taken before tie",
+			load:       map[string]int{"a": 2, "b": 0, "c": 2, "d": 3},
+			candidates: []string{"a", "b", "c", "d"},
+			max:        2,
+			want:       []string{"a,b", "b,c"},
+		},
+		{
+			name:       "all tied",
+			load:       map[string]int{},
+			candidates: []string{"a", "b", "c"},
+			max:        2,
+			want:       []string{"a,b", "a,c", "b,c"},
+		},
+		{
+			name:       "fewer candidates than max",
+			load:       map[string]int{"a": 4, "b": 0},
+			candidates: []string{"a", "b"},
+			max:        3,
+			want:       []string{"a,b"},
+		},
+		{
+			name:       "no candidates",
+			candidates: nil,
+			max:        2,
+			want:       []string{""},
+		},
+		{
+			name:       "zero max",
+			candidates: []string{"a"},
+			max:        0,
+			want:       []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := &leastLoadedSelector{prRepo: &loadRepo{load: tt.load}}
+
+			seen := map[string]int{}
+			for i := 0; i < runs; i++ {
+				ids, err := selector.Select(context.Background(), testUsers(tt.candidates...), tt.max)
+				if err != nil {
+					t.Fatalf("Select: %v", err)
+				}
+				// Ревьюверы упорядочены по загрузке
+				for j := 1; j < len(ids); j++ {
+					if tt.load[ids[j-1]] > tt.load[ids[j]] {
+						t.Fatalf("reviewers %v are not ordered by load", ids)
+					}
+				}
+				sort.Strings(ids)
+				seen[strings.Join(ids, ",")]++
+			}
+
+			for _, want := range tt.want {
+				if seen[want] == 0 {
+					t.Errorf("result %q never selected in %d runs: %v", want, runs, seen)
+				}
+				delete(seen, want)
+			}
+			if len(seen) > 0 {
+				t.Errorf("unexpected results: %v", seen)
+			}
+		})
+	}
+}
+
+func TestPickLeastLoaded(t *testing.T) {
+	const runs = 200
+
+	tests := []struct {
+		name       string
+		load       map[string]int
+		candidates []string
+		want       []string
+	}{
+		{name: "single least loaded", load: map[string]int{"a": 2, "b": 1}, candidates: []string{"a", "b"}, want: []string{"b"}},
+		{name: "tie broken randomly", load: map[string]int{"a": 1, "b": 1, "c": 2}, candidates: []string{"a", "b", "c"}, want: []string{"a", "b"}},
+		{name: "missing load counts as zero", load: map[string]int{"a": 1}, candidates: []string{"a", "b"}, want: []string{"b"}},
+		{name: "no candidates", candidates: nil, want: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := map[string]int{}
+			for i := 0; i < runs; i++ {
+				seen[pickLeastLoaded(testUsers(tt.candidates...), tt.load)]++
+			}
+			for _, want := range tt.want {
+				if seen[want] == 0 {
+					t.Errorf("%q never picked in %d runs: %v", want, runs, seen)
+				}
+				delete(seen, want)
+			}
+			if len(seen) > 0 {
+				t.Errorf("unexpected picks: %v", seen)
+			}
+		})
+	}
+}
+
+func TestReplacementChooserSkipsUncountedCandidates(t *testing.T) {
+	const runs = 50
+
+	settings := entity.DefaultTeamSettings("backend")
+	settings.Strategy = StrategyLeastLoaded
+	settings.MaxOpenReviews = 2
+	prRepo := &loadRepo{load: map[string]int{"a": 1, "b": 0, "full": 2}}
+
+	tests := []struct {
+		name       string
+		counted    []string
+		candidates []string
+		want       string
+	}{
+		// "b" свободнее "a", но его загрузка и лимит не посчитаны
+		{name: "uncounted candidate skipped", counted: []string{"a"}, candidates: []string{"a", "b"}, want: "a"},
+		// Без предварительного подсчета "full" выглядел бы свободным
+		{name: "uncounted full candidate skipped", counted: []string{"a"}, candidates: []string{"full"}, want: ""},
+		{name: "only uncounted candidates", counted: nil, candidates: []string{"b"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < runs; i++ {
+				choose, err := replacementChooser(context.Background(), &settingsRepo{settings: settings}, prRepo,
+					&randomSelector{}, settings.TeamName, testUsers(tt.counted...))
+				if err != nil {
+					t.Fatalf("replacementChooser: %v", err)
+				}
+
+				got, err := choose(context.Background(), &entity.PullRequest{ID: "pr"}, "old", testUsers(tt.candidates...))
+				if err != nil {
+					t.Fatalf("choose: %v", err)
+				}
+				if got != tt.want {
+					t.Fatalf("chose %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
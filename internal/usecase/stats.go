@@ -0,0 +1,60 @@
+// stats.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+// StatsUseCase интерфейс для получения статистики назначений
+type StatsUseCase interface {
+	GetAssignmentStats(ctx context.Context, filter entity.StatsFilter) (*entity.AssignmentStats, error)
+}
+
+type statsUseCase struct {
+	statsRepo repository.StatsRepository
+	teamRepo  repository.TeamRepository
+	logger    logger.Interface
+}
+
+func NewStatsUseCase(statsRepo repository.StatsRepository, teamRepo repository.TeamRepository, l logger.Interface) StatsUseCase {
+	return &statsUseCase{
+		statsRepo: statsRepo,
+		teamRepo:  teamRepo,
+		logger:    l,
+	}
+}
+
+func (uc *statsUseCase) GetAssignmentStats(ctx context.Context, filter entity.StatsFilter) (*entity.AssignmentStats, error) {
+	uc.logger.Debug("Getting assignment stats: %+v", filter)
+
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "from must not be after to")
+	}
+
+	// Проверяем существование команды, если фильтр задан
+	if filter.TeamName != "" {
+		exists, err := uc.teamRepo.TeamExists(ctx, filter.TeamName)
+		if err != nil {
+			uc.logger.Error("Failed to check team existence: %v", err)
+			return nil, fmt.Errorf("statsUseCase - GetAssignmentStats - TeamExists: %w", err)
+		}
+		if !exists {
+			uc.logger.Warn("Team not found: %s", filter.TeamName)
+			return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+		}
+	}
+
+	stats, err := uc.statsRepo.GetAssignmentStats(ctx, filter)
+	if err != nil {
+		uc.logger.Error("Failed to get assignment stats: %v", err)
+		return nil, fmt.Errorf("statsUseCase - GetAssignmentStats - GetAssignmentStats: %w", err)
+	}
+
+	uc.logger.Debug("Assignment stats collected for %d users", len(stats.Users))
+	return stats, nil
+}
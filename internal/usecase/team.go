@@ -3,36 +3,79 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/PaulLocust/Avito-review/internal/entity"
 	"github.com/PaulLocust/Avito-review/internal/repository"
 	"github.com/PaulLocust/Avito-review/pkg/logger"
 )
 
-
 type TeamUseCase interface {
 	CreateTeam(ctx context.Context, team entity.Team) error
 	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
+	// SetTeamSettings сохраняет настройки команды; поля update со значением nil не меняются
+	SetTeamSettings(ctx context.Context, update entity.TeamSettingsUpdate) (*entity.TeamSettings, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error)
+	DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*entity.TeamDeactivation, error)
+	ListTeams(ctx context.Context) ([]entity.TeamSummary, error)
+	// AddMembers добавляет или обновляет участников существующей команды.
+	// Открытые ревью участников, перешедших из других команд, переназначаются внутри прежних команд
+	AddMembers(ctx context.Context, teamName string, members []entity.TeamMember) (*entity.TeamChange, error)
+	// RemoveMembers мягко удаляет участников команды и переназначает их открытые ревью
+	RemoveMembers(ctx context.Context, teamName string, userIDs []string) (*entity.TeamChange, error)
+	RenameTeam(ctx context.Context, oldName, newName string) (*entity.Team, error)
+	// DeleteTeam мягко удаляет команду и ее участников. Если у авторов из команды есть незавершенные PR,
+	// без force возвращает TEAM_HAS_OPEN_PRS, с force - закрывает их
+	DeleteTeam(ctx context.Context, teamName string, force bool) (*entity.TeamDeletion, error)
+	// SyncTeam приводит состав команды к переданному списку участников; с dryRun только возвращает расхождение
+	SyncTeam(ctx context.Context, team entity.Team, dryRun bool) (*entity.TeamSync, error)
 }
 
 type teamUseCase struct {
-	teamRepo repository.TeamRepository
-	userRepo repository.UserRepository
-	logger   logger.Interface
+	teamRepo   repository.TeamRepository
+	userRepo   repository.UserRepository
+	prRepo     repository.PRRepository
+	eventRepo  repository.EventRepository
+	outboxRepo repository.OutboxRepository
+	tx         repository.Transactor
+	selector   ReviewerSelector
+	logger     logger.Interface
 }
 
-func NewTeamUseCase(teamRepo repository.TeamRepository, userRepo repository.UserRepository, l logger.Interface) TeamUseCase {
+func NewTeamUseCase(
+	teamRepo repository.TeamRepository,
+	userRepo repository.UserRepository,
+	prRepo repository.PRRepository,
+	eventRepo repository.EventRepository,
+	outboxRepo repository.OutboxRepository,
+	tx repository.Transactor,
+	selector ReviewerSelector,
+	l logger.Interface,
+) TeamUseCase {
 	return &teamUseCase{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
-		logger:   l,
+		teamRepo:   teamRepo,
+		userRepo:   userRepo,
+		prRepo:     prRepo,
+		eventRepo:  eventRepo,
+		outboxRepo: outboxRepo,
+		tx:         tx,
+		selector:   selector,
+		logger:     l,
 	}
 }
 
 func (uc *teamUseCase) CreateTeam(ctx context.Context, team entity.Team) error {
 	uc.logger.Info("Creating team: %s with %d members", team.Name, len(team.Members))
 
+	// Пользователь токена может менять только свою команду
+	if err := authorizeTeam(ctx, uc.userRepo, team.Name); err != nil {
+		uc.logger.Warn("Team %s modification denied: %v", team.Name, err)
+		return err
+	}
+
 	// Проверяем существование команды
 	exists, err := uc.teamRepo.TeamExists(ctx, team.Name)
 	if err != nil {
@@ -66,4 +109,592 @@ func (uc *teamUseCase) GetTeam(ctx context.Context, teamName string) (*entity.Te
 
 	uc.logger.Debug("Team found: %s with %d members", teamName, len(team.Members))
 	return team, nil
-}
\ No newline at end of file
+}
+
+func (uc *teamUseCase) SetTeamSettings(ctx context.Context, update entity.TeamSettingsUpdate) (*entity.TeamSettings, error) {
+	uc.logger.Info("Setting team settings: %s", update.TeamName)
+
+	// Пользователь токена может менять только свою команду
+	if err := authorizeTeam(ctx, uc.userRepo, update.TeamName); err != nil {
+		uc.logger.Warn("Team %s modification denied: %v", update.TeamName, err)
+		return nil, err
+	}
+
+	// Проверяем существование команды
+	exists, err := uc.teamRepo.TeamExists(ctx, update.TeamName)
+	if err != nil {
+		uc.logger.Error("Failed to check team existence: %v", err)
+		return nil, fmt.Errorf("teamUseCase - SetTeamSettings - TeamExists: %w", err)
+	}
+	if !exists {
+		uc.logger.Warn("Team not found: %s", update.TeamName)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+	}
+
+	// Незаданные поля берутся из текущих настроек, чтобы изменение одного параметра не сбрасывало остальные
+	var settings entity.TeamSettings
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := teamSettingsOrDefault(ctx, uc.teamRepo, update.TeamName)
+		if err != nil {
+			uc.logger.Error("Failed to get team settings: %v", err)
+			return fmt.Errorf("teamUseCase - SetTeamSettings - teamSettingsOrDefault: %w", err)
+		}
+		settings = update.Apply(*current)
+
+		if err := validateTeamSettings(settings); err != nil {
+			return err
+		}
+
+		if err := uc.teamRepo.UpsertTeamSettings(ctx, &settings); err != nil {
+			uc.logger.Error("Failed to save team settings: %v", err)
+			return fmt.Errorf("teamUseCase - SetTeamSettings - UpsertTeamSettings: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("Team settings updated: %s", settings.TeamName)
+	return &settings, nil
+}
+
+// validateTeamSettings проверяет согласованность настроек команды
+func validateTeamSettings(settings entity.TeamSettings) error {
+	if settings.ReviewerCount < 0 || settings.MinRequired < 0 || settings.RequiredApprovals < 0 || settings.MaxOpenReviews < 0 {
+		return entity.NewAppError(entity.ErrorInvalidInput,
+			"reviewer_count, min_required, required_approvals and max_open_reviews must be non-negative")
+	}
+	if settings.MinRequired > settings.ReviewerCount {
+		return entity.NewAppError(entity.ErrorInvalidInput, "min_required must not exceed reviewer_count")
+	}
+	if settings.RequiredApprovals > settings.ReviewerCount {
+		return entity.NewAppError(entity.ErrorInvalidInput, "required_approvals must not exceed reviewer_count")
+	}
+	if !IsKnownStrategy(settings.Strategy) {
+		return entity.NewAppError(entity.ErrorInvalidInput, "unknown strategy")
+	}
+	return nil
+}
+
+func (uc *teamUseCase) GetTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	uc.logger.Debug("Getting team settings: %s", teamName)
+
+	exists, err := uc.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		uc.logger.Error("Failed to check team existence: %v", err)
+		return nil, fmt.Errorf("teamUseCase - GetTeamSettings - TeamExists: %w", err)
+	}
+	if !exists {
+		uc.logger.Warn("Team not found: %s", teamName)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+	}
+
+	settings, err := uc.teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		uc.logger.Error("Failed to get team settings: %v", err)
+		return nil, fmt.Errorf("teamUseCase - GetTeamSettings - GetTeamSettings: %w", err)
+	}
+	if settings == nil {
+		settings = entity.DefaultTeamSettings(teamName)
+	}
+
+	return settings, nil
+}
+
+func (uc *teamUseCase) DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*entity.TeamDeactivation, error) {
+	uc.logger.Info("Deactivating %d users in team %s", len(userIDs), teamName)
+
+	if teamName == "" || len(userIDs) == 0 {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "team_name and user_ids are required")
+	}
+
+	// Пользователь токена может менять только свою команду
+	if err := authorizeTeam(ctx, uc.userRepo, teamName); err != nil {
+		uc.logger.Warn("Team %s modification denied: %v", teamName, err)
+		return nil, err
+	}
+
+	// Проверяем, что все пользователи состоят в команде
+	team, err := uc.teamRepo.GetTeam(ctx, teamName)
+	if err != nil {
+		uc.logger.Warn("Team not found: %s", teamName)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+	}
+
+	members := make(map[string]bool, len(team.Members))
+	for _, member := range team.Members {
+		members[member.UserID] = true
+	}
+
+	toDeactivate := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if !members[userID] {
+			uc.logger.Warn("User %s is not a member of team %s", userID, teamName)
+			return nil, entity.NewAppError(entity.ErrorNotFound, fmt.Sprintf("user %s not found in team", userID))
+		}
+		toDeactivate[userID] = true
+	}
+
+	// Слоты деактивированных распределяются между оставшимися активными участниками
+	// с учетом их лимитов открытых ревью и периодов недоступности
+	choose, err := remainingMembersChooser(ctx, uc.userRepo, uc.teamRepo, uc.prRepo, uc.selector, teamName, toDeactivate, time.Now())
+	if err != nil {
+		uc.logger.Error("Failed to prepare reviewer replacement: %v", err)
+		return nil, fmt.Errorf("teamUseCase - DeactivateUsers - %w", err)
+	}
+
+	// Деактивация, записи журнала о снятых ревьюверах и событие в outbox фиксируются вместе
+	var result *entity.TeamDeactivation
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = uc.userRepo.DeactivateTeamUsers(ctx, teamName, userIDs, choose)
+		if err != nil {
+			uc.logger.Error("Failed to deactivate team users: %v", err)
+			return fmt.Errorf("teamUseCase - DeactivateUsers - DeactivateTeamUsers: %w", err)
+		}
+
+		if err := uc.eventRepo.AddEvents(ctx, deactivationEvents(result, actorFrom(ctx))); err != nil {
+			uc.logger.Error("Failed to record PR events: %v", err)
+			return fmt.Errorf("teamUseCase - DeactivateUsers - AddEvents: %w", err)
+		}
+
+		if err := enqueueEvent(ctx, uc.outboxRepo, entity.WebhookUserDeactivated, result); err != nil {
+			uc.logger.Error("Failed to enqueue event: %v", err)
+			return fmt.Errorf("teamUseCase - DeactivateUsers - %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("Deactivated %d users in team %s, %d reviewer slots changed",
+		len(result.DeactivatedUsers), teamName, len(result.Reassignments))
+	return result, nil
+}
+
+func (uc *teamUseCase) ListTeams(ctx context.Context) ([]entity.TeamSummary, error) {
+	uc.logger.Debug("Listing teams")
+
+	teams, err := uc.teamRepo.ListTeams(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to list teams: %v", err)
+		return nil, fmt.Errorf("teamUseCase - ListTeams - ListTeams: %w", err)
+	}
+
+	uc.logger.Debug("Found %d teams", len(teams))
+	return teams, nil
+}
+
+func (uc *teamUseCase) AddMembers(ctx context.Context, teamName string, members []entity.TeamMember) (*entity.TeamChange, error) {
+	uc.logger.Info("Adding %d members to team %s", len(members), teamName)
+
+	if teamName == "" || len(members) == 0 {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "team_name and members are required")
+	}
+	for _, member := range members {
+		if member.UserID == "" || member.Username == "" {
+			return nil, entity.NewAppError(entity.ErrorInvalidInput, "user_id and username are required")
+		}
+	}
+
+	// Пользователь токена может менять только свою команду
+	if err := authorizeTeam(ctx, uc.userRepo, teamName); err != nil {
+		uc.logger.Warn("Team %s modification denied: %v", teamName, err)
+		return nil, err
+	}
+
+	exists, err := uc.teamRepo.TeamExists(ctx, teamName)
+	if err != nil {
+		uc.logger.Error("Failed to check team existence: %v", err)
+		return nil, fmt.Errorf("teamUseCase - AddMembers - TeamExists: %w", err)
+	}
+	if !exists {
+		uc.logger.Warn("Team not found: %s", teamName)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+	}
+
+	moves, err := uc.planMoves(ctx, teamName, members)
+	if err != nil {
+		return nil, fmt.Errorf("teamUseCase - AddMembers - %w", err)
+	}
+
+	// Участники, замены в ревью переходящих, журнал и события фиксируются вместе
+	result := &entity.TeamChange{Reassignments: []entity.ReviewerReassignment{}}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, member := range members {
+			err := uc.userRepo.CreateOrUpdateUser(ctx, &entity.User{
+				ID:       member.UserID,
+				Username: member.Username,
+				TeamName: teamName,
+				IsActive: member.IsActive,
+			})
+			if err != nil {
+				uc.logger.Error("Failed to save member %s: %v", member.UserID, err)
+				return fmt.Errorf("teamUseCase - AddMembers - CreateOrUpdateUser: %w", err)
+			}
+		}
+
+		var err error
+		result.Reassignments, err = uc.applyMoves(ctx, moves)
+		if err != nil {
+			return fmt.Errorf("teamUseCase - AddMembers - %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := uc.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	result.Team = *team
+
+	uc.logger.Info("Added %d members to team %s, %d open reviews reassigned", len(members), teamName, len(result.Reassignments))
+	return result, nil
+}
+
+func (uc *teamUseCase) RemoveMembers(ctx context.Context, teamName string, userIDs []string) (*entity.TeamChange, error) {
+	uc.logger.Info("Removing %d members from team %s", len(userIDs), teamName)
+
+	if teamName == "" || len(userIDs) == 0 {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "team_name and user_ids are required")
+	}
+
+	// Пользователь токена может менять только свою команду
+	if err := authorizeTeam(ctx, uc.userRepo, teamName); err != nil {
+		uc.logger.Warn("Team %s modification denied: %v", teamName, err)
+		return nil, err
+	}
+
+	// Проверяем, что все пользователи состоят в команде
+	team, err := uc.teamRepo.GetTeam(ctx, teamName)
+	if err != nil {
+		uc.logger.Warn("Team not found: %s", teamName)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+	}
+
+	members := make(map[string]bool, len(team.Members))
+	for _, member := range team.Members {
+		members[member.UserID] = true
+	}
+
+	leaving := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if !members[userID] {
+			uc.logger.Warn("User %s is not a member of team %s", userID, teamName)
+			return nil, entity.NewAppError(entity.ErrorNotFound, fmt.Sprintf("user %s not found in team", userID))
+		}
+		leaving[userID] = true
+	}
+
+	// Ревью удаляемых распределяются между оставшимися активными участниками
+	choose, err := remainingMembersChooser(ctx, uc.userRepo, uc.teamRepo, uc.prRepo, uc.selector, teamName, leaving, time.Now())
+	if err != nil {
+		uc.logger.Error("Failed to prepare reviewer replacement: %v", err)
+		return nil, fmt.Errorf("teamUseCase - RemoveMembers - %w", err)
+	}
+
+	result := &entity.TeamChange{}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		result.Reassignments, err = uc.deleteMembers(ctx, teamName, sortedKeys(leaving), choose)
+		if err != nil {
+			return fmt.Errorf("teamUseCase - RemoveMembers - %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err = uc.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	result.Team = *team
+
+	uc.logger.Info("Removed %d members from team %s, %d reviewer slots changed", len(leaving), teamName, len(result.Reassignments))
+	return result, nil
+}
+
+func (uc *teamUseCase) RenameTeam(ctx context.Context, oldName, newName string) (*entity.Team, error) {
+	uc.logger.Info("Renaming team %s to %s", oldName, newName)
+
+	if oldName == "" || newName == "" {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "team_name and new_team_name are required")
+	}
+	if oldName == newName {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "new_team_name must differ from team_name")
+	}
+
+	// Пользователь токена может менять только свою команду
+	if err := authorizeTeam(ctx, uc.userRepo, oldName); err != nil {
+		uc.logger.Warn("Team %s modification denied: %v", oldName, err)
+		return nil, err
+	}
+
+	err := uc.teamRepo.RenameTeam(ctx, oldName, newName)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("Team not found: %s", oldName)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+	}
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		uc.logger.Warn("Team already exists: %s", newName)
+		return nil, entity.NewAppError(entity.ErrorTeamExists, "team already exists")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to rename team: %v", err)
+		return nil, fmt.Errorf("teamUseCase - RenameTeam - RenameTeam: %w", err)
+	}
+
+	uc.logger.Info("Team %s renamed to %s", oldName, newName)
+	return uc.GetTeam(ctx, newName)
+}
+
+func (uc *teamUseCase) DeleteTeam(ctx context.Context, teamName string, force bool) (*entity.TeamDeletion, error) {
+	uc.logger.Info("Deleting team %s (force=%v)", teamName, force)
+
+	if teamName == "" {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "team_name is required")
+	}
+
+	// Пользователь токена может менять только свою команду
+	if err := authorizeTeam(ctx, uc.userRepo, teamName); err != nil {
+		uc.logger.Warn("Team %s modification denied: %v", teamName, err)
+		return nil, err
+	}
+
+	team, err := uc.teamRepo.GetTeam(ctx, teamName)
+	if err != nil {
+		uc.logger.Warn("Team not found: %s", teamName)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+	}
+
+	userIDs := make([]string, len(team.Members))
+	for i, member := range team.Members {
+		userIDs[i] = member.UserID
+	}
+	sort.Strings(userIDs)
+
+	// Замен нет: команда удаляется целиком, ее участники снимаются с ревью
+	choose, err := replacementChooser(ctx, uc.teamRepo, uc.prRepo, uc.selector, teamName, nil)
+	if err != nil {
+		uc.logger.Error("Failed to prepare reviewer replacement: %v", err)
+		return nil, fmt.Errorf("teamUseCase - DeleteTeam - replacementChooser: %w", err)
+	}
+
+	// Закрытие PR, удаление участников и команды, журнал и событие фиксируются вместе
+	result := &entity.TeamDeletion{TeamName: teamName, DeletedUsers: userIDs, ClosedPullRequests: []string{}}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		prIDs, err := uc.prRepo.GetUnfinishedPRIDsByTeam(ctx, teamName)
+		if err != nil {
+			uc.logger.Error("Failed to get unfinished PRs: %v", err)
+			return fmt.Errorf("teamUseCase - DeleteTeam - GetUnfinishedPRIDsByTeam: %w", err)
+		}
+		if len(prIDs) > 0 && !force {
+			uc.logger.Warn("Team %s has %d unfinished PRs", teamName, len(prIDs))
+			return entity.NewAppError(entity.ErrorTeamHasOpenPRs,
+				fmt.Sprintf("team has %d open or draft PRs, use force to close them", len(prIDs)))
+		}
+
+		for _, prID := range prIDs {
+			if err := uc.closePR(ctx, prID); err != nil {
+				return fmt.Errorf("teamUseCase - DeleteTeam - %w", err)
+			}
+		}
+		result.ClosedPullRequests = prIDs
+
+		result.Reassignments, err = uc.deleteMembers(ctx, teamName, userIDs, choose)
+		if err != nil {
+			return fmt.Errorf("teamUseCase - DeleteTeam - %w", err)
+		}
+
+		err = uc.teamRepo.DeleteTeam(ctx, teamName)
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.NewAppError(entity.ErrorNotFound, "team not found")
+		}
+		if err != nil {
+			uc.logger.Error("Failed to delete team: %v", err)
+			return fmt.Errorf("teamUseCase - DeleteTeam - DeleteTeam: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("Team %s deleted: %d users, %d PRs closed, %d reviewer slots changed",
+		teamName, len(result.DeletedUsers), len(result.ClosedPullRequests), len(result.Reassignments))
+	return result, nil
+}
+
+// memberMoves - участники, переходящие в команду из других команд
+type memberMoves struct {
+	oldTeams []string                              // прежние команды по возрастанию
+	users    map[string]map[string]bool            // прежняя команда -> переходящие пользователи
+	choosers map[string]repository.ReplacementFunc // выбор замены среди оставшихся в прежней команде
+}
+
+// planMoves находит среди members участников других команд и готовит переназначение их открытых ревью,
+// которые остаются в прежних командах. Вызывается до транзакции
+func (uc *teamUseCase) planMoves(ctx context.Context, teamName string, members []entity.TeamMember) (*memberMoves, error) {
+	moves := &memberMoves{
+		users:    make(map[string]map[string]bool),
+		choosers: make(map[string]repository.ReplacementFunc),
+	}
+	for _, member := range members {
+		user, err := uc.userRepo.GetUser(ctx, member.UserID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			uc.logger.Error("Failed to get user %s: %v", member.UserID, err)
+			return nil, fmt.Errorf("GetUser: %w", err)
+		}
+		if user.TeamName == teamName {
+			continue
+		}
+		if moves.users[user.TeamName] == nil {
+			moves.users[user.TeamName] = make(map[string]bool)
+			moves.oldTeams = append(moves.oldTeams, user.TeamName)
+		}
+		moves.users[user.TeamName][member.UserID] = true
+	}
+	sort.Strings(moves.oldTeams)
+
+	for _, oldTeam := range moves.oldTeams {
+		// Перевести пользователя можно только из команды, которой разрешено управлять
+		if err := authorizeTeam(ctx, uc.userRepo, oldTeam); err != nil {
+			uc.logger.Warn("Move from team %s denied: %v", oldTeam, err)
+			return nil, err
+		}
+		choose, err := remainingMembersChooser(ctx, uc.userRepo, uc.teamRepo, uc.prRepo, uc.selector, oldTeam, moves.users[oldTeam], time.Now())
+		if err != nil {
+			uc.logger.Error("Failed to prepare reviewer replacement: %v", err)
+			return nil, err
+		}
+		moves.choosers[oldTeam] = choose
+	}
+	return moves, nil
+}
+
+// applyMoves переназначает открытые ревью перешедших участников в прежних командах и публикует
+// reviewer.reassigned. Вызывается в транзакции после сохранения участников
+func (uc *teamUseCase) applyMoves(ctx context.Context, moves *memberMoves) ([]entity.ReviewerReassignment, error) {
+	all := []entity.ReviewerReassignment{}
+	for _, oldTeam := range moves.oldTeams {
+		for _, userID := range sortedKeys(moves.users[oldTeam]) {
+			reassignments, err := reassignReviews(ctx, uc.userRepo, uc.eventRepo, userID, oldTeam, moves.choosers[oldTeam], entity.ReasonUserMoved)
+			if err != nil {
+				uc.logger.Error("Failed to reassign user reviews: %v", err)
+				return nil, err
+			}
+			all = append(all, reassignments...)
+		}
+	}
+
+	for _, reassignment := range all {
+		if err := enqueueEvent(ctx, uc.outboxRepo, entity.WebhookReviewerReassigned, reassignment); err != nil {
+			uc.logger.Error("Failed to enqueue event: %v", err)
+			return nil, err
+		}
+	}
+	return all, nil
+}
+
+// deleteMembers мягко удаляет участников команды, переназначает их открытые ревью и публикует user.deactivated.
+// Сначала удаляются все участники, чтобы их ревью не переходили друг другу. Вызывается в транзакции
+func (uc *teamUseCase) deleteMembers(ctx context.Context, teamName string, userIDs []string, choose repository.ReplacementFunc) ([]entity.ReviewerReassignment, error) {
+	if len(userIDs) == 0 {
+		return []entity.ReviewerReassignment{}, nil
+	}
+
+	for _, userID := range userIDs {
+		if _, err := uc.userRepo.DeleteUser(ctx, userID); err != nil {
+			uc.logger.Error("Failed to delete user %s: %v", userID, err)
+			return nil, fmt.Errorf("DeleteUser: %w", err)
+		}
+	}
+
+	all := []entity.ReviewerReassignment{}
+	for _, userID := range userIDs {
+		reassignments, err := reassignReviews(ctx, uc.userRepo, uc.eventRepo, userID, teamName, choose, entity.ReasonUserDeleted)
+		if err != nil {
+			uc.logger.Error("Failed to reassign user reviews: %v", err)
+			return nil, err
+		}
+		all = append(all, reassignments...)
+	}
+
+	err := enqueueEvent(ctx, uc.outboxRepo, entity.WebhookUserDeactivated, entity.TeamDeactivation{
+		TeamName:         teamName,
+		DeactivatedUsers: userIDs,
+		Reassignments:    all,
+	})
+	if err != nil {
+		uc.logger.Error("Failed to enqueue event: %v", err)
+		return nil, err
+	}
+	return all, nil
+}
+
+// closePR закрывает незавершенный PR удаляемой команды под блокировкой строки PR, вызывается в транзакции
+func (uc *teamUseCase) closePR(ctx context.Context, prID string) error {
+	pr, err := uc.prRepo.GetPRForUpdate(ctx, prID)
+	if err != nil {
+		uc.logger.Error("Failed to lock PR %s: %v", prID, err)
+		return fmt.Errorf("GetPRForUpdate: %w", err)
+	}
+	if err := checkTransition(pr.Status, entity.StatusClosed); err != nil {
+		return err
+	}
+
+	event, _ := transitionEvent(pr.Status, entity.StatusClosed)
+	pr.Status = entity.StatusClosed
+	if err := uc.prRepo.UpdatePR(ctx, pr); err != nil {
+		uc.logger.Error("Failed to close PR %s: %v", prID, err)
+		return fmt.Errorf("UpdatePR: %w", err)
+	}
+
+	if err := uc.eventRepo.AddEvents(ctx, []entity.PREvent{entity.NewPREvent(prID, event, actorFrom(ctx))}); err != nil {
+		uc.logger.Error("Failed to record PR events: %v", err)
+		return fmt.Errorf("AddEvents: %w", err)
+	}
+	return nil
+}
+
+// sortedKeys возвращает ключи множества в порядке возрастания
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// deactivationEvents возвращает события журнала для слотов ревьюверов, изменённых деактивацией
+func deactivationEvents(result *entity.TeamDeactivation, actor string) []entity.PREvent {
+	return reassignmentEvents(result.Reassignments, entity.ReasonDeactivation, actor)
+}
+
+// reassignmentEvents возвращает события журнала для замен и снятий ревьюверов с указанной причиной
+func reassignmentEvents(reassignments []entity.ReviewerReassignment, reason, actor string) []entity.PREvent {
+	events := make([]entity.PREvent, 0, len(reassignments))
+	for _, reassignment := range reassignments {
+		eventType := entity.EventReviewerReplaced
+		if reassignment.NewUserID == "" {
+			eventType = entity.EventReviewerRemoved
+		}
+		event := entity.NewPREvent(reassignment.PullRequestID, eventType, actor)
+		event.OldUserID = reassignment.OldUserID
+		event.NewUserID = reassignment.NewUserID
+		event.Reason = reason
+		events = append(events, event)
+	}
+	return events
+}
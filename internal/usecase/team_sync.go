@@ -0,0 +1,175 @@
+// team_sync.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+// errDryRun откатывает транзакцию синхронизации в режиме dry_run
+var errDryRun = errors.New("dry run")
+
+// SyncTeam приводит состав команды к желаемому: создает команду, если ее нет, добавляет и обновляет
+// участников, деактивирует отсутствующих в списке и переназначает их открытые ревью.
+// В режиме dryRun изменения выполняются в транзакции и откатываются, поэтому план совпадает с применением
+// с точностью до случайного выбора ревьюверов
+func (uc *teamUseCase) SyncTeam(ctx context.Context, team entity.Team, dryRun bool) (*entity.TeamSync, error) {
+	uc.logger.Info("Syncing team %s with %d members (dry_run=%v)", team.Name, len(team.Members), dryRun)
+
+	if team.Name == "" {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "team_name is required")
+	}
+	desired := make(map[string]entity.TeamMember, len(team.Members))
+	for _, member := range team.Members {
+		if member.UserID == "" || member.Username == "" {
+			return nil, entity.NewAppError(entity.ErrorInvalidInput, "user_id and username are required")
+		}
+		if _, ok := desired[member.UserID]; ok {
+			return nil, entity.NewAppError(entity.ErrorInvalidInput, fmt.Sprintf("duplicate user_id %s", member.UserID))
+		}
+		desired[member.UserID] = member
+	}
+
+	// Пользователь токена может менять только свою команду
+	if err := authorizeTeam(ctx, uc.userRepo, team.Name); err != nil {
+		uc.logger.Warn("Team %s modification denied: %v", team.Name, err)
+		return nil, err
+	}
+
+	exists, err := uc.teamRepo.TeamExists(ctx, team.Name)
+	if err != nil {
+		uc.logger.Error("Failed to check team existence: %v", err)
+		return nil, fmt.Errorf("teamUseCase - SyncTeam - TeamExists: %w", err)
+	}
+
+	current := make(map[string]entity.TeamMember)
+	if exists {
+		existing, err := uc.teamRepo.GetTeam(ctx, team.Name)
+		if err != nil {
+			uc.logger.Error("Failed to get team: %v", err)
+			return nil, fmt.Errorf("teamUseCase - SyncTeam - GetTeam: %w", err)
+		}
+		for _, member := range existing.Members {
+			current[member.UserID] = member
+		}
+	}
+
+	result := &entity.TeamSync{
+		TeamName:      team.Name,
+		DryRun:        dryRun,
+		TeamCreated:   !exists,
+		Added:         []entity.TeamMember{},
+		Updated:       []entity.TeamMember{},
+		Deactivated:   []string{},
+		Reassignments: []entity.ReviewerReassignment{},
+	}
+
+	// Считаем расхождение. Деактивация идет через DeactivateTeamUsers, чтобы переназначить ревью,
+	// поэтому деактивируемые участники сохраняются активными
+	var upserts []entity.TeamMember
+	for _, member := range team.Members {
+		cur, ok := current[member.UserID]
+		if !ok {
+			result.Added = append(result.Added, member)
+			upserts = append(upserts, member)
+			continue
+		}
+		if cur.IsActive && !member.IsActive {
+			result.Deactivated = append(result.Deactivated, member.UserID)
+		}
+		if cur.Username != member.Username || (!cur.IsActive && member.IsActive) {
+			result.Updated = append(result.Updated, member)
+			upserts = append(upserts, entity.TeamMember{
+				UserID:   member.UserID,
+				Username: member.Username,
+				IsActive: cur.IsActive || member.IsActive,
+			})
+		}
+	}
+	for userID, cur := range current {
+		if _, ok := desired[userID]; !ok && cur.IsActive {
+			result.Deactivated = append(result.Deactivated, userID)
+		}
+	}
+	sort.Strings(result.Deactivated)
+
+	moves, err := uc.planMoves(ctx, team.Name, result.Added)
+	if err != nil {
+		return nil, fmt.Errorf("teamUseCase - SyncTeam - %w", err)
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if !exists {
+			if err := uc.teamRepo.CreateTeam(ctx, &entity.Team{Name: team.Name}); err != nil {
+				uc.logger.Error("Failed to create team: %v", err)
+				return fmt.Errorf("teamUseCase - SyncTeam - CreateTeam: %w", err)
+			}
+		}
+
+		for _, member := range upserts {
+			err := uc.userRepo.CreateOrUpdateUser(ctx, &entity.User{
+				ID:       member.UserID,
+				Username: member.Username,
+				TeamName: team.Name,
+				IsActive: member.IsActive,
+			})
+			if err != nil {
+				uc.logger.Error("Failed to save member %s: %v", member.UserID, err)
+				return fmt.Errorf("teamUseCase - SyncTeam - CreateOrUpdateUser: %w", err)
+			}
+		}
+
+		reassignments, err := uc.applyMoves(ctx, moves)
+		if err != nil {
+			return fmt.Errorf("teamUseCase - SyncTeam - %w", err)
+		}
+		result.Reassignments = append(result.Reassignments, reassignments...)
+
+		if len(result.Deactivated) > 0 {
+			// Слоты деактивированных распределяются между участниками, активными после синхронизации.
+			// Кандидаты читаются в транзакции после сохранения участников, чтобы учесть перешедших
+			// и восстановленных пользователей, их лимиты открытых ревью и периоды недоступности
+			leaving := make(map[string]bool, len(result.Deactivated))
+			for _, userID := range result.Deactivated {
+				leaving[userID] = true
+			}
+			choose, err := remainingMembersChooser(ctx, uc.userRepo, uc.teamRepo, uc.prRepo, uc.selector, team.Name, leaving, time.Now())
+			if err != nil {
+				uc.logger.Error("Failed to prepare reviewer replacement: %v", err)
+				return fmt.Errorf("teamUseCase - SyncTeam - %w", err)
+			}
+
+			deactivation, err := uc.userRepo.DeactivateTeamUsers(ctx, team.Name, result.Deactivated, choose)
+			if err != nil {
+				uc.logger.Error("Failed to deactivate team users: %v", err)
+				return fmt.Errorf("teamUseCase - SyncTeam - DeactivateTeamUsers: %w", err)
+			}
+			if err := uc.eventRepo.AddEvents(ctx, deactivationEvents(deactivation, actorFrom(ctx))); err != nil {
+				uc.logger.Error("Failed to record PR events: %v", err)
+				return fmt.Errorf("teamUseCase - SyncTeam - AddEvents: %w", err)
+			}
+			if err := enqueueEvent(ctx, uc.outboxRepo, entity.WebhookUserDeactivated, deactivation); err != nil {
+				uc.logger.Error("Failed to enqueue event: %v", err)
+				return fmt.Errorf("teamUseCase - SyncTeam - %w", err)
+			}
+			result.Reassignments = append(result.Reassignments, deactivation.Reassignments...)
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	uc.logger.Info("Team %s synced (dry_run=%v): %d added, %d updated, %d deactivated, %d reviewer slots changed",
+		team.Name, dryRun, len(result.Added), len(result.Updated), len(result.Deactivated), len(result.Reassignments))
+	return result, nil
+}
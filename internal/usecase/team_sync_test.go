@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+func TestSyncTeamDiff(t *testing.T) {
+	member := func(userID, username string, active bool) entity.TeamMember {
+		return entity.TeamMember{UserID: userID, Username: username, IsActive: active}
+	}
+	// Исходное состояние: backend = a, b (ревьювер pr-1 автора a), c неактивен; frontend = f
+	current := []entity.TeamMember{member("a", "a", true), member("b", "b", true), member("c", "c", false)}
+
+	tests := []struct {
+		name            string
+		team            string
+		members         []entity.TeamMember
+		dryRun          bool
+		wantCreated     bool
+		wantAdded       []string
+		wantUpdated     []string
+		wantDeactivated []string
+		// wantReplaced - новые ревьюверы слотов деактивированных ("" - слот снят без замены)
+		wantReplaced []string
+		// wantActive - активность участников команды после синхронизации
+		wantActive map[string]bool
+	}{
+		{
+			name:       "no changes",
+			team:       "backend",
+			members:    current,
+			wantActive: map[string]bool{"a": true, "b": true, "c": false},
+		},
+		{
+			name:        "new team",
+			team:        "platform",
+			members:     []entity.TeamMember{member("x", "x", true), member("y", "y", false)},
+			wantCreated: true,
+			wantAdded:   []string{"x", "y"},
+			wantActive:  map[string]bool{"x": true, "y": false},
+		},
+		{
+			name:        "renamed member",
+			team:        "backend",
+			members:     []entity.TeamMember{member("a", "Alice", true), member("b", "b", true), member("c", "c", false)},
+			wantUpdated: []string{"a"},
+			wantActive:  map[string]bool{"a": true, "b": true, "c": false},
+		},
+		{
+			name:            "missing member deactivated",
+			team:            "backend",
+			members:         []entity.TeamMember{member("a", "a", true), member("c", "c", false)},
+			wantDeactivated: []string{"b"},
+			wantReplaced:    []string{""},
+			wantActive:      map[string]bool{"a": true, "b": false, "c": false},
+		},
+		{
+			name:            "member passed inactive",
+			team:            "backend",
+			members:         []entity.TeamMember{member("a", "a", true), member("b", "b", false), member("c", "c", false)},
+			wantDeactivated: []string{"b"},
+			wantReplaced:    []string{""},
+			wantActive:      map[string]bool{"a": true, "b": false, "c": false},
+		},
+		{
+			name:       "missing inactive member is kept",
+			team:       "backend",
+			members:    []entity.TeamMember{member("a", "a", true), member("b", "b", true)},
+			wantActive: map[string]bool{"a": true, "b": true, "c": false},
+		},
+		{
+			name:            "reactivated member replaces deactivated",
+			team:            "backend",
+			members:         []entity.TeamMember{member("a", "a", true), member("c", "c", true)},
+			wantUpdated:     []string{"c"},
+			wantDeactivated: []string{"b"},
+			wantReplaced:    []string{"c"},
+			wantActive:      map[string]bool{"a": true, "b": false, "c": true},
+		},
+		{
+			name:            "member moved from another team",
+			team:            "backend",
+			members:         []entity.TeamMember{member("a", "a", true), member("c", "c", false), member("f", "f", true)},
+			wantAdded:       []string{"f"},
+			wantDeactivated: []string{"b"},
+			wantReplaced:    []string{"f"},
+			wantActive:      map[string]bool{"a": true, "b": false, "c": false, "f": true},
+		},
+		{
+			name:            "dry run",
+			team:            "backend",
+			members:         []entity.TeamMember{member("a", "Alice", true), member("c", "c", true), member("z", "z", true)},
+			dryRun:          true,
+			wantAdded:       []string{"z"},
+			wantUpdated:     []string{"a", "c"},
+			wantDeactivated: []string{"b"},
+			wantReplaced:    []string{"c|z"},
+			wantActive:      map[string]bool{"a": true, "b": true, "c": false},
+		},
+	}
+
+	ids := func(members []entity.TeamMember) []string {
+		result := []string{}
+		for _, m := range members {
+			result = append(result, m.UserID)
+		}
+		sort.Strings(result)
+		return result
+	}
+	orEmpty := func(s []string) []string {
+		if s == nil {
+			return []string{}
+		}
+		return s
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uc := newTestUseCases(t, StrategyRandom)
+			createTeam(t, uc, "backend", "a", "b", "c")
+			createTeam(t, uc, "frontend", "f")
+			if _, err := uc.User.SetUserActive(ctx, "c", false); err != nil {
+				t.Fatalf("SetUserActive: %v", err)
+			}
+			if _, err := uc.PR.CreatePR(ctx, "pr-1", "sync", "a", false); err != nil {
+				t.Fatalf("CreatePR: %v", err)
+			}
+
+			result, err := uc.Team.SyncTeam(ctx, entity.Team{Name: tt.team, Members: tt.members}, tt.dryRun)
+			if err != nil {
+				t.Fatalf("SyncTeam: %v", err)
+			}
+
+			if result.TeamCreated != tt.wantCreated || result.DryRun != tt.dryRun {
+				t.Errorf("team_created=%v dry_run=%v, want %v %v", result.TeamCreated, result.DryRun, tt.wantCreated, tt.dryRun)
+			}
+			if got := ids(result.Added); !reflect.DeepEqual(got, orEmpty(tt.wantAdded)) {
+				t.Errorf("added = %v, want %v", got, tt.wantAdded)
+			}
+			if got := ids(result.Updated); !reflect.DeepEqual(got, orEmpty(tt.wantUpdated)) {
+				t.Errorf("updated = %v, want %v", got, tt.wantUpdated)
+			}
+			if !reflect.DeepEqual(result.Deactivated, orEmpty(tt.wantDeactivated)) {
+				t.Errorf("deactivated = %v, want %v", result.Deactivated, tt.wantDeactivated)
+			}
+
+			if len(result.Reassignments) != len(tt.wantReplaced) {
+				t.Fatalf("reassignments = %+v, want %d", result.Reassignments, len(tt.wantReplaced))
+			}
+			for i, reassignment := range result.Reassignments {
+				// Несколько допустимых замен перечислены через |
+				allowed := map[string]bool{}
+				for _, id := range strings.Split(tt.wantReplaced[i], "|") {
+					allowed[id] = true
+				}
+				if reassignment.PullRequestID != "pr-1" || reassignment.OldUserID != "b" || !allowed[reassignment.NewUserID] {
+					t.Errorf("reassignment %+v, want pr-1 b -> %s", reassignment, tt.wantReplaced[i])
+				}
+			}
+
+			team, err := uc.Team.GetTeam(ctx, tt.team)
+			if err != nil {
+				t.Fatalf("GetTeam: %v", err)
+			}
+			active := map[string]bool{}
+			for _, m := range team.Members {
+				active[m.UserID] = m.IsActive
+			}
+			if !reflect.DeepEqual(active, tt.wantActive) {
+				t.Errorf("members after sync = %v, want %v", active, tt.wantActive)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// BenchmarkDeactivateUsers измеряет деактивацию 200 пользователей с открытыми PR
+// на хранилище в памяти. Ту же операцию на PostgreSQL измеряет BenchmarkDeactivateTeamUsers
+func BenchmarkDeactivateUsers(b *testing.B) {
+	const leaving, staying = 200, 10
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		uc := newTestUseCases(b, StrategyLeastLoaded)
+		var ids []string
+		for j := 0; j < leaving+staying; j++ {
+			ids = append(ids, fmt.Sprintf("u%03d", j))
+		}
+		leavingIDs := ids[staying:]
+		createTeam(b, uc, "backend", ids...)
+		for j := 0; j < leaving; j++ {
+			if _, err := uc.PR.CreatePR(ctx, fmt.Sprintf("pr-%d", j), "bench", ids[j%staying], false); err != nil {
+				b.Fatalf("CreatePR: %v", err)
+			}
+		}
+		b.StartTimer()
+
+		result, err := uc.Team.DeactivateUsers(ctx, "backend", leavingIDs)
+		if err != nil {
+			b.Fatalf("DeactivateUsers: %v", err)
+		}
+		if len(result.DeactivatedUsers) != leaving {
+			b.Fatalf("deactivated %d users, want %d", len(result.DeactivatedUsers), leaving)
+		}
+	}
+}
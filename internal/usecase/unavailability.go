@@ -0,0 +1,272 @@
+// unavailability.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+// UnavailabilityUseCase интерфейс для работы с периодами недоступности пользователей.
+// Во время периода пользователь не выбирается ревьювером
+type UnavailabilityUseCase interface {
+	CreateUnavailability(ctx context.Context, period entity.Unavailability) (*entity.Unavailability, error)
+	ListUnavailability(ctx context.Context, userID string) ([]entity.Unavailability, error)
+	// UpdateUnavailability меняет границы и причину периода
+	UpdateUnavailability(ctx context.Context, period entity.Unavailability) (*entity.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id int64) error
+	// ReassignStarted переназначает открытые ревью пользователей, у которых к моменту at начался период недоступности.
+	// Возвращает количество обработанных периодов
+	ReassignStarted(ctx context.Context, at time.Time) (int, error)
+}
+
+// unavailabilityBatchSize - сколько начавшихся периодов обрабатывается за один запуск
+const unavailabilityBatchSize = 100
+
+type unavailabilityUseCase struct {
+	unavailabilityRepo repository.UnavailabilityRepository
+	userRepo           repository.UserRepository
+	teamRepo           repository.TeamRepository
+	prRepo             repository.PRRepository
+	eventRepo          repository.EventRepository
+	outboxRepo         repository.OutboxRepository
+	tx                 repository.Transactor
+	selector           ReviewerSelector
+	logger             logger.Interface
+}
+
+func NewUnavailabilityUseCase(
+	unavailabilityRepo repository.UnavailabilityRepository,
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PRRepository,
+	eventRepo repository.EventRepository,
+	outboxRepo repository.OutboxRepository,
+	tx repository.Transactor,
+	selector ReviewerSelector,
+	l logger.Interface,
+) UnavailabilityUseCase {
+	return &unavailabilityUseCase{
+		unavailabilityRepo: unavailabilityRepo,
+		userRepo:           userRepo,
+		teamRepo:           teamRepo,
+		prRepo:             prRepo,
+		eventRepo:          eventRepo,
+		outboxRepo:         outboxRepo,
+		tx:                 tx,
+		selector:           selector,
+		logger:             l,
+	}
+}
+
+func (uc *unavailabilityUseCase) CreateUnavailability(ctx context.Context, period entity.Unavailability) (*entity.Unavailability, error) {
+	uc.logger.Info("Creating unavailability for user %s: %s - %s", period.UserID, period.From, period.To)
+
+	if period.UserID == "" {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "user_id is required")
+	}
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeUser(ctx, period.UserID); err != nil {
+		return nil, err
+	}
+
+	period.ReassignedAt = nil
+	if err := uc.unavailabilityRepo.CreateUnavailability(ctx, &period); err != nil {
+		uc.logger.Error("Failed to create unavailability: %v", err)
+		return nil, fmt.Errorf("unavailabilityUseCase - CreateUnavailability - CreateUnavailability: %w", err)
+	}
+
+	uc.logger.Info("Unavailability %d created for user %s", period.ID, period.UserID)
+	return &period, nil
+}
+
+func (uc *unavailabilityUseCase) ListUnavailability(ctx context.Context, userID string) ([]entity.Unavailability, error) {
+	uc.logger.Debug("Listing unavailability for user %s", userID)
+
+	if userID == "" {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "user_id is required")
+	}
+	if _, err := uc.userRepo.GetUser(ctx, userID); err != nil {
+		uc.logger.Warn("User not found: %s", userID)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "user not found")
+	}
+
+	periods, err := uc.unavailabilityRepo.ListUnavailability(ctx, userID)
+	if err != nil {
+		uc.logger.Error("Failed to list unavailability: %v", err)
+		return nil, fmt.Errorf("unavailabilityUseCase - ListUnavailability: %w", err)
+	}
+	return periods, nil
+}
+
+func (uc *unavailabilityUseCase) UpdateUnavailability(ctx context.Context, period entity.Unavailability) (*entity.Unavailability, error) {
+	uc.logger.Info("Updating unavailability %d: %s - %s", period.ID, period.From, period.To)
+
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.getUnavailability(ctx, period.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeUser(ctx, existing.UserID); err != nil {
+		return nil, err
+	}
+
+	// После переноса периода ревью переназначаются заново, когда начнется новый период
+	if !existing.From.Equal(period.From) || !existing.To.Equal(period.To) {
+		existing.ReassignedAt = nil
+	}
+	existing.From = period.From
+	existing.To = period.To
+	existing.Reason = period.Reason
+
+	if err := uc.unavailabilityRepo.UpdateUnavailability(ctx, existing); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, entity.NewAppError(entity.ErrorNotFound, "unavailability not found")
+		}
+		uc.logger.Error("Failed to update unavailability: %v", err)
+		return nil, fmt.Errorf("unavailabilityUseCase - UpdateUnavailability - UpdateUnavailability: %w", err)
+	}
+
+	uc.logger.Info("Unavailability %d updated", existing.ID)
+	return existing, nil
+}
+
+func (uc *unavailabilityUseCase) DeleteUnavailability(ctx context.Context, id int64) error {
+	uc.logger.Info("Deleting unavailability %d", id)
+
+	existing, err := uc.getUnavailability(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.authorizeUser(ctx, existing.UserID); err != nil {
+		return err
+	}
+
+	if err := uc.unavailabilityRepo.DeleteUnavailability(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.NewAppError(entity.ErrorNotFound, "unavailability not found")
+		}
+		uc.logger.Error("Failed to delete unavailability: %v", err)
+		return fmt.Errorf("unavailabilityUseCase - DeleteUnavailability - DeleteUnavailability: %w", err)
+	}
+
+	uc.logger.Info("Unavailability %d deleted", id)
+	return nil
+}
+
+func (uc *unavailabilityUseCase) ReassignStarted(ctx context.Context, at time.Time) (int, error) {
+	periods, err := uc.unavailabilityRepo.GetStartedUnavailability(ctx, at, unavailabilityBatchSize)
+	if err != nil {
+		uc.logger.Error("Failed to get started unavailability: %v", err)
+		return 0, fmt.Errorf("unavailabilityUseCase - ReassignStarted - GetStartedUnavailability: %w", err)
+	}
+
+	// Ошибка одного периода не мешает остальным, он будет обработан при следующем запуске
+	processed := 0
+	for _, period := range periods {
+		if err := uc.reassignPeriod(ctx, period, at); err != nil {
+			uc.logger.Error("Failed to reassign reviews for unavailability %d: %v", period.ID, err)
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// reassignPeriod переназначает открытые ревью пользователя на доступных участников его команды
+// и отмечает период обработанным
+func (uc *unavailabilityUseCase) reassignPeriod(ctx context.Context, period entity.Unavailability, at time.Time) error {
+	user, err := uc.userRepo.GetUser(ctx, period.UserID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("unavailabilityUseCase - reassignPeriod - GetUser: %w", err)
+	}
+
+	// Ревью удаленного пользователя уже переназначены при удалении
+	var choose repository.ReplacementFunc
+	if user != nil {
+		choose, err = remainingMembersChooser(ctx, uc.userRepo, uc.teamRepo, uc.prRepo, uc.selector, user.TeamName, map[string]bool{user.ID: true}, at)
+		if err != nil {
+			return fmt.Errorf("unavailabilityUseCase - reassignPeriod - %w", err)
+		}
+	}
+
+	var reassignments []entity.ReviewerReassignment
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if user != nil {
+			reassignments, err = reassignReviews(ctx, uc.userRepo, uc.eventRepo, user.ID, user.TeamName, choose, entity.ReasonUnavailable)
+			if err != nil {
+				return fmt.Errorf("unavailabilityUseCase - reassignPeriod - %w", err)
+			}
+			for _, reassignment := range reassignments {
+				if err := enqueueEvent(ctx, uc.outboxRepo, entity.WebhookReviewerReassigned, reassignment); err != nil {
+					return fmt.Errorf("unavailabilityUseCase - reassignPeriod - %w", err)
+				}
+			}
+		}
+
+		period.ReassignedAt = &at
+		if err := uc.unavailabilityRepo.UpdateUnavailability(ctx, &period); err != nil {
+			return fmt.Errorf("unavailabilityUseCase - reassignPeriod - UpdateUnavailability: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("Unavailability %d of user %s started, %d open reviews reassigned", period.ID, period.UserID, len(reassignments))
+	return nil
+}
+
+// getUnavailability возвращает период или ошибку NOT_FOUND
+func (uc *unavailabilityUseCase) getUnavailability(ctx context.Context, id int64) (*entity.Unavailability, error) {
+	if id <= 0 {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "id is required")
+	}
+
+	period, err := uc.unavailabilityRepo.GetUnavailability(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("Unavailability not found: %d", id)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "unavailability not found")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to get unavailability: %v", err)
+		return nil, fmt.Errorf("unavailabilityUseCase - GetUnavailability: %w", err)
+	}
+	return period, nil
+}
+
+// authorizeUser проверяет, что пользователь существует и токен может менять участников его команды
+func (uc *unavailabilityUseCase) authorizeUser(ctx context.Context, userID string) error {
+	user, err := uc.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		uc.logger.Warn("User not found: %s", userID)
+		return entity.NewAppError(entity.ErrorNotFound, "user not found")
+	}
+
+	if err := authorizeTeam(ctx, uc.userRepo, user.TeamName); err != nil {
+		uc.logger.Warn("User %s modification denied: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+func validatePeriod(period entity.Unavailability) error {
+	if period.From.IsZero() || period.To.IsZero() {
+		return entity.NewAppError(entity.ErrorInvalidInput, "from and to are required")
+	}
+	if !period.To.After(period.From) {
+		return entity.NewAppError(entity.ErrorInvalidInput, "to must be after from")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+)
+
+func TestReassignStartedSkipsUnavailableTeammates(t *testing.T) {
+	ctx := context.Background()
+	uc := newTestUseCases(t, StrategyRandom)
+	createTeam(t, uc, "backend", "author", "a", "b", "c")
+	if _, err := uc.PR.CreatePR(ctx, "pr-1", "vacation", "author", false); err != nil {
+		t.Fatalf("CreatePR: %v", err)
+	}
+
+	// Отпуска a и b пересекаются и начинаются позже текущего момента: проверяется доступность в момент at
+	now := time.Now()
+	at := now.Add(2 * time.Hour)
+	for _, period := range []entity.Unavailability{
+		{UserID: "a", From: now.Add(30 * time.Minute), To: now.Add(48 * time.Hour)},
+		{UserID: "b", From: now.Add(time.Hour), To: now.Add(72 * time.Hour)},
+	} {
+		if _, err := uc.Unavailability.CreateUnavailability(ctx, period); err != nil {
+			t.Fatalf("CreateUnavailability(%s): %v", period.UserID, err)
+		}
+	}
+
+	processed, err := uc.Unavailability.ReassignStarted(ctx, at)
+	if err != nil {
+		t.Fatalf("ReassignStarted: %v", err)
+	}
+	if processed != 2 {
+		t.Fatalf("processed %d periods, want 2", processed)
+	}
+
+	pr, err := uc.PR.GetPR(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPR: %v", err)
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID != "c" {
+			t.Errorf("reviewers %v: %s is unavailable at %s", pr.AssignedReviewers, reviewerID, at)
+		}
+	}
+}
@@ -2,25 +2,60 @@
 package usecase
 
 import (
+	"time"
+
 	"github.com/PaulLocust/Avito-review/internal/repository"
 	"github.com/PaulLocust/Avito-review/pkg/logger"
 )
 
+// Metrics - бизнес-метрики сценариев
+type Metrics interface {
+	PRCreated()
+	PRMerged()
+	ReviewerReassigned()
+	NoCandidate(operation string)
+}
+
 type UseCases struct {
-	Team TeamUseCase
-	User UserUseCase  
-	PR   PRUseCase
+	Team    TeamUseCase
+	User    UserUseCase
+	PR      PRUseCase
+	Stats   StatsUseCase
+	Webhook WebhookUseCase
+	Auth    AuthUseCase
+
+	Idempotency    IdempotencyUseCase
+	Unavailability UnavailabilityUseCase
 }
 
 func NewUseCases(
 	teamRepo repository.TeamRepository,
 	userRepo repository.UserRepository,
 	prRepo repository.PRRepository,
+	statsRepo repository.StatsRepository,
+	eventRepo repository.EventRepository,
+	webhookRepo repository.WebhookRepository,
+	outboxRepo repository.OutboxRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	idempotencyRepo repository.IdempotencyRepository,
+	unavailabilityRepo repository.UnavailabilityRepository,
+	tx repository.Transactor,
+	selector ReviewerSelector,
+	authOpts AuthOptions,
+	idempotencyTTL time.Duration,
+	idempotencyLease time.Duration,
+	m Metrics,
 	l logger.Interface,
 ) *UseCases {
 	return &UseCases{
-		Team: NewTeamUseCase(teamRepo, userRepo, l),
-		User: NewUserUseCase(userRepo, prRepo, l),
-		PR:   NewPRUseCase(prRepo, userRepo, teamRepo, l),
+		Team:    NewTeamUseCase(teamRepo, userRepo, prRepo, eventRepo, outboxRepo, tx, selector, l),
+		User:    NewUserUseCase(userRepo, prRepo, teamRepo, eventRepo, outboxRepo, tx, selector, l),
+		PR:      NewPRUseCase(prRepo, userRepo, teamRepo, eventRepo, outboxRepo, tx, selector, m, l),
+		Stats:   NewStatsUseCase(statsRepo, teamRepo, l),
+		Webhook: NewWebhookUseCase(webhookRepo, l),
+		Auth:    NewAuthUseCase(apiKeyRepo, authOpts, l),
+
+		Idempotency:    NewIdempotencyUseCase(idempotencyRepo, idempotencyTTL, idempotencyLease, l),
+		Unavailability: NewUnavailabilityUseCase(unavailabilityRepo, userRepo, teamRepo, prRepo, eventRepo, outboxRepo, tx, selector, l),
 	}
-}
\ No newline at end of file
+}
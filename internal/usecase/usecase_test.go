@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository/memory"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+type nopMetrics struct{}
+
+func (nopMetrics) PRCreated()          {}
+func (nopMetrics) PRMerged()           {}
+func (nopMetrics) ReviewerReassigned() {}
+func (nopMetrics) NoCandidate(string)  {}
+
+// newTestUseCases собирает use case поверх хранилища в памяти
+func newTestUseCases(t testing.TB, strategy string) *UseCases {
+	t.Helper()
+
+	l := logger.New("error")
+	storage := memory.NewStorage()
+	prRepo := memory.NewPRRepository(storage, l)
+	selector, err := NewReviewerSelector(strategy, prRepo)
+	if err != nil {
+		t.Fatalf("NewReviewerSelector: %v", err)
+	}
+
+	return NewUseCases(
+		memory.NewTeamRepository(storage, l),
+		memory.NewUserRepository(storage, l),
+		prRepo,
+		memory.NewStatsRepository(storage, l),
+		memory.NewEventRepository(storage, l),
+		memory.NewWebhookRepository(storage, l),
+		memory.NewOutboxRepository(storage, l),
+		memory.NewAPIKeyRepository(storage, l),
+		memory.NewIdempotencyRepository(storage, l),
+		memory.NewUnavailabilityRepository(storage, l),
+		memory.NewTransactor(storage),
+		selector, AuthOptions{}, time.Hour, time.Minute, nopMetrics{}, l,
+	)
+}
+
+// createTeam создает команду из активных участников с user_id userIDs
+func createTeam(t testing.TB, uc *UseCases, teamName string, userIDs ...string) {
+	t.Helper()
+
+	team := entity.Team{Name: teamName}
+	for _, userID := range userIDs {
+		team.Members = append(team.Members, entity.TeamMember{UserID: userID, Username: userID, IsActive: true})
+	}
+	if err := uc.Team.CreateTeam(context.Background(), team); err != nil {
+		t.Fatalf("CreateTeam(%s): %v", teamName, err)
+	}
+}
+
+// setTeamSettings задает настройки назначения команды
+func setTeamSettings(t testing.TB, uc *UseCases, settings entity.TeamSettings) {
+	t.Helper()
+
+	update := entity.TeamSettingsUpdate{
+		TeamName:          settings.TeamName,
+		ReviewerCount:     settings.ReviewerCount,
+		MinRequired:       settings.MinRequired,
+		Strategy:          settings.Strategy,
+		RequiredApprovals: &settings.RequiredApprovals,
+		MaxOpenReviews:    &settings.MaxOpenReviews,
+	}
+	if _, err := uc.Team.SetTeamSettings(context.Background(), update); err != nil {
+		t.Fatalf("SetTeamSettings(%s): %v", settings.TeamName, err)
+	}
+}
+
+// setReviewLimit задает личный лимит открытых ревью пользователя
+func setReviewLimit(t testing.TB, uc *UseCases, userID string, limit int) {
+	t.Helper()
+
+	if _, err := uc.User.UpdateUser(context.Background(), userID, nil, nil, &limit); err != nil {
+		t.Fatalf("UpdateUser(%s): %v", userID, err)
+	}
+}
+
+// openReviews возвращает число открытых ревью пользователя
+func openReviews(t testing.TB, uc *UseCases, userID string) int {
+	t.Helper()
+
+	_, load, err := uc.User.GetUser(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetUser(%s): %v", userID, err)
+	}
+	return load.OpenReviews
+}
@@ -3,7 +3,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/PaulLocust/Avito-review/internal/entity"
 	"github.com/PaulLocust/Avito-review/internal/repository"
@@ -12,24 +14,314 @@ import (
 
 // UserUseCase интерфейс для работы с пользователями
 type UserUseCase interface {
+	// GetUser возвращает пользователя и его текущую загрузку открытыми ревью
+	GetUser(ctx context.Context, userID string) (*entity.User, *entity.ReviewLoad, error)
+	ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, error)
+	// UpdateUser меняет имя, команду и/или личный лимит открытых ревью пользователя (nil - без изменений).
+	// При переводе в другую команду его открытые ревью переназначаются внутри прежней команды
+	UpdateUser(ctx context.Context, userID string, username, teamName *string, maxOpenReviews *int) (*entity.UserChange, error)
+	// DeleteUser мягко удаляет пользователя и переназначает его открытые ревью
+	DeleteUser(ctx context.Context, userID string) (*entity.UserChange, error)
 	SetUserActive(ctx context.Context, userID string, active bool) (*entity.User, error)
 	GetUserReviews(ctx context.Context, userID string) ([]entity.PullRequestShort, error)
 }
 
 type userUseCase struct {
-	userRepo repository.UserRepository
-	prRepo   repository.PRRepository
-	logger   logger.Interface
+	userRepo   repository.UserRepository
+	prRepo     repository.PRRepository
+	teamRepo   repository.TeamRepository
+	eventRepo  repository.EventRepository
+	outboxRepo repository.OutboxRepository
+	tx         repository.Transactor
+	selector   ReviewerSelector
+	logger     logger.Interface
 }
 
-func NewUserUseCase(userRepo repository.UserRepository, prRepo repository.PRRepository, l logger.Interface) UserUseCase {
+func NewUserUseCase(
+	userRepo repository.UserRepository,
+	prRepo repository.PRRepository,
+	teamRepo repository.TeamRepository,
+	eventRepo repository.EventRepository,
+	outboxRepo repository.OutboxRepository,
+	tx repository.Transactor,
+	selector ReviewerSelector,
+	l logger.Interface,
+) UserUseCase {
 	return &userUseCase{
-		userRepo: userRepo,
-		prRepo:   prRepo,
-		logger:   l,
+		userRepo:   userRepo,
+		prRepo:     prRepo,
+		teamRepo:   teamRepo,
+		eventRepo:  eventRepo,
+		outboxRepo: outboxRepo,
+		tx:         tx,
+		selector:   selector,
+		logger:     l,
 	}
 }
 
+func (uc *userUseCase) GetUser(ctx context.Context, userID string) (*entity.User, *entity.ReviewLoad, error) {
+	uc.logger.Debug("Getting user: %s", userID)
+
+	user, err := uc.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		uc.logger.Warn("User not found: %s", userID)
+		return nil, nil, entity.NewAppError(entity.ErrorNotFound, "user not found")
+	}
+
+	settings, err := teamSettingsOrDefault(ctx, uc.teamRepo, user.TeamName)
+	if err != nil {
+		uc.logger.Error("Failed to get team settings: %v", err)
+		return nil, nil, fmt.Errorf("userUseCase - GetUser - teamSettingsOrDefault: %w", err)
+	}
+	open, err := uc.prRepo.CountOpenReviews(ctx, []string{user.ID})
+	if err != nil {
+		uc.logger.Error("Failed to count open reviews: %v", err)
+		return nil, nil, fmt.Errorf("userUseCase - GetUser - CountOpenReviews: %w", err)
+	}
+
+	load := &entity.ReviewLoad{
+		OpenReviews:    open[user.ID],
+		MaxOpenReviews: settings.ReviewLimit(*user),
+	}
+	load.AtCapacity = load.MaxOpenReviews > 0 && load.OpenReviews >= load.MaxOpenReviews
+	return user, load, nil
+}
+
+func (uc *userUseCase) ListUsers(ctx context.Context, filter entity.UserFilter) ([]entity.User, error) {
+	uc.logger.Debug("Listing users: %+v", filter)
+
+	if filter.TeamName != "" {
+		exists, err := uc.teamRepo.TeamExists(ctx, filter.TeamName)
+		if err != nil {
+			uc.logger.Error("Failed to check team existence: %v", err)
+			return nil, fmt.Errorf("userUseCase - ListUsers - TeamExists: %w", err)
+		}
+		if !exists {
+			uc.logger.Warn("Team not found: %s", filter.TeamName)
+			return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+		}
+	}
+
+	users, err := uc.userRepo.ListUsers(ctx, filter)
+	if err != nil {
+		uc.logger.Error("Failed to list users: %v", err)
+		return nil, fmt.Errorf("userUseCase - ListUsers - ListUsers: %w", err)
+	}
+
+	uc.logger.Debug("Found %d users", len(users))
+	return users, nil
+}
+
+func (uc *userUseCase) UpdateUser(ctx context.Context, userID string, username, teamName *string, maxOpenReviews *int) (*entity.UserChange, error) {
+	uc.logger.Info("Updating user %s", userID)
+
+	// Валидируем изменения
+	if userID == "" {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "user_id is required")
+	}
+	if username == nil && teamName == nil && maxOpenReviews == nil {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "username, team_name or max_open_reviews is required")
+	}
+	if (username != nil && *username == "") || (teamName != nil && *teamName == "") {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "username and team_name must not be empty")
+	}
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "max_open_reviews must be non-negative")
+	}
+
+	user, err := uc.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		uc.logger.Warn("User not found: %s", userID)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "user not found")
+	}
+
+	// Пользователь токена может менять только участников своей команды
+	if err := authorizeTeam(ctx, uc.userRepo, user.TeamName); err != nil {
+		uc.logger.Warn("User %s modification denied: %v", userID, err)
+		return nil, err
+	}
+
+	oldTeam := user.TeamName
+	moving := teamName != nil && *teamName != oldTeam
+	if moving {
+		// Права проверяются только на прежнюю команду: лид переводит своего участника в любую существующую команду
+		exists, err := uc.teamRepo.TeamExists(ctx, *teamName)
+		if err != nil {
+			uc.logger.Error("Failed to check team existence: %v", err)
+			return nil, fmt.Errorf("userUseCase - UpdateUser - TeamExists: %w", err)
+		}
+		if !exists {
+			uc.logger.Warn("Team not found: %s", *teamName)
+			return nil, entity.NewAppError(entity.ErrorNotFound, "team not found")
+		}
+		user.TeamName = *teamName
+	}
+	if username != nil {
+		user.Username = *username
+	}
+	if maxOpenReviews != nil {
+		user.MaxOpenReviews = *maxOpenReviews
+	}
+
+	// Открытые ревью остаются в прежней команде: их забирают ее активные участники
+	var choose repository.ReplacementFunc
+	if moving {
+		choose, err = remainingMembersChooser(ctx, uc.userRepo, uc.teamRepo, uc.prRepo, uc.selector, oldTeam, map[string]bool{userID: true}, time.Now())
+		if err != nil {
+			uc.logger.Error("Failed to prepare reviewer replacement: %v", err)
+			return nil, fmt.Errorf("userUseCase - UpdateUser - %w", err)
+		}
+	}
+
+	// Изменение пользователя, замены в его ревью, журнал и события фиксируются вместе
+	result := &entity.UserChange{Reassignments: []entity.ReviewerReassignment{}}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.UpdateUser(ctx, user); err != nil {
+			uc.logger.Error("Failed to update user: %v", err)
+			return fmt.Errorf("userUseCase - UpdateUser - UpdateUser: %w", err)
+		}
+		if !moving {
+			return nil
+		}
+
+		reassignments, err := reassignReviews(ctx, uc.userRepo, uc.eventRepo, userID, oldTeam, choose, entity.ReasonUserMoved)
+		if err != nil {
+			uc.logger.Error("Failed to reassign user reviews: %v", err)
+			return fmt.Errorf("userUseCase - UpdateUser - %w", err)
+		}
+		result.Reassignments = reassignments
+
+		for _, reassignment := range reassignments {
+			if err := enqueueEvent(ctx, uc.outboxRepo, entity.WebhookReviewerReassigned, reassignment); err != nil {
+				uc.logger.Error("Failed to enqueue event: %v", err)
+				return fmt.Errorf("userUseCase - UpdateUser - %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.User = *user
+
+	uc.logger.Info("User %s updated, %d open reviews reassigned", userID, len(result.Reassignments))
+	return result, nil
+}
+
+func (uc *userUseCase) DeleteUser(ctx context.Context, userID string) (*entity.UserChange, error) {
+	uc.logger.Info("Deleting user %s", userID)
+
+	if userID == "" {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "user_id is required")
+	}
+
+	user, err := uc.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		uc.logger.Warn("User not found: %s", userID)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "user not found")
+	}
+
+	// Пользователь токена может менять только участников своей команды
+	if err := authorizeTeam(ctx, uc.userRepo, user.TeamName); err != nil {
+		uc.logger.Warn("User %s modification denied: %v", userID, err)
+		return nil, err
+	}
+
+	choose, err := remainingMembersChooser(ctx, uc.userRepo, uc.teamRepo, uc.prRepo, uc.selector, user.TeamName, map[string]bool{userID: true}, time.Now())
+	if err != nil {
+		uc.logger.Error("Failed to prepare reviewer replacement: %v", err)
+		return nil, fmt.Errorf("userUseCase - DeleteUser - %w", err)
+	}
+
+	// Удаление, замены в ревью, журнал и событие фиксируются вместе
+	result := &entity.UserChange{}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		deleted, err := uc.userRepo.DeleteUser(ctx, userID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.NewAppError(entity.ErrorNotFound, "user not found")
+		}
+		if err != nil {
+			uc.logger.Error("Failed to delete user: %v", err)
+			return fmt.Errorf("userUseCase - DeleteUser - DeleteUser: %w", err)
+		}
+		result.User = *deleted
+
+		result.Reassignments, err = reassignReviews(ctx, uc.userRepo, uc.eventRepo, userID, deleted.TeamName, choose, entity.ReasonUserDeleted)
+		if err != nil {
+			uc.logger.Error("Failed to reassign user reviews: %v", err)
+			return fmt.Errorf("userUseCase - DeleteUser - %w", err)
+		}
+
+		err = enqueueEvent(ctx, uc.outboxRepo, entity.WebhookUserDeactivated, entity.TeamDeactivation{
+			TeamName:         deleted.TeamName,
+			DeactivatedUsers: []string{userID},
+			Reassignments:    result.Reassignments,
+		})
+		if err != nil {
+			uc.logger.Error("Failed to enqueue event: %v", err)
+			return fmt.Errorf("userUseCase - DeleteUser - %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("User %s deleted, %d open reviews reassigned", userID, len(result.Reassignments))
+	return result, nil
+}
+
+// remainingMembersChooser готовит выбор замены среди активных участников команды, доступных в момент at,
+// кроме покидающих ее
+func remainingMembersChooser(
+	ctx context.Context,
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PRRepository,
+	selector ReviewerSelector,
+	teamName string,
+	leaving map[string]bool,
+	at time.Time,
+) (repository.ReplacementFunc, error) {
+	members, err := userRepo.GetAvailableUsersByTeam(ctx, teamName, at)
+	if err != nil {
+		return nil, fmt.Errorf("GetAvailableUsersByTeam: %w", err)
+	}
+
+	remaining := make([]entity.User, 0, len(members))
+	for _, member := range members {
+		if !leaving[member.ID] {
+			remaining = append(remaining, member)
+		}
+	}
+
+	choose, err := replacementChooser(ctx, teamRepo, prRepo, selector, teamName, remaining)
+	if err != nil {
+		return nil, fmt.Errorf("replacementChooser: %w", err)
+	}
+	return choose, nil
+}
+
+// reassignReviews переназначает открытые ревью пользователя и записывает изменения в журнал, вызывается в транзакции
+func reassignReviews(
+	ctx context.Context,
+	userRepo repository.UserRepository,
+	eventRepo repository.EventRepository,
+	userID, teamName string,
+	choose repository.ReplacementFunc,
+	reason string,
+) ([]entity.ReviewerReassignment, error) {
+	reassignments, err := userRepo.ReassignUserReviews(ctx, userID, teamName, choose)
+	if err != nil {
+		return nil, fmt.Errorf("ReassignUserReviews: %w", err)
+	}
+
+	if err := eventRepo.AddEvents(ctx, reassignmentEvents(reassignments, reason, actorFrom(ctx))); err != nil {
+		return nil, fmt.Errorf("AddEvents: %w", err)
+	}
+	return reassignments, nil
+}
+
 func (uc *userUseCase) SetUserActive(ctx context.Context, userID string, active bool) (*entity.User, error) {
 	uc.logger.Info("Setting user %s active=%v", userID, active)
 
@@ -40,6 +332,12 @@ func (uc *userUseCase) SetUserActive(ctx context.Context, userID string, active
 		return nil, entity.NewAppError(entity.ErrorNotFound, "user not found")
 	}
 
+	// Пользователь токена может менять только участников своей команды
+	if err := authorizeTeam(ctx, uc.userRepo, user.TeamName); err != nil {
+		uc.logger.Warn("User %s modification denied: %v", userID, err)
+		return nil, err
+	}
+
 	// Обновляем флаг активности
 	user.IsActive = active
 	err = uc.userRepo.UpdateUser(ctx, user)
@@ -0,0 +1,121 @@
+// webhook.go
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+// WebhookUseCase интерфейс для управления подписками на события
+type WebhookUseCase interface {
+	CreateWebhook(ctx context.Context, rawURL string, eventTypes []entity.WebhookEventType, secret string) (*entity.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]entity.Webhook, error)
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]entity.WebhookDelivery, error)
+}
+
+const (
+	defaultDeliveryPageSize = 50
+	maxDeliveryPageSize     = 100
+	webhookSecretBytes      = 32
+)
+
+type webhookUseCase struct {
+	webhookRepo repository.WebhookRepository
+	logger      logger.Interface
+}
+
+func NewWebhookUseCase(webhookRepo repository.WebhookRepository, l logger.Interface) WebhookUseCase {
+	return &webhookUseCase{
+		webhookRepo: webhookRepo,
+		logger:      l,
+	}
+}
+
+// CreateWebhook регистрирует подписку; если secret не задан, генерирует случайный
+func (uc *webhookUseCase) CreateWebhook(ctx context.Context, rawURL string, eventTypes []entity.WebhookEventType, secret string) (*entity.Webhook, error) {
+	uc.logger.Info("Creating webhook for %s on %v", rawURL, eventTypes)
+
+	// Валидируем подписку
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "url must be an absolute http or https URL")
+	}
+	if len(eventTypes) == 0 {
+		return nil, entity.NewAppError(entity.ErrorInvalidInput, "event_types must not be empty")
+	}
+	for _, eventType := range eventTypes {
+		if !eventType.IsKnown() {
+			return nil, entity.NewAppError(entity.ErrorInvalidInput, fmt.Sprintf("unknown event type %s", eventType))
+		}
+	}
+
+	if secret == "" {
+		buf := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("webhookUseCase - CreateWebhook - rand.Read: %w", err)
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	webhook := &entity.Webhook{
+		URL:        rawURL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+	}
+	if err := uc.webhookRepo.CreateWebhook(ctx, webhook); err != nil {
+		uc.logger.Error("Failed to create webhook: %v", err)
+		return nil, fmt.Errorf("webhookUseCase - CreateWebhook - CreateWebhook: %w", err)
+	}
+
+	uc.logger.Info("Webhook created: %d", webhook.ID)
+	return webhook, nil
+}
+
+func (uc *webhookUseCase) ListWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	uc.logger.Debug("Listing webhooks")
+
+	webhooks, err := uc.webhookRepo.ListWebhooks(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to list webhooks: %v", err)
+		return nil, fmt.Errorf("webhookUseCase - ListWebhooks - ListWebhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// ListDeliveries возвращает журнал доставок вебхука, новые первыми
+func (uc *webhookUseCase) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]entity.WebhookDelivery, error) {
+	uc.logger.Debug("Listing deliveries of webhook %d", webhookID)
+
+	switch {
+	case limit == 0:
+		limit = defaultDeliveryPageSize
+	case limit < 0 || limit > maxDeliveryPageSize:
+		return nil, entity.NewAppError(entity.ErrorInvalidInput,
+			fmt.Sprintf("limit must be between 1 and %d", maxDeliveryPageSize))
+	}
+
+	_, err := uc.webhookRepo.GetWebhook(ctx, webhookID)
+	if errors.Is(err, repository.ErrNotFound) {
+		uc.logger.Warn("Webhook not found: %d", webhookID)
+		return nil, entity.NewAppError(entity.ErrorNotFound, "webhook not found")
+	}
+	if err != nil {
+		uc.logger.Error("Failed to get webhook %d: %v", webhookID, err)
+		return nil, fmt.Errorf("webhookUseCase - ListDeliveries - GetWebhook: %w", err)
+	}
+
+	deliveries, err := uc.webhookRepo.ListDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		uc.logger.Error("Failed to list deliveries: %v", err)
+		return nil, fmt.Errorf("webhookUseCase - ListDeliveries - ListDeliveries: %w", err)
+	}
+	return deliveries, nil
+}
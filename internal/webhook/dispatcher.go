@@ -0,0 +1,281 @@
+// Package webhook delivers service events to subscribed HTTP endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+// Заголовки запроса доставки
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature" // sha256=<hex HMAC-SHA256 тела по секрету подписки>
+)
+
+const (
+	_defaultWorkers     = 4
+	_defaultMaxAttempts = 5
+	_defaultBackoff     = time.Second
+	_defaultTimeout     = 5 * time.Second
+	_queueSize          = 1024
+	_resumeBatchSize    = 100
+	_maxResponseBody    = 4 << 10
+)
+
+type job struct {
+	webhook  entity.Webhook
+	delivery entity.WebhookDelivery
+}
+
+// Dispatcher записывает доставки событий в журнал и отправляет их подписчикам в фоне.
+// Неуспешная попытка повторяется с экспоненциальной задержкой, пока не исчерпан лимит попыток
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	logger logger.Interface
+
+	workers     int
+	maxAttempts int
+	backoff     time.Duration
+
+	queue chan job
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// New creates dispatcher, Start must be called to begin deliveries.
+func New(repo repository.WebhookRepository, l logger.Interface, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		repo:        repo,
+		client:      &http.Client{Timeout: _defaultTimeout},
+		logger:      l,
+		workers:     _defaultWorkers,
+		maxAttempts: _defaultMaxAttempts,
+		backoff:     _defaultBackoff,
+		queue:       make(chan job, _queueSize),
+		stop:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Start запускает воркеры доставки и возвращает в очередь доставки, оставшиеся в статусе pending
+// после остановки или падения сервиса. Вызывается до запуска публикации из outbox,
+// чтобы новые доставки не попали в очередь дважды
+func (d *Dispatcher) Start() error {
+	pending, err := d.pendingJobs()
+	if err != nil {
+		return fmt.Errorf("webhook - Start - %w", err)
+	}
+	if len(pending) > 0 {
+		d.logger.Info("Resuming %d pending webhook deliveries", len(pending))
+	}
+
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+
+	// Незавершенных доставок может быть больше, чем вмещает очередь
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for _, j := range pending {
+			select {
+			case d.queue <- j:
+			case <-d.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// pendingJobs загружает доставки в статусе pending вместе с их подписками
+func (d *Dispatcher) pendingJobs() ([]job, error) {
+	ctx := context.Background()
+	webhooks := map[int64]*entity.Webhook{}
+
+	var jobs []job
+	var afterID int64
+	for {
+		deliveries, err := d.repo.GetPendingDeliveries(ctx, afterID, _resumeBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("GetPendingDeliveries: %w", err)
+		}
+		for _, delivery := range deliveries {
+			webhook, ok := webhooks[delivery.WebhookID]
+			if !ok {
+				if webhook, err = d.repo.GetWebhook(ctx, delivery.WebhookID); err != nil {
+					return nil, fmt.Errorf("GetWebhook %d: %w", delivery.WebhookID, err)
+				}
+				webhooks[delivery.WebhookID] = webhook
+			}
+			jobs = append(jobs, job{webhook: *webhook, delivery: delivery})
+			afterID = delivery.ID
+		}
+		if len(deliveries) < _resumeBatchSize {
+			return jobs, nil
+		}
+	}
+}
+
+// Stop останавливает воркеры; недоставленные события остаются в журнале в статусе pending
+// и будут доставлены после следующего Start
+func (d *Dispatcher) Stop() {
+	close(d.stop)
+	d.wg.Wait()
+}
+
+// Publish создает доставку события из outbox для каждой подписки на его тип и ставит их в очередь.
+// Если очередь заполнена, возвращает ошибку, и outbox повторит публикацию позже.
+// При повторе подписчик может получить событие повторно: ID события совпадает с ID записи outbox
+// и позволяет отбросить дубликат
+func (d *Dispatcher) Publish(ctx context.Context, msg entity.OutboxMessage) error {
+	webhooks, err := d.repo.GetWebhooksByEvent(ctx, msg.Type)
+	if err != nil {
+		return fmt.Errorf("webhook - Publish - GetWebhooksByEvent: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	event := entity.WebhookEvent{
+		ID:         strconv.FormatInt(msg.ID, 10),
+		Type:       msg.Type,
+		OccurredAt: msg.CreatedAt,
+		Data:       msg.Payload,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("webhook - Publish - Marshal: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		delivery := entity.WebhookDelivery{
+			WebhookID: webhook.ID,
+			EventID:   event.ID,
+			EventType: msg.Type,
+			Payload:   payload,
+			Status:    entity.DeliveryPending,
+		}
+		if err := d.repo.CreateDelivery(ctx, &delivery); err != nil {
+			return fmt.Errorf("webhook - Publish - CreateDelivery for webhook %d: %w", webhook.ID, err)
+		}
+
+		select {
+		case d.queue <- job{webhook: webhook, delivery: delivery}:
+		default:
+			// Доставка не будет отправлена: outbox создаст новую при повторной публикации
+			d.logger.Warn("Webhook delivery queue is full, delivery %d will be retried from outbox", delivery.ID)
+			delivery.Status = entity.DeliveryFailed
+			delivery.LastError = "delivery queue is full"
+			d.save(&delivery)
+			return fmt.Errorf("webhook - Publish - delivery %d: queue is full", delivery.ID)
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case j := <-d.queue:
+			d.deliver(j.webhook, &j.delivery)
+		}
+	}
+}
+
+// deliver отправляет доставку до успеха или исчерпания попыток, после каждой попытки обновляя журнал
+func (d *Dispatcher) deliver(webhook entity.Webhook, delivery *entity.WebhookDelivery) {
+	for {
+		delivery.Attempts++
+		code, err := d.send(webhook, delivery)
+		delivery.ResponseCode = code
+
+		if err == nil {
+			now := time.Now()
+			delivery.Status = entity.DeliveryDelivered
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+			d.save(delivery)
+			d.logger.Debug("Delivery %d to webhook %d succeeded", delivery.ID, webhook.ID)
+			return
+		}
+
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= d.maxAttempts {
+			delivery.Status = entity.DeliveryFailed
+			d.save(delivery)
+			d.logger.Warn("Delivery %d to webhook %d failed after %d attempts: %v", delivery.ID, webhook.ID, delivery.Attempts, err)
+			return
+		}
+		d.save(delivery)
+
+		// Экспоненциальная задержка: backoff, 2*backoff, 4*backoff...
+		delay := d.backoff << (delivery.Attempts - 1)
+		d.logger.Debug("Delivery %d attempt %d failed: %v, retrying in %s", delivery.ID, delivery.Attempts, err, delay)
+		select {
+		case <-time.After(delay):
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// send выполняет одну попытку доставки и возвращает код ответа (0, если ответа нет)
+func (d *Dispatcher) send(webhook entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, fmt.Sprintf("%d", delivery.ID))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, _maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) save(delivery *entity.WebhookDelivery) {
+	if err := d.repo.UpdateDelivery(context.Background(), delivery); err != nil {
+		d.logger.Error("Failed to update delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// Sign возвращает значение заголовка X-Webhook-Signature для тела запроса
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
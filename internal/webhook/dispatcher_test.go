@@ -0,0 +1,245 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PaulLocust/Avito-review/internal/entity"
+	"github.com/PaulLocust/Avito-review/internal/repository"
+	"github.com/PaulLocust/Avito-review/internal/repository/memory"
+	"github.com/PaulLocust/Avito-review/pkg/logger"
+)
+
+const testSecret = "s3cr3t"
+
+func newTestRepo(t *testing.T, url string) (repository.WebhookRepository, *entity.Webhook) {
+	t.Helper()
+
+	repo := memory.NewWebhookRepository(memory.NewStorage(), logger.New("error"))
+	webhook := &entity.Webhook{URL: url, EventTypes: []entity.WebhookEventType{entity.WebhookPRCreated}, Secret: testSecret}
+	if err := repo.CreateWebhook(context.Background(), webhook); err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	return repo, webhook
+}
+
+// waitDelivery дожидается, пока доставка перейдет в статус want
+func waitDelivery(t *testing.T, repo repository.WebhookRepository, webhookID, deliveryID int64, want entity.DeliveryStatus) entity.WebhookDelivery {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		deliveries, err := repo.ListDeliveries(context.Background(), webhookID, 100)
+		if err != nil {
+			t.Fatalf("ListDeliveries: %v", err)
+		}
+		for _, delivery := range deliveries {
+			if delivery.ID == deliveryID && delivery.Status == want {
+				return delivery
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery %d did not become %s: %+v", deliveryID, want, deliveries)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStartResumesPendingDeliveries(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get(HeaderDelivery)
+	}))
+	defer server.Close()
+
+	repo, webhook := newTestRepo(t, server.URL)
+
+	// Доставка, оставшаяся pending после остановки сервиса во время задержки перед повтором
+	delivery := entity.WebhookDelivery{
+		WebhookID: webhook.ID,
+		EventID:   "1",
+		EventType: entity.WebhookPRCreated,
+		Payload:   json.RawMessage(`{"id":"1"}`),
+		Status:    entity.DeliveryPending,
+		Attempts:  1,
+	}
+	if err := repo.CreateDelivery(context.Background(), &delivery); err != nil {
+		t.Fatalf("CreateDelivery: %v", err)
+	}
+
+	d := New(repo, logger.New("error"))
+	if err := d.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer d.Stop()
+
+	select {
+	case id := <-received:
+		if id != "1" {
+			t.Errorf("received delivery %s, want 1", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending delivery was not resumed")
+	}
+
+	delivered := waitDelivery(t, repo, webhook.ID, delivery.ID, entity.DeliveryDelivered)
+	if delivered.Attempts != 2 {
+		t.Errorf("attempts = %d, want 2", delivered.Attempts)
+	}
+}
+
+func TestPublishFailsWhenQueueIsFull(t *testing.T) {
+	repo, webhook := newTestRepo(t, "http://127.0.0.1:0")
+
+	// Воркеры не запущены, очередь вмещает одну доставку
+	d := New(repo, logger.New("error"))
+	d.queue = make(chan job, 1)
+
+	msg := entity.OutboxMessage{ID: 1, Type: entity.WebhookPRCreated, Payload: json.RawMessage(`{}`), CreatedAt: time.Now()}
+	if err := d.Publish(context.Background(), msg); err != nil {
+		t.Fatalf("first Publish: %v", err)
+	}
+	msg.ID = 2
+	if err := d.Publish(context.Background(), msg); err == nil {
+		t.Fatal("Publish must fail when the queue is full, so outbox retries it")
+	}
+
+	deliveries, err := repo.ListDeliveries(context.Background(), webhook.ID, 10)
+	if err != nil {
+		t.Fatalf("ListDeliveries: %v", err)
+	}
+	if len(deliveries) != 2 || deliveries[0].Status != entity.DeliveryFailed || deliveries[1].Status != entity.DeliveryPending {
+		t.Errorf("deliveries = %+v, want newest failed and oldest pending", deliveries)
+	}
+}
+
+// recordingRepo запоминает статусы, с которыми сохраняется доставка
+type recordingRepo struct {
+	repository.WebhookRepository
+	statuses chan entity.WebhookDelivery
+}
+
+func (r *recordingRepo) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if err := r.WebhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	r.statuses <- *delivery
+	return nil
+}
+
+func TestDeliverySignedAndRetriedWithBackoff(t *testing.T) {
+	const backoff = 20 * time.Millisecond
+
+	type attempt struct {
+		at        time.Time
+		signature string
+		body      []byte
+	}
+	attempts := make(chan attempt, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		attempts <- attempt{at: time.Now(), signature: r.Header.Get(HeaderSignature), body: body}
+		// Первые две попытки неуспешны
+		if len(attempts) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	base, webhook := newTestRepo(t, server.URL)
+	repo := &recordingRepo{WebhookRepository: base, statuses: make(chan entity.WebhookDelivery, 10)}
+
+	d := New(repo, logger.New("error"), Backoff(backoff), MaxAttempts(5))
+	if err := d.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer d.Stop()
+
+	msg := entity.OutboxMessage{ID: 7, Type: entity.WebhookPRCreated, Payload: json.RawMessage(`{"pull_request_id":"pr-1"}`), CreatedAt: time.Now()}
+	if err := d.Publish(context.Background(), msg); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	// Журнал: pending после каждой неуспешной попытки, затем delivered
+	want := []struct {
+		status entity.DeliveryStatus
+		code   int
+	}{
+		{entity.DeliveryPending, http.StatusServiceUnavailable},
+		{entity.DeliveryPending, http.StatusServiceUnavailable},
+		{entity.DeliveryDelivered, http.StatusOK},
+	}
+	for i, w := range want {
+		select {
+		case delivery := <-repo.statuses:
+			if delivery.Status != w.status || delivery.ResponseCode != w.code || delivery.Attempts != i+1 {
+				t.Errorf("update %d: status=%s code=%d attempts=%d, want %s %d %d",
+					i, delivery.Status, delivery.ResponseCode, delivery.Attempts, w.status, w.code, i+1)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("update %d was not recorded", i)
+		}
+	}
+	waitDelivery(t, base, webhook.ID, 1, entity.DeliveryDelivered)
+
+	close(attempts)
+	var got []attempt
+	for a := range attempts {
+		got = append(got, a)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d attempts, want 3", len(got))
+	}
+
+	var event entity.WebhookEvent
+	if err := json.Unmarshal(got[0].body, &event); err != nil || event.ID != "7" || event.Type != entity.WebhookPRCreated {
+		t.Errorf("unexpected event body %s: %v", got[0].body, err)
+	}
+	for i, a := range got {
+		mac := hmac.New(sha256.New, []byte(testSecret))
+		mac.Write(a.body)
+		if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); a.signature != want {
+			t.Errorf("attempt %d: signature %q, want %q", i, a.signature, want)
+		}
+	}
+
+	// Задержка удваивается: backoff перед второй попыткой, 2*backoff перед третьей
+	if delay := got[1].at.Sub(got[0].at); delay < backoff {
+		t.Errorf("first retry after %s, want at least %s", delay, backoff)
+	}
+	if delay := got[2].at.Sub(got[1].at); delay < 2*backoff {
+		t.Errorf("second retry after %s, want at least %s", delay, 2*backoff)
+	}
+}
+
+func TestDeliveryFailsAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	repo, webhook := newTestRepo(t, server.URL)
+	d := New(repo, logger.New("error"), Backoff(time.Millisecond), MaxAttempts(3))
+	if err := d.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer d.Stop()
+
+	msg := entity.OutboxMessage{ID: 1, Type: entity.WebhookPRCreated, Payload: json.RawMessage(`{}`), CreatedAt: time.Now()}
+	if err := d.Publish(context.Background(), msg); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	failed := waitDelivery(t, repo, webhook.ID, 1, entity.DeliveryFailed)
+	if failed.Attempts != 3 || failed.ResponseCode != http.StatusInternalServerError || failed.LastError == "" {
+		t.Errorf("failed delivery = %+v, want 3 attempts with code 500 and error", failed)
+	}
+}
@@ -0,0 +1,42 @@
+package webhook
+
+import "time"
+
+// Option - dispatcher option type.
+type Option func(*Dispatcher)
+
+// Workers sets number of concurrent delivery workers.
+func Workers(n int) Option {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.workers = n
+		}
+	}
+}
+
+// MaxAttempts sets number of delivery attempts before the delivery is marked failed.
+func MaxAttempts(n int) Option {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.maxAttempts = n
+		}
+	}
+}
+
+// Backoff sets delay before the first retry, doubled on every next one.
+func Backoff(delay time.Duration) Option {
+	return func(d *Dispatcher) {
+		if delay > 0 {
+			d.backoff = delay
+		}
+	}
+}
+
+// Timeout sets timeout of a single delivery request.
+func Timeout(timeout time.Duration) Option {
+	return func(d *Dispatcher) {
+		if timeout > 0 {
+			d.client.Timeout = timeout
+		}
+	}
+}
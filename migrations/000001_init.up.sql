@@ -1,15 +1,15 @@
-CREATE TABLE teams (
+CREATE TABLE IF NOT EXISTS teams (
     name VARCHAR PRIMARY KEY
 );
 
-CREATE TABLE users (
+CREATE TABLE IF NOT EXISTS users (
     id VARCHAR PRIMARY KEY,
     username VARCHAR NOT NULL,
     team_name VARCHAR REFERENCES teams(name),
     is_active BOOLEAN DEFAULT true
 );
 
-CREATE TABLE pull_requests (
+CREATE TABLE IF NOT EXISTS pull_requests (
     id VARCHAR PRIMARY KEY,
     name VARCHAR NOT NULL,
     author_id VARCHAR REFERENCES users(id),
@@ -18,7 +18,7 @@ CREATE TABLE pull_requests (
     merged_at TIMESTAMP
 );
 
-CREATE TABLE pr_reviewers (
+CREATE TABLE IF NOT EXISTS pr_reviewers (
     pr_id VARCHAR REFERENCES pull_requests(id),
     user_id VARCHAR REFERENCES users(id),
     PRIMARY KEY (pr_id, user_id)
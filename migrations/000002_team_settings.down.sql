@@ -0,0 +1 @@
+DROP TABLE IF EXISTS team_settings;
\ No newline at end of file
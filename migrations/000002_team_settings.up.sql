@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS team_settings (
+    team_name VARCHAR PRIMARY KEY REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    reviewer_count INTEGER NOT NULL DEFAULT 2 CHECK (reviewer_count >= 0),
+    min_required INTEGER NOT NULL DEFAULT 0 CHECK (min_required >= 0),
+    strategy VARCHAR NOT NULL DEFAULT ''
+);
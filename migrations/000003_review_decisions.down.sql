@@ -0,0 +1,5 @@
+ALTER TABLE team_settings DROP COLUMN IF EXISTS required_approvals;
+
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS state;
@@ -0,0 +1,6 @@
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS state VARCHAR NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP;
+
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0 CHECK (required_approvals >= 0);
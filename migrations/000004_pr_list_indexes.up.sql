@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at_id ON pull_requests (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id ON pull_requests (author_id);
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_user_id ON pr_reviewers (user_id);
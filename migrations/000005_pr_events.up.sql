@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR NOT NULL REFERENCES pull_requests(id),
+    type VARCHAR NOT NULL,
+    actor VARCHAR NOT NULL DEFAULT '',
+    old_user_id VARCHAR,
+    new_user_id VARCHAR,
+    reason VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_pr_id ON pr_events (pr_id, id);
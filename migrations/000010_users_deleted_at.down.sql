@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_team_name;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_unavailability (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR NOT NULL REFERENCES users(id),
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    reassigned_at TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_user_id ON user_unavailability (user_id, starts_at);
+-- Периоды, открытые ревью по которым еще не переназначены
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_pending ON user_unavailability (starts_at) WHERE reassigned_at IS NULL;
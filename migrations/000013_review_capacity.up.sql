@@ -0,0 +1,6 @@
+-- 0 у пользователя - действует лимит команды, 0 у команды - без ограничения
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
+
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
@@ -0,0 +1,2 @@
+-- Недоставленные доставки вебхуков подхватываются при старте сервиса
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries (id) WHERE status = 'pending';
@@ -0,0 +1,255 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+var _testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signHS256 и signRS256 выпускают токен с заголовком h и claims
+func signHS256(t *testing.T, secret []byte, h header, claims Claims) string {
+	t.Helper()
+
+	signed := encodeSegment(t, h) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, h header, claims Claims) string {
+	t.Helper()
+
+	signed := encodeSegment(t, h) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("SignPKCS1v15: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return key
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("hs-secret")
+	key, otherKey := generateKey(t), generateKey(t)
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+
+	valid := func() Claims {
+		return Claims{"sub": "u1", "exp": float64(_testNow.Add(time.Hour).Unix())}
+	}
+	with := func(name string, value any) Claims {
+		claims := valid()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+	hs := header{Alg: "HS256"}
+	rs := header{Alg: "RS256", Kid: "k1"}
+
+	hsOnly := []Option{HS256Secret(secret)}
+	rsOnly := []Option{RSAKeys(map[string]*rsa.PublicKey{"k1": &key.PublicKey})}
+	both := append(append([]Option{}, hsOnly...), rsOnly...)
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		token   func(t *testing.T) string
+		wantErr error
+	}{
+		{
+			name:  "hs256 valid",
+			opts:  hsOnly,
+			token: func(t *testing.T) string { return signHS256(t, secret, hs, valid()) },
+		},
+		{
+			name:    "hs256 wrong secret",
+			opts:    hsOnly,
+			token:   func(t *testing.T) string { return signHS256(t, []byte("other"), hs, valid()) },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "hs256 not accepted",
+			opts:    rsOnly,
+			token:   func(t *testing.T) string { return signHS256(t, secret, hs, valid()) },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			// Подмена RS256 на HS256 с публичным ключом в качестве секрета
+			name:    "hs256 signed with public key",
+			opts:    rsOnly,
+			token:   func(t *testing.T) string { return signHS256(t, publicDER, hs, valid()) },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:  "rs256 valid",
+			opts:  rsOnly,
+			token: func(t *testing.T) string { return signRS256(t, key, rs, valid()) },
+		},
+		{
+			name:  "rs256 with both algorithms enabled",
+			opts:  both,
+			token: func(t *testing.T) string { return signRS256(t, key, rs, valid()) },
+		},
+		{
+			name:  "rs256 without kid and single key",
+			opts:  rsOnly,
+			token: func(t *testing.T) string { return signRS256(t, key, header{Alg: "RS256"}, valid()) },
+		},
+		{
+			name: "rs256 without kid and several keys",
+			opts: []Option{RSAKeys(map[string]*rsa.PublicKey{"k1": &key.PublicKey, "k2": &otherKey.PublicKey})},
+			token: func(t *testing.T) string {
+				return signRS256(t, key, header{Alg: "RS256"}, valid())
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "rs256 unknown kid",
+			opts:    rsOnly,
+			token:   func(t *testing.T) string { return signRS256(t, key, header{Alg: "RS256", Kid: "k2"}, valid()) },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "rs256 wrong key",
+			opts:    rsOnly,
+			token:   func(t *testing.T) string { return signRS256(t, otherKey, rs, valid()) },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "rs256 not accepted",
+			opts:    hsOnly,
+			token:   func(t *testing.T) string { return signRS256(t, key, rs, valid()) },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "alg none",
+			opts: both,
+			token: func(t *testing.T) string {
+				return encodeSegment(t, header{Alg: "none"}) + "." + encodeSegment(t, valid()) + "."
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "malformed",
+			opts:    hsOnly,
+			token:   func(t *testing.T) string { return "not.a-token" },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "expired",
+			opts: hsOnly,
+			token: func(t *testing.T) string {
+				return signHS256(t, secret, hs, with("exp", float64(_testNow.Add(-time.Minute).Unix())))
+			},
+			wantErr: ErrExpired,
+		},
+		{
+			name: "expired within leeway",
+			opts: hsOnly,
+			token: func(t *testing.T) string {
+				return signHS256(t, secret, hs, with("exp", float64(_testNow.Add(-10*time.Second).Unix())))
+			},
+		},
+		{
+			name:    "without exp",
+			opts:    hsOnly,
+			token:   func(t *testing.T) string { return signHS256(t, secret, hs, with("exp", nil)) },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "not valid yet",
+			opts: hsOnly,
+			token: func(t *testing.T) string {
+				return signHS256(t, secret, hs, with("nbf", float64(_testNow.Add(time.Minute).Unix())))
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:  "issuer matches",
+			opts:  append([]Option{Issuer("idp")}, hsOnly...),
+			token: func(t *testing.T) string { return signHS256(t, secret, hs, with("iss", "idp")) },
+		},
+		{
+			name:    "unexpected issuer",
+			opts:    append([]Option{Issuer("idp")}, hsOnly...),
+			token:   func(t *testing.T) string { return signHS256(t, secret, hs, with("iss", "other")) },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:  "audience in array",
+			opts:  append([]Option{Audience("review")}, hsOnly...),
+			token: func(t *testing.T) string { return signHS256(t, secret, hs, with("aud", []string{"other", "review"})) },
+		},
+		{
+			name:    "unexpected audience",
+			opts:    append([]Option{Audience("review")}, hsOnly...),
+			token:   func(t *testing.T) string { return signHS256(t, secret, hs, with("aud", "other")) },
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := New(tt.opts...)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			v.now = func() time.Time { return _testNow }
+
+			claims, err := v.Verify(tt.token(t))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("want %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.String("sub") != "u1" {
+				t.Errorf("sub = %q, want u1", claims.String("sub"))
+			}
+		})
+	}
+}
+
+func TestNewRequiresKeys(t *testing.T) {
+	if _, err := New(Issuer("idp")); err == nil {
+		t.Fatal("New without keys must fail")
+	}
+}
@@ -0,0 +1,49 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"time"
+)
+
+// Option - verifier option type.
+type Option func(*Verifier)
+
+// HS256Secret enables HS256 tokens signed with secret.
+func HS256Secret(secret []byte) Option {
+	return func(v *Verifier) {
+		v.secret = secret
+	}
+}
+
+// RSAKeys enables RS256 tokens signed with one of keys, indexed by key id.
+func RSAKeys(keys map[string]*rsa.PublicKey) Option {
+	return func(v *Verifier) {
+		if v.rsaKeys == nil {
+			v.rsaKeys = make(map[string]*rsa.PublicKey, len(keys))
+		}
+		for kid, key := range keys {
+			v.rsaKeys[kid] = key
+		}
+	}
+}
+
+// Issuer sets required iss claim.
+func Issuer(issuer string) Option {
+	return func(v *Verifier) {
+		v.issuer = issuer
+	}
+}
+
+// Audience sets audience required in aud claim.
+func Audience(audience string) Option {
+	return func(v *Verifier) {
+		v.audience = audience
+	}
+}
+
+// Leeway sets allowed clock skew for exp and nbf.
+func Leeway(leeway time.Duration) Option {
+	return func(v *Verifier) {
+		v.leeway = leeway
+	}
+}